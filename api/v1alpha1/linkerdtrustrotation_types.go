@@ -18,8 +18,18 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// ControlAnnotationPrefix prefixes the CR annotations that steer the operator at runtime.
+// Changing any of them triggers an immediate reconcile, unlike other metadata-only edits.
+const ControlAnnotationPrefix = "control.rotation.linkerd.edenlab.io/"
+
+// VerifyAnnotation, while set on the CR, turns reconciles into a read-only health probe: the trust secrets and
+// bundle are inspected and the mesh-wide linkerd check is run once per annotation value, with the findings in
+// status.verification. Nothing is rotated, even on overlap; remove the annotation to resume.
+const VerifyAnnotation = ControlAnnotationPrefix + "verify"
+
 // RotationTrigger defines the conditions that initiate a trust rotation.
 // Rotation can be triggered when the trust-roots ConfigMap changes and/or
 // when the current and previous trust anchor secrets diverge. Both conditions
@@ -32,6 +42,19 @@ type RotationTrigger struct {
 	// OnTrustAnchorSecretsDiff, if true, triggers rotation only when the current
 	// trust-anchor Secret(s) differ from the previously observed state (current != previous)ю
 	OnTrustAnchorSecretsDiff bool `json:"onTrustAnchorSecretsDiff"`
+
+	// DivergenceGracePeriod debounces secret divergence (e.g. "1m"): rotation starts only after the
+	// secrets have differed for this long (and, with the ConfigMap trigger, the bundle shows overlap),
+	// covering the window where trust-manager/cert-manager update the resources at slightly different times.
+	// +optional
+	DivergenceGracePeriod *metav1.Duration `json:"divergenceGracePeriod,omitempty"`
+
+	// BundlePropagationTimeout bounds how long the secrets may differ before trust-manager publishes the
+	// overlap to the trust-roots ConfigMap (e.g. "5m"). Past it the CR reports BundlePropagationTimeout and a
+	// Warning event. When set with only onTrustAnchorSecretsDiff (and no manageTrustBundle), restarts also
+	// wait for the overlap instead of starting on the secret divergence alone.
+	// +optional
+	BundlePropagationTimeout *metav1.Duration `json:"bundlePropagationTimeout,omitempty"`
 }
 
 // RolloutSpec defines how workloads should be restarted during trust rotation.
@@ -42,8 +65,205 @@ type RotationTrigger struct {
 type RolloutSpec struct {
 	// Workload selection by pod-template annotation and per-kind scoping.
 	TargetAnnotationSelector TargetAnnotationSelector `json:"targetAnnotationSelector"`
+
+	// AnnotatePlanHash, if true, mirrors the current data-plane plan hash onto the CR metadata
+	// as "rotation.linkerd.edenlab.io/plan-hash", so GitOps tooling can detect plan drift.
+	// +optional
+	AnnotatePlanHash bool `json:"annotatePlanHash,omitempty"`
+
+	// SkipOwnedWorkloads, if true, drops selected workloads whose controlling OwnerReference points to
+	// another selected workload or to a kind listed in targets, so only the top-level workload is restarted.
+	// +optional
+	SkipOwnedWorkloads bool `json:"skipOwnedWorkloads,omitempty"`
+
+	// SkipUpToDateWorkloads, if true, skips Deployments, StatefulSets and DaemonSets whose pods all started
+	// after the trust-roots ConfigMap was last updated (i.e. they already loaded the overlap bundle).
+	// Costs one pod list per workload.
+	// +optional
+	SkipUpToDateWorkloads bool `json:"skipUpToDateWorkloads,omitempty"`
+
+	// NamespaceOptOutKey, if set, skips every workload in a namespace whose Namespace object carries this
+	// label or annotation with the value "true", even if the namespace is listed in allowedNamespaces.
+	// It lets namespace owners exclude themselves temporarily (e.g. during maintenance).
+	// Requires permission to list namespaces.
+	// +optional
+	NamespaceOptOutKey string `json:"namespaceOptOutKey,omitempty"`
+
+	// OnlyKinds, if set, restricts this run of the data-plane rollout to the listed kinds (e.g. only
+	// Deployments, deferring StatefulSets to a manual window) without editing the targets. While set, the
+	// rotation holds before cleanup; clear it to roll the remaining kinds (restarted workloads are not
+	// restarted again) and finish the rotation.
+	// +kubebuilder:validation:items:Enum=Deployment;StatefulSet;DaemonSet;CustomResource
+	// +optional
+	OnlyKinds []string `json:"onlyKinds,omitempty"`
+
+	// DirectPodDelete, if true, makes rolloutDelete delete pods directly instead of evicting them,
+	// bypassing PodDisruptionBudgets. Only use it for workloads without PDBs.
+	// +optional
+	DirectPodDelete bool `json:"directPodDelete,omitempty"`
+
+	// AnnotateRotationID, if true, stamps the pod template (or CR) of every bumped workload with
+	// the rotation ID from status.rotationID, so restarted pods can be traced back to a rotation.
+	// +optional
+	AnnotateRotationID bool `json:"annotateRotationID,omitempty"`
+
+	// RotationIDAnnotationKey overrides the rotation ID annotation key
+	// (default: "rotation.linkerd.edenlab.io/rotation-id").
+	// +optional
+	RotationIDAnnotationKey string `json:"rotationIDAnnotationKey,omitempty"`
+
+	// AnnotateFailures, if true, stamps a workload that failed to roll with the metadata annotations
+	// "rotation.linkerd.edenlab.io/last-error" (short reason) and "rotation.linkerd.edenlab.io/last-error-at".
+	// Both are removed once the workload rolls successfully.
+	// +optional
+	AnnotateFailures bool `json:"annotateFailures,omitempty"`
+
+	// ProgressRounding selects how status.progress.dataPlanePercent is rounded: Nearest (default)
+	// or Floor, which never overstates progress. Either way 100 is only reported once all workloads are done.
+	// +kubebuilder:validation:Enum=Nearest;Floor
+	// +optional
+	ProgressRounding ProgressRounding `json:"progressRounding,omitempty"`
+
+	// RestartAnnotationValue selects the value written to the restart annotation of data-plane workloads:
+	// Timestamp (default) bumps it to the current time, Fingerprint sets it to the current trust anchor
+	// fingerprint (status.trust.currentFP, suffixed with "-cleanup" for the re-triggered rollout after cleanup).
+	// Fingerprint values are stable per rotation, so a repeated restart of an already restarted workload is a no-op.
+	// +kubebuilder:validation:Enum=Timestamp;Fingerprint
+	// +optional
+	RestartAnnotationValue RestartAnnotationValue `json:"restartAnnotationValue,omitempty"`
+
+	// VerifyPodAnnotation, if set, checks after each Deployment, StatefulSet or DaemonSet has rolled out
+	// that all of its live pods carry the given annotation value, failing the workload on any mismatch.
+	// +optional
+	VerifyPodAnnotation *PodAnnotationCheck `json:"verifyPodAnnotation,omitempty"`
+
+	// TierOrder, if set, sorts the data-plane queue by the integer tier in each workload's TierLabelKey
+	// label (or annotation), where tier 0 are leaf dependencies such as backends: LeavesFirst restarts
+	// the lowest tier first, LeavesLast restarts it last. Workloads without a tier go last and ties keep
+	// the selection order. The order is part of the plan hash.
+	// +kubebuilder:validation:Enum=LeavesFirst;LeavesLast
+	// +optional
+	TierOrder TierOrder `json:"tierOrder,omitempty"`
+
+	// TierLabelKey overrides the tier label/annotation key (default: "rotation.linkerd.edenlab.io/tier").
+	// +optional
+	TierLabelKey string `json:"tierLabelKey,omitempty"`
+
+	// ApprovedPlanHash, if set, is the reviewed data-plane plan (status.dryRunPlanHash of a dry run).
+	// A rotation only restarts anything while the computed plan hash still matches it; otherwise it
+	// pauses in AwaitingApproval with the new plan in status.dryRunPlan until the hash is updated.
+	// +optional
+	ApprovedPlanHash string `json:"approvedPlanHash,omitempty"`
+
+	// CanarySample, if set, restarts only this many workloads (absolute or percentage of the queue, rounded up)
+	// first, always running the linkerd proxy check on them, and then pauses in Verifying until the canary
+	// is approved via CanaryApprovedRotation. The rest of the queue then resumes from the cursor.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	CanarySample *intstr.IntOrString `json:"canarySample,omitempty"`
+
+	// CanaryApprovedRotation approves the canary sample of a rotation: set it to status.rotationID
+	// to continue with the rest of the data plane.
+	// +optional
+	CanaryApprovedRotation string `json:"canaryApprovedRotation,omitempty"`
+
+	// ReconcileBudget bounds how long one reconcile keeps restarting data-plane workloads before it yields
+	// and requeues, resuming from the cursor, so a large rotation doesn't hold a worker for hours and spec
+	// changes are seen in between (default: 10m). The workload in progress is always finished.
+	// +optional
+	ReconcileBudget *metav1.Duration `json:"reconcileBudget,omitempty"`
+
+	// MaxWorkloadsPerReconcile, if set, also yields after this many workloads were restarted in one reconcile.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxWorkloadsPerReconcile int `json:"maxWorkloadsPerReconcile,omitempty"`
+
+	// OngoingRolloutPolicy selects what happens to a Deployment that is already mid-rollout (generation not yet
+	// observed or Progressing towards a new ReplicaSet, e.g. after a concurrent deploy) when its turn comes:
+	// Wait waits for that rollout to finish before restarting it, Skip defers it to a later reconcile (the
+	// rest of the queue goes on and the cursor keeps it pending), and Proceed (default) restarts it right away,
+	// stacking another rollout on top.
+	// +kubebuilder:validation:Enum=Wait;Skip;Proceed
+	// +optional
+	OngoingRolloutPolicy OngoingRolloutPolicy `json:"ongoingRolloutPolicy,omitempty"`
+}
+
+// PodAnnotationCheck defines a pod annotation expected on restarted pods.
+type PodAnnotationCheck struct {
+	// Annotation key to check (e.g. "rotation.linkerd.edenlab.io/rotation-id")
+	Key string `json:"key"`
+
+	// Expected annotation value. It is a Go template with {{.CurrentFP}} (status.trust.currentFP)
+	// and {{.RotationID}} (status.rotationID) available.
+	Value string `json:"value"`
 }
 
+// ProgressRounding defines how the data-plane progress percentage is rounded.
+type ProgressRounding string
+
+const (
+	ProgressRoundingNearest ProgressRounding = "Nearest"
+	ProgressRoundingFloor   ProgressRounding = "Floor"
+)
+
+// RestartAnnotationValue defines the value written to the restart annotation of data-plane workloads.
+type RestartAnnotationValue string
+
+const (
+	RestartAnnotationValueTimestamp   RestartAnnotationValue = "Timestamp"
+	RestartAnnotationValueFingerprint RestartAnnotationValue = "Fingerprint"
+)
+
+// IssuerStrategy defines how a managed identity issuer secret is handled around the control-plane restart.
+type IssuerStrategy string
+
+const (
+	IssuerStrategyDeleteThenRestart IssuerStrategy = "DeleteThenRestart"
+	IssuerStrategyRestartOnly       IssuerStrategy = "RestartOnly"
+	IssuerStrategyRestartThenDelete IssuerStrategy = "RestartThenDelete"
+)
+
+// TierOrder defines in which direction data-plane tiers are restarted.
+type TierOrder string
+
+const (
+	TierOrderLeavesFirst TierOrder = "LeavesFirst"
+	TierOrderLeavesLast  TierOrder = "LeavesLast"
+)
+
+// CheckMode defines how the linkerd check is executed.
+type CheckMode string
+
+const (
+	CheckModeJob                CheckMode = "Job"
+	CheckModeEphemeralContainer CheckMode = "EphemeralContainer"
+)
+
+// ProxyCheckScope defines when the data-plane proxy check runs.
+type ProxyCheckScope string
+
+const (
+	ProxyCheckScopePerWorkload ProxyCheckScope = "PerWorkload"
+	ProxyCheckScopeOnce        ProxyCheckScope = "Once"
+)
+
+// OngoingRolloutPolicy defines how a Deployment that is already mid-rollout is handled before its restart.
+type OngoingRolloutPolicy string
+
+const (
+	OngoingRolloutPolicyWait    OngoingRolloutPolicy = "Wait"
+	OngoingRolloutPolicySkip    OngoingRolloutPolicy = "Skip"
+	OngoingRolloutPolicyProceed OngoingRolloutPolicy = "Proceed"
+)
+
+// FingerprintMode defines which certificates of a trust anchor PEM bundle are fingerprinted.
+type FingerprintMode string
+
+const (
+	FingerprintModeChain  FingerprintMode = "Chain"
+	FingerprintModeAnchor FingerprintMode = "Anchor"
+)
+
 // ProtectionSpec defines validation and guard settings for the rotation process.
 // It controls when rollouts can start, what checks are performed during rollout,
 // the readiness threshold required for data-plane convergence, how long to wait
@@ -57,10 +277,75 @@ type ProtectionSpec struct {
 	// +optional
 	LinkerdCheckProxyImage string `json:"linkerdCheckProxyImage,omitempty"`
 
+	// LinkerdCheckCommand overrides the entrypoint of the check container (e.g. for wrapper images).
+	// Items are Go templates with {{.TargetNs}}, {{.LinkerdNs}} and {{.ControlPlane}} available.
+	// +optional
+	LinkerdCheckCommand []string `json:"linkerdCheckCommand,omitempty"`
+
+	// LinkerdCheckArgs overrides the `linkerd check` arguments of the check container.
+	// Items are templated like LinkerdCheckCommand. Defaults to the standard `check [--proxy]` arguments.
+	// +optional
+	LinkerdCheckArgs []string `json:"linkerdCheckArgs,omitempty"`
+
+	// CheckMode selects how `linkerd check` runs: as a Job (default) or as an ephemeral container
+	// attached to a running meshed pod. EphemeralContainer avoids Job scheduling and image-pull
+	// overhead, but the check then runs with the target pod's service account.
+	// +kubebuilder:validation:Enum=Job;EphemeralContainer
+	// +optional
+	CheckMode CheckMode `json:"checkMode,omitempty"`
+
+	// CheckJobNamespace is where `linkerd check` Jobs are created (default: linkerd.namespace), e.g. a dedicated
+	// namespace with its own RBAC where creating Jobs in the Linkerd namespace is not allowed. The check still
+	// targets the control plane in linkerd.namespace. The linkerd-check ServiceAccount must exist there.
+	// +optional
+	CheckJobNamespace string `json:"checkJobNamespace,omitempty"`
+
+	// MaxConcurrentCheckJobs caps how many `linkerd check` Jobs this CR keeps outstanding at once
+	// (default: 3). The cap is per CR: rotations of other CRs have their own.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentCheckJobs int32 `json:"maxConcurrentCheckJobs,omitempty"`
+
+	// KeepFailedCheckJobs, if set, preserves the last N failed `linkerd check` Jobs of this CR for inspection:
+	// failed Jobs lose their TTL and are labeled trust-anchor.linkerd.edenlab.io/preserved-check, and older
+	// preserved Jobs beyond N are deleted. Successful Jobs are still cleaned up. 0 (default) keeps none.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepFailedCheckJobs int32 `json:"keepFailedCheckJobs,omitempty"`
+
+	// PersistPlanToConfigMap, if true, records the data-plane plan of every rotation in a ConfigMap named
+	// <cr>-plan-<timestamp> when it is first computed: the plan and its hash, the rotation ID, the trust
+	// anchor fingerprints and the effective config. The ConfigMap is immutable and owned by the CR when
+	// both live in the same namespace.
+	// +optional
+	PersistPlanToConfigMap bool `json:"persistPlanToConfigMap,omitempty"`
+
+	// PlanConfigMapNamespace is where plan ConfigMaps are written (default: the CR's namespace).
+	// +optional
+	PlanConfigMapNamespace string `json:"planConfigMapNamespace,omitempty"`
+
+	// PlanConfigMapRetention is how many of the CR's plan ConfigMaps are kept; older ones are deleted (default: 10).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PlanConfigMapRetention int32 `json:"planConfigMapRetention,omitempty"`
+
+	// ProxyCheckScope selects when `linkerd check --proxy` runs if runLinkerdCheckProxy is set: PerWorkload
+	// (default) after each restarted workload, or Once as a single mesh-wide check after the whole data plane
+	// is rolled and before cleanup. A failed Once check fails the rotation and keeps the previous anchor, leaving
+	// the mesh in the recoverable overlap state. The canary sample is always checked per workload.
+	// +kubebuilder:validation:Enum=PerWorkload;Once
+	// +optional
+	ProxyCheckScope ProxyCheckScope `json:"proxyCheckScope,omitempty"`
+
 	// Delay before starting rollouts after detecting change (e.g. "30s")
 	// +optional
 	BeforeRolloutDelay *metav1.Duration `json:"beforeRolloutDelay,omitempty"`
 
+	// Pause between the control-plane restart and the data-plane rollout, letting identity
+	// stabilize (e.g. "2m"). Not repeated when a retry resumes at the data plane.
+	// +optional
+	BetweenPhasesDelay *metav1.Duration `json:"betweenPhasesDelay,omitempty"`
+
 	// RetriggerRolloutAfterCleanup runs an additional restart after trust cleanup,
 	// ensuring proxies reload only the new trust anchor.
 	// +optional
@@ -71,8 +356,86 @@ type ProtectionSpec struct {
 	// +optional
 	HoldAfterCleanup *metav1.Duration `json:"holdAfterCleanup,omitempty"`
 
+	// StabilizationPeriod is how long a workload must stay ready after its rollout
+	// completes before the next workload is started (e.g. "30s").
+	// +optional
+	StabilizationPeriod *metav1.Duration `json:"stabilizationPeriod,omitempty"`
+
+	// WorkloadTimeout sizes the per-workload rollout timeout as base + perReplica * desiredReplicas, capped at max.
+	// +optional
+	WorkloadTimeout *WorkloadTimeoutSpec `json:"workloadTimeout,omitempty"`
+
 	// Maximum number of allowed failures before aborting rotation
 	MaxRolloutFailures int `json:"maxRolloutFailures"`
+
+	// RollbackOnFailure, if true, returns to the previous trust anchor once the rotation has failed for good
+	// (maxRolloutFailures exceeded): the previous anchor is restored as current, the new one is saved to the
+	// secret <trustAnchorSecret>-rolled-back, the bundle keeps both anchors, and the issuer is re-issued and
+	// the control plane restarted from it. Misconfigurations are not rolled back, as fixing the spec resumes
+	// the rotation.
+	// Rollback restarts workloads itself and does not undo data-plane restarts, so proxies already restarted
+	// keep certificates from the new anchor until they renew; it does return the mesh to a consistent anchor.
+	// Only used by the control-plane owner; not supported in single-secret mode.
+	// +optional
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+
+	// PostRotationTrigger, if set, notifies downstream tooling once a rotation succeeded, e.g. by annotating
+	// an Argo CD Application or Flux Kustomization so it reconciles manifests depending on the new anchor.
+	// A failing trigger only emits a Warning event; the rotation stays succeeded.
+	// +optional
+	PostRotationTrigger *PostRotationTriggerSpec `json:"postRotationTrigger,omitempty"`
+}
+
+// PostRotationTriggerSpec defines what is notified after a successful rotation.
+// If both Target and WebhookURL are set, both are triggered.
+type PostRotationTriggerSpec struct {
+	// Target is an object whose annotation is set after the rotation.
+	// +optional
+	Target *PostRotationTarget `json:"target,omitempty"`
+
+	// WebhookURL receives a POST with the CR namespace/name, rotation ID and current anchor fingerprint.
+	// +optional
+	WebhookURL string `json:"webhookURL,omitempty"`
+}
+
+// PostRotationTarget identifies the object and annotation set after a rotation.
+// E.g. argoproj.io/v1alpha1 Application with "argocd.argoproj.io/refresh: hard", or
+// kustomize.toolkit.fluxcd.io/v1 Kustomization with "reconcile.fluxcd.io/requestedAt".
+type PostRotationTarget struct {
+	// API group of the target (empty for the core group)
+	// +optional
+	APIGroup string `json:"apiGroup,omitempty"`
+
+	Version string `json:"version"`
+
+	Kind string `json:"kind"`
+
+	Namespace string `json:"namespace"`
+
+	Name string `json:"name"`
+
+	// Annotation key to set on the target
+	AnnotationKey string `json:"annotationKey"`
+
+	// Annotation value; a Go template with {{.RotationID}} and {{.CurrentFP}} available
+	// (default: "{{.RotationID}}", which changes with every rotation).
+	// +optional
+	AnnotationValue string `json:"annotationValue,omitempty"`
+}
+
+// WorkloadTimeoutSpec defines the coefficients of the per-workload rollout timeout.
+type WorkloadTimeoutSpec struct {
+	// Fixed part of the timeout (default: "3m")
+	// +optional
+	Base *metav1.Duration `json:"base,omitempty"`
+
+	// Additional time per desired replica (default: "30s")
+	// +optional
+	PerReplica *metav1.Duration `json:"perReplica,omitempty"`
+
+	// Upper bound of the computed timeout (default: "30m")
+	// +optional
+	Max *metav1.Duration `json:"max,omitempty"`
 }
 
 // TargetAnnotationSelector defines how to select workloads that should be restarted.
@@ -84,10 +447,30 @@ type TargetAnnotationSelector struct {
 	// Expected value (e.g., "enabled")
 	Value string `json:"value"`
 
+	// Values lists further accepted values (e.g. ["ingress"] next to value "enabled"); a workload matches
+	// if the annotation holds value or any of these.
+	// +optional
+	Values []string `json:"values,omitempty"`
+
+	// MatchLocation selects where the annotation is looked up: on the pod template (PodTemplate, default,
+	// Linkerd's convention), on the workload's own metadata (Metadata), or on either of them (Either).
+	// +kubebuilder:validation:Enum=PodTemplate;Metadata;Either
+	// +optional
+	MatchLocation MatchLocation `json:"matchLocation,omitempty"`
+
 	// Per-kind map: key is Kind (e.g., "Deployment", "StatefulSet", "DaemonSet", or custom kinds).
 	Targets []TargetScope `json:"targets"`
 }
 
+// MatchLocation defines where the target annotation is looked up on a workload.
+type MatchLocation string
+
+const (
+	MatchLocationPodTemplate MatchLocation = "PodTemplate"
+	MatchLocationMetadata    MatchLocation = "Metadata"
+	MatchLocationEither      MatchLocation = "Either"
+)
+
 // TargetScope defines scope for a particular Kind.
 type TargetScope struct {
 	// Type of Kubernetes resources (e.g. "Deployment", "StatefulSet", "DaemonSet", "CustomResource")
@@ -97,11 +480,19 @@ type TargetScope struct {
 	// Whitelist of namespaces for this Kind.
 	AllowedNamespaces []string `json:"allowedNamespaces"`
 
-	// Rollout strategy (e.g. "rolloutRestart", "rolloutDelete")
+	// Rollout strategy (e.g. "rolloutRestart", "rolloutDelete"); rolloutDelete is only supported for StatefulSet, Deployment
+	// and DaemonSet. DaemonSets are then restarted in waves of rollingUpdate.maxUnavailable pods, also with OnDelete.
+	// A single workload can override it with the "rotation.linkerd.edenlab.io/strategy" annotation on itself or
+	// its pod template.
 	// +kubebuilder:validation:Enum=rolloutRestart;rolloutDelete
 	// +optional
 	RolloutStrategy string `json:"rolloutStrategy,omitempty"`
 
+	// Priority orders the scopes' workloads in the data-plane queue: lower priorities are restarted first
+	// and scopes with equal priority keep their declaration order (default: 0). It is part of the plan hash.
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
 	// Optional G/V for custom kinds. Built-ins default to apps/v1.
 	// +optional
 	APIGroup string `json:"apiGroup,omitempty"`
@@ -115,6 +506,54 @@ type TargetScope struct {
 	// Options for the rolloutRestart.
 	// +optional
 	AnnotationBump *AnnotationBumpOptions `json:"annotationBump,omitempty"`
+
+	// RunLinkerdCheckProxy, if set, overrides protection.runLinkerdCheckProxy for this scope.
+	// +optional
+	RunLinkerdCheckProxy *bool `json:"runLinkerdCheckProxy,omitempty"`
+
+	// PreDeleteHook, if set, must green-light each pod before it is deleted by rolloutDelete.
+	// Only used for StatefulSets with rolloutStrategy=rolloutDelete.
+	// +optional
+	PreDeleteHook *PreDeleteHookSpec `json:"preDeleteHook,omitempty"`
+
+	// MinAvailable is how many replicas (absolute or percentage of desired) must be ready before
+	// a Deployment, StatefulSet or DaemonSet of this scope is restarted (default: all). A workload
+	// below it is failed instead of being restarted, so a degraded workload isn't made worse.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// ReadyExpression is a CEL expression evaluated against the custom resource as `self` that must
+	// return true once the CR is ready (e.g. `self.status.readyReplicas == self.spec.replicas`).
+	// It replaces the default status.readyPods == status.pods check. Only used for CustomResource scopes.
+	// +optional
+	ReadyExpression string `json:"readyExpression,omitempty"`
+
+	// ReadyConditions lists the pod condition types that must all be True before a pod recreated by
+	// rolloutDelete counts as ready (default: [Ready]). Use it for pods with readiness gates or custom
+	// conditions. Only used with rolloutStrategy=rolloutDelete.
+	// +optional
+	ReadyConditions []string `json:"readyConditions,omitempty"`
+}
+
+// PreDeleteHookSpec defines how the operator confirms a pod is safe to delete.
+// If both WebhookURL and Annotation are set, both must pass.
+type PreDeleteHookSpec struct {
+	// WebhookURL receives a POST with the pod namespace/name; a 2xx response green-lights the delete.
+	// +optional
+	WebhookURL string `json:"webhookURL,omitempty"`
+
+	// Annotation on the pod to wait for before the delete (e.g. "example.com/safe-to-restart").
+	// +optional
+	Annotation string `json:"annotation,omitempty"`
+
+	// Expected value of Annotation (default: "true")
+	// +optional
+	AnnotationValue string `json:"annotationValue,omitempty"`
+
+	// How long to wait for the hook to green-light a pod before failing the workload (default: "5m")
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // AnnotationBumpOptions customizes how the annotation bump is applied.
@@ -129,6 +568,14 @@ type AnnotationBumpOptions struct {
 	BumpAnnotationValue string `json:"value,omitempty"`
 }
 
+// BootstrapMode defines when the previous trust secret is bootstrapped from the current one.
+type BootstrapMode string
+
+const (
+	BootstrapModeIfMissing          BootstrapMode = "IfMissing"
+	BootstrapModeIfMissingOrInvalid BootstrapMode = "IfMissingOrInvalid"
+)
+
 type LinkerdSpec struct {
 	// Namespace where Linkerd control-plane is installed
 	Namespace string `json:"namespace"`
@@ -142,6 +589,134 @@ type LinkerdSpec struct {
 	// during the first bootstrap if it does not exist.
 	// If false, the operator assumes it is already provisioned.
 	BootstrapPreviousSecret bool `json:"bootstrapPreviousSecret"`
+
+	// BootstrapMode controls when the previous trust secret is (re)created from the current one.
+	// IfMissing (default) only creates it when absent; IfMissingOrInvalid also recreates an existing
+	// previous secret whose certificate fails validation. A valid previous secret is never overwritten.
+	// Ignored unless BootstrapPreviousSecret is true.
+	// +kubebuilder:validation:Enum=IfMissing;IfMissingOrInvalid
+	// +optional
+	BootstrapMode BootstrapMode `json:"bootstrapMode,omitempty"`
+
+	// BootstrapOnly, if true, makes a reconcile that bootstraps the previous trust secret stop there:
+	// it reports the single bundle state as a clean baseline and requeues without evaluating divergence.
+	// The next anchor change then triggers a normal rotation. Ignored unless BootstrapPreviousSecret is true.
+	// +optional
+	BootstrapOnly bool `json:"bootstrapOnly,omitempty"`
+
+	// OwnBootstrappedSecret, if true, sets the CR as owner of the previous secret the operator bootstraps,
+	// so it is garbage-collected with the CR. Off by default: the anchor usually outlives the CR.
+	// Only applies when the CR lives in the Linkerd namespace.
+	// +optional
+	OwnBootstrappedSecret bool `json:"ownBootstrappedSecret,omitempty"`
+
+	// BootstrapTimeout bounds how long creating the previous trust secret is retried on transient
+	// API errors and how long the operator waits for it to become readable (default: "3s").
+	// +optional
+	BootstrapTimeout *metav1.Duration `json:"bootstrapTimeout,omitempty"`
+
+	// CurrentSecretTimeout bounds how long the operator waits for TrustAnchorSecret to exist (e.g. on a freshly
+	// installed cluster) before failing the reconcile (default: "10m"). Until then it requeues in Detecting
+	// with reason AwaitingCurrentSecret.
+	// +optional
+	CurrentSecretTimeout *metav1.Duration `json:"currentSecretTimeout,omitempty"`
+
+	// FingerprintMode selects what the trust anchor fingerprints are computed from: Chain (default) hashes
+	// all certificates in the secret, Anchor hashes only the self-signed root so fingerprints and divergence
+	// are stable against chain reordering and intermediate changes.
+	// +kubebuilder:validation:Enum=Chain;Anchor
+	// +optional
+	FingerprintMode FingerprintMode `json:"fingerprintMode,omitempty"`
+
+	// FingerprintShortLength, if set, shortens status.trust.currentFP and previousFP to the first N hex characters
+	// of the fingerprint (e.g. 16) for readable `kubectl get` output. The full fingerprints stay in
+	// status.trust.currentFullFP and previousFullFP and are what rotations compare. 0 (default) shows them in full.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FingerprintShortLength int32 `json:"fingerprintShortLength,omitempty"`
+
+	// MinCerts and MaxCerts, if set, bound the number of certificates a trust anchor secret may hold
+	// (usually exactly one); a secret outside the bounds is rejected before it drives a rotation.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinCerts int32 `json:"minCerts,omitempty"`
+
+	// MaxCerts is the maximum number of certificates a trust anchor secret may hold (see MinCerts).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxCerts int32 `json:"maxCerts,omitempty"`
+
+	// SingleSecretMode, if set, reads both the current and the previous anchors
+	// from TrustAnchorSecret under separate keys; PreviousTrustAnchorSecret is ignored.
+	// BootstrapPreviousSecret and BootstrapMode then apply to the previous key.
+	// +optional
+	SingleSecretMode *SingleSecretModeSpec `json:"singleSecretMode,omitempty"`
+
+	// ManageTrustBundle, if true, makes the operator write the overlap bundle (current + previous anchors)
+	// into TrustRootsConfigMap before restarts and prune it back to the current anchor after cleanup.
+	// Use it in clusters without trust-manager. Requires trigger.onTrustAnchorSecretsDiff.
+	// +optional
+	ManageTrustBundle bool `json:"manageTrustBundle,omitempty"`
+
+	// ControlPlaneOwner marks the CR that deletes the issuer secret, restarts the shared control plane
+	// and cleans up the previous anchor (default: true). With several CRs per Linkerd installation
+	// (e.g. one per tenant), set it to false on all but one: the others only roll their own data plane
+	// once the owner has restarted the control plane, and the owner cleans up after all of them are done.
+	// +optional
+	ControlPlaneOwner *bool `json:"controlPlaneOwner,omitempty"`
+
+	// ManagedIssuer marks the identity issuer secret as re-issued by cert-manager (default: true): the operator
+	// deletes it before the control-plane restart so it is re-issued from the new anchor. Set it to false for
+	// an external issuer (e.g. Vault): the secret is left alone, the control plane is only restarted, and a
+	// warning is emitted if the issuer does not chain to the current anchor.
+	// +optional
+	ManagedIssuer *bool `json:"managedIssuer,omitempty"`
+
+	// IssuerStrategy selects how a managed issuer is handled around the control-plane restart:
+	// DeleteThenRestart (default) deletes the issuer secret and restarts once it is re-issued, RestartOnly
+	// restarts without deleting it, and RestartThenDelete restarts first and deletes it afterwards.
+	// The latter two then wait up to 2m for the issuer to chain to the current anchor (when
+	// trigger.onTrustAnchorSecretsDiff is set), failing the rotation otherwise.
+	// +kubebuilder:validation:Enum=DeleteThenRestart;RestartOnly;RestartThenDelete
+	// +optional
+	IssuerStrategy IssuerStrategy `json:"issuerStrategy,omitempty"`
+
+	// VerifyIssuerChain, if true, verifies that the re-issued identity issuer certificate chains to the
+	// current trust anchor before the control plane restarts, failing the rotation otherwise.
+	// Requires trigger.onTrustAnchorSecretsDiff.
+	// +optional
+	VerifyIssuerChain bool `json:"verifyIssuerChain,omitempty"`
+
+	// VerifyAnchorOrder, if true (default), refuses to rotate when the diverged current trust anchor was issued
+	// (notBefore) before the previous one, e.g. because the secrets were swapped, instead of rotating back to
+	// an older anchor. Set it to false for setups that deliberately install an older anchor.
+	// +optional
+	VerifyAnchorOrder *bool `json:"verifyAnchorOrder,omitempty"`
+
+	// TrustRootsConfigMapKeys lists the TrustRootsConfigMap keys (or glob patterns, e.g. "*.crt") whose
+	// PEM contents are merged into one bundle before inspection (default: ["ca-bundle.crt"]).
+	// The overlap written by manageTrustBundle always goes to "ca-bundle.crt".
+	// +optional
+	TrustRootsConfigMapKeys []string `json:"trustRootsConfigMapKeys,omitempty"`
+
+	// ControlPlaneRestartOrder lists control-plane components in the order they are restarted, each matched
+	// against a Deployment's linkerd.io/control-plane-component label or its name (e.g. ["identity",
+	// "destination", "proxy-injector"]). Unlisted Deployments are restarted last, alphabetically
+	// (default: ["identity"]).
+	// +optional
+	ControlPlaneRestartOrder []string `json:"controlPlaneRestartOrder,omitempty"`
+}
+
+// SingleSecretModeSpec defines the keys holding the current and previous anchors
+// when both live in the same secret.
+type SingleSecretModeSpec struct {
+	// Key of the current trust anchor (default: "tls.crt")
+	// +optional
+	CurrentKey string `json:"currentKey,omitempty"`
+
+	// Key of the previous trust anchor (default: "tls-old.crt")
+	// +optional
+	PreviousKey string `json:"previousKey,omitempty"`
 }
 
 // LinkerdTrustRotationSpec defines the desired state of LinkerdTrustRotation
@@ -161,6 +736,16 @@ type LinkerdTrustRotationSpec struct {
 	// Dry-run mode
 	// +optional
 	DryRun bool `json:"dryRun,omitempty"`
+
+	// CommonLabels are added to every object the operator creates (check Jobs, bootstrapped secrets,
+	// managed ConfigMaps), next to app.kubernetes.io/managed-by and the owner labels.
+	// +optional
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// RotationClass selects the operator instance that reconciles this CR: only an operator started with
+	// the same --rotation-class acts on it. Empty (default) matches operators without a class.
+	// +optional
+	RotationClass string `json:"rotationClass,omitempty"`
 }
 
 // ProgressStatus Status
@@ -177,13 +762,21 @@ type TrustStatus struct {
 	// Bundle state: single | overlap
 	BundleState *BundleState `json:"bundleState"`
 
-	// Current trust anchor fingerprint (short SHA256)
+	// Current trust anchor fingerprint (short SHA256, see linkerd.fingerprintShortLength)
 	// +optional
 	CurrentFP string `json:"currentFP,omitempty"`
 
-	// Previous trust anchor fingerprint (short SHA256)
+	// Previous trust anchor fingerprint (short SHA256, see linkerd.fingerprintShortLength)
 	// +optional
 	PreviousFP string `json:"previousFP,omitempty"`
+
+	// CurrentFullFP is the full current trust anchor fingerprint.
+	// +optional
+	CurrentFullFP string `json:"currentFullFP,omitempty"`
+
+	// PreviousFullFP is the full previous trust anchor fingerprint.
+	// +optional
+	PreviousFullFP string `json:"previousFullFP,omitempty"`
 }
 
 // WorkRef is a stable reference to a workload in the plan.
@@ -201,6 +794,11 @@ type RolloutCursor struct {
 	// +optional
 	PlanHash string `json:"planHash,omitempty"`
 
+	// PlanHashVersion is the version of the hashing scheme PlanHash was computed with. After an operator
+	// upgrade that changed the scheme, the hash and index are not comparable and only Completed is used.
+	// +optional
+	PlanHashVersion int `json:"planHashVersion,omitempty"`
+
 	// Index of the next item to process (0..Total). Increments on success.
 	Next int `json:"next"`
 
@@ -210,6 +808,58 @@ type RolloutCursor struct {
 	// Last successfully processed item (for logs/diagnostics).
 	// +optional
 	LastDone *WorkRef `json:"lastDone,omitempty"`
+
+	// Completed lists the workloads restarted in the current rotation. When the plan grows
+	// (its hash changes), these are skipped and only new or remaining workloads are restarted.
+	// +optional
+	Completed []WorkRef `json:"completed,omitempty"`
+}
+
+// WorkloadCounts holds per-kind workload counters.
+type WorkloadCounts struct {
+	Deployments     int `json:"deployments"`
+	StatefulSets    int `json:"statefulSets"`
+	DaemonSets      int `json:"daemonSets"`
+	CustomResources int `json:"customResources"`
+}
+
+// EffectiveConfig is the operator configuration in effect for a CR once defaults are applied.
+type EffectiveConfig struct {
+	// RestartAnnotationKey is the pod template annotation bumped to restart workloads.
+	RestartAnnotationKey string `json:"restartAnnotationKey"`
+
+	// CheckImage is the linkerd CLI image used for `linkerd check`.
+	CheckImage string `json:"checkImage"`
+
+	// CheckMode is how `linkerd check` runs.
+	CheckMode CheckMode `json:"checkMode"`
+
+	// CheckJobNamespace is the namespace `linkerd check` Jobs run in.
+	CheckJobNamespace string `json:"checkJobNamespace"`
+
+	// MaxConcurrentCheckJobs is the cap on outstanding `linkerd check` Jobs.
+	MaxConcurrentCheckJobs int32 `json:"maxConcurrentCheckJobs"`
+
+	// DefaultRolloutStrategy is the strategy used for target scopes without rolloutStrategy.
+	DefaultRolloutStrategy string `json:"defaultRolloutStrategy"`
+
+	// WorkloadTimeoutBase is the base of the per-workload rollout timeout.
+	WorkloadTimeoutBase metav1.Duration `json:"workloadTimeoutBase"`
+
+	// WorkloadTimeoutPerReplica is added to the per-workload rollout timeout for every desired replica.
+	WorkloadTimeoutPerReplica metav1.Duration `json:"workloadTimeoutPerReplica"`
+
+	// WorkloadTimeoutMax caps the per-workload rollout timeout.
+	WorkloadTimeoutMax metav1.Duration `json:"workloadTimeoutMax"`
+
+	// PreDeleteHookTimeout is the default timeout of StatefulSet pre-delete hooks.
+	PreDeleteHookTimeout metav1.Duration `json:"preDeleteHookTimeout"`
+
+	// ReconcileBudget is how long one reconcile restarts data-plane workloads before it yields.
+	ReconcileBudget metav1.Duration `json:"reconcileBudget"`
+
+	// ControlPlaneRestartOrder is the order control-plane components are restarted in.
+	ControlPlaneRestartOrder []string `json:"controlPlaneRestartOrder"`
 }
 
 // RetryStatus Status
@@ -230,6 +880,23 @@ type RetryStatus struct {
 	LastErrorTime *metav1.Time `json:"lastErrorTime,omitempty"`
 }
 
+// VerificationStatus is the outcome of the last verify-only reconcile (see VerifyAnnotation).
+type VerificationStatus struct {
+	// Request is the VerifyAnnotation value this result answers.
+	Request string `json:"request"`
+
+	// Healthy is true when the trust state is consistent and the linkerd check passed.
+	Healthy bool `json:"healthy"`
+
+	// Findings lists the inconsistencies and failed checks.
+	// +optional
+	Findings []string `json:"findings,omitempty"`
+
+	// CompletedAt is when the verification finished.
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
 // LinkerdTrustRotationStatus defines the observed state of LinkerdTrustRotation.
 type LinkerdTrustRotationStatus struct {
 	// Current phase of the rotation process
@@ -244,10 +911,34 @@ type LinkerdTrustRotationStatus struct {
 	// +optional
 	Message *string `json:"message,omitempty"`
 
+	// PhaseEnteredAt is when trackedPhase was entered.
+	// +optional
+	PhaseEnteredAt *metav1.Time `json:"phaseEnteredAt,omitempty"`
+
+	// TrackedPhase is the last phase other than Idle. The Idle reset at the start of every reconcile does not
+	// leave it, so a phase spanning several reconciles is timed from its first entry.
+	// +optional
+	TrackedPhase Phase `json:"trackedPhase,omitempty"`
+
+	// PhaseDurations is the time spent in each phase of the current (or last) rotation, keyed by phase.
+	// Idle and the terminal phases are not timed.
+	// +optional
+	PhaseDurations map[string]metav1.Duration `json:"phaseDurations,omitempty"`
+
 	// Timestamp when rotation started
 	// +optional
 	StartedAt *metav1.Time `json:"startedAt,omitempty"`
 
+	// RotationID identifies the current (or last) rotation attempt; a new one is generated
+	// whenever a rotation starts after the previous one has completed.
+	// +optional
+	RotationID string `json:"rotationID,omitempty"`
+
+	// RotationFP is the full trust anchor fingerprint the current (or last) rotation was started for.
+	// A rotation for another anchor starts fresh instead of resuming the progress of this one.
+	// +optional
+	RotationFP string `json:"rotationFP,omitempty"`
+
 	// Timestamp of the last update
 	// +optional
 	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
@@ -260,10 +951,83 @@ type LinkerdTrustRotationStatus struct {
 	// +optional
 	Progress *ProgressStatus `json:"progress,omitempty"`
 
+	// ControlPlaneDone is set once the issuer secret was deleted and the control plane restarted
+	// in the current rotation, so retries resume at the data plane. Cleared when the rotation succeeds.
+	// +optional
+	ControlPlaneDone bool `json:"controlPlaneDone,omitempty"`
+
+	// ControlPlaneDoneAt is when the control-plane phase of the current rotation completed;
+	// protection.betweenPhasesDelay is counted from it.
+	// +optional
+	ControlPlaneDoneAt *metav1.Time `json:"controlPlaneDoneAt,omitempty"`
+
+	// IssuerSecretDeleted is set once the issuer secret was deleted in the current rotation,
+	// so a retry after a control-plane failure does not delete it again. Cleared when the rotation succeeds.
+	// +optional
+	IssuerSecretDeleted bool `json:"issuerSecretDeleted,omitempty"`
+
+	// RollbackPending is set once a rollback restored the previous anchor as current and cleared once the
+	// control plane was restarted from it, so an interrupted rollback is finished by the next reconcile.
+	// +optional
+	RollbackPending bool `json:"rollbackPending,omitempty"`
+
+	// ControlPlaneRotatedFP is the trust anchor fingerprint the control plane was last restarted for.
+	// Non-owner CRs wait for their control-plane owner to reach their current fingerprint.
+	// +optional
+	ControlPlaneRotatedFP string `json:"controlPlaneRotatedFP,omitempty"`
+
+	// DataPlaneRotatedFP is the trust anchor fingerprint the data plane was last fully rolled for.
+	// +optional
+	DataPlaneRotatedFP string `json:"dataPlaneRotatedFP,omitempty"`
+
+	// MeshProxyCheckedFP is the trust anchor fingerprint the mesh-wide proxy check
+	// (protection.proxyCheckScope=Once) last passed for, so it is not repeated while waiting for cleanup.
+	// +optional
+	MeshProxyCheckedFP string `json:"meshProxyCheckedFP,omitempty"`
+
 	// Trust anchor information
 	// +optional
 	Trust *TrustStatus `json:"trust,omitempty"`
 
+	// DivergedSince is when the current secret divergence was first observed (cleared when the secrets match).
+	// +optional
+	DivergedSince *metav1.Time `json:"divergedSince,omitempty"`
+
+	// CurrentSecretMissingSince is when the current trust anchor secret was first found missing
+	// (cleared once it exists); linkerd.currentSecretTimeout is counted from it.
+	// +optional
+	CurrentSecretMissingSince *metav1.Time `json:"currentSecretMissingSince,omitempty"`
+
+	// CleanedUpAt is when the previous anchor was cleaned up in the current rotation. It is only set while
+	// the protection.holdAfterCleanup timer before the re-triggered data-plane rollout runs.
+	// +optional
+	CleanedUpAt *metav1.Time `json:"cleanedUpAt,omitempty"`
+
+	// LastObservedBundleHash is the hash of the trust-roots bundle fingerprints as of the last settled
+	// state or completed rotation. An overlap bundle with the same hash does not trigger another rotation.
+	// +optional
+	LastObservedBundleHash string `json:"lastObservedBundleHash,omitempty"`
+
+	// LastObservedSecretHash is the hash of the trust anchor secret fingerprints as of the last settled
+	// state or completed rotation.
+	// +optional
+	LastObservedSecretHash string `json:"lastObservedSecretHash,omitempty"`
+
+	// SkippedTriggerCount counts the trust changes that were detected but needed no action (divergence
+	// still within trigger.divergenceGracePeriod, duplicate certificates, state already rotated). A trigger
+	// seen again by later reconciles is counted once. Use it to tune triggers.
+	// +optional
+	SkippedTriggerCount int `json:"skippedTriggerCount,omitempty"`
+
+	// LastSkipReason is why the trigger was last skipped.
+	// +optional
+	LastSkipReason Reason `json:"lastSkipReason,omitempty"`
+
+	// LastSkippedHash identifies the trust state of the last skipped trigger, so reconciles skipping it
+	// again with the same reason do not count it again.
+	// +optional
+	LastSkippedHash string `json:"lastSkippedHash,omitempty"`
+
 	// Number of retries and last error
 	// +optional
 	Retries *RetryStatus `json:"retries,omitempty"`
@@ -272,9 +1036,50 @@ type LinkerdTrustRotationStatus struct {
 	// +optional
 	Cursor *RolloutCursor `json:"cursor,omitempty"`
 
+	// OngoingRolloutsDeferredSince is when rollout.ongoingRolloutPolicy=Skip first deferred a mid-rollout
+	// Deployment in the current data-plane pass; a Deployment still mid-rollout once the workload timeout
+	// has elapsed since then fails instead of being deferred again. Cleared when a pass defers nothing.
+	// +optional
+	OngoingRolloutsDeferredSince *metav1.Time `json:"ongoingRolloutsDeferredSince,omitempty"`
+
 	// DryRunPlan is a human-readable summary of the last dry-run (no changes applied).
 	// +optional
 	DryRunPlan string `json:"dryRunPlan,omitempty"`
+
+	// DryRunPlanHash is the plan hash of DryRunPlan; copy it to spec.rollout.approvedPlanHash to approve the plan.
+	// +optional
+	DryRunPlanHash string `json:"dryRunPlanHash,omitempty"`
+
+	// DryRunEstimatedDuration is the rough duration of the rotation planned by the last dry run: the control
+	// plane, the data-plane queue at a per-workload estimate (from the last rotation, else a default) and the
+	// configured delays. See the comment atop status.dryRunPlan for the breakdown.
+	// +optional
+	DryRunEstimatedDuration *metav1.Duration `json:"dryRunEstimatedDuration,omitempty"`
+
+	// LastReconcileError is the error returned by the last failed reconcile (cleared on success).
+	// +optional
+	LastReconcileError string `json:"lastReconcileError,omitempty"`
+
+	// LastReconcileErrorTime is the timestamp of LastReconcileError.
+	// +optional
+	LastReconcileErrorTime *metav1.Time `json:"lastReconcileErrorTime,omitempty"`
+
+	// PlannedCounts is the number of data-plane workloads per kind in the current plan.
+	// +optional
+	PlannedCounts *WorkloadCounts `json:"plannedCounts,omitempty"`
+
+	// CompletedCounts is the number of data-plane workloads per kind restarted so far in the current plan.
+	// +optional
+	CompletedCounts *WorkloadCounts `json:"completedCounts,omitempty"`
+
+	// EffectiveConfig is the configuration the operator resolved for this CR (after defaults).
+	// Purely informational; refreshed at the start of every reconcile.
+	// +optional
+	EffectiveConfig *EffectiveConfig `json:"effectiveConfig,omitempty"`
+
+	// Verification is the outcome of the last verify-only reconcile.
+	// +optional
+	Verification *VerificationStatus `json:"verification,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -283,6 +1088,7 @@ type LinkerdTrustRotationStatus struct {
 // +kubebuilder:printcolumn:name="ControlPlaneReady",type=boolean,JSONPath=`.status.progress.controlPlaneReady`
 // +kubebuilder:printcolumn:name="DataPlaneProgress%",type=integer,JSONPath=`.status.progress.dataPlanePercent`
 // +kubebuilder:printcolumn:name="BundleState",type=string,JSONPath=`.status.trust.bundleState`
+// +kubebuilder:printcolumn:name="CurrentFP",type=string,JSONPath=`.status.trust.currentFP`,priority=1
 // +kubebuilder:printcolumn:name="LastUpdated",type=date,JSONPath=`.status.lastUpdated`
 
 // LinkerdTrustRotation is the Schema for the linkerdtrustrotations API
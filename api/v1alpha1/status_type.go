@@ -31,6 +31,9 @@ const (
 	// PhaseDryRun indicates a data-plane dry run (plan/validate) with no changes applied
 	PhaseDryRun Phase = "DryRun"
 
+	// PhaseAwaitingApproval — the data-plane plan no longer matches rollout.approvedPlanHash
+	PhaseAwaitingApproval Phase = "AwaitingApproval"
+
 	// PhaseBootstrap — previous secret created/verified (first initialization)
 	PhaseBootstrap Phase = "Bootstrap"
 
@@ -46,7 +49,7 @@ const (
 	// PhaseVerifying — verifying data plane readiness threshold
 	PhaseVerifying Phase = "Verifying"
 
-	// PhaseHold — waiting after cleanup (holdAfterCleanup timer)
+	// PhaseHold — intentionally waiting (beforeRolloutDelay, holdAfterCleanup and betweenPhasesDelay timers, peers)
 	PhaseHold Phase = "Hold"
 
 	// PhaseCleanup — deleting old previous secret, finalizing bundle
@@ -67,6 +70,18 @@ const (
 	// --- Detecting ---
 	ReasonConfigMapChanged Reason = "ConfigMapChanged"
 	ReasonSecretsDiverged  Reason = "SecretsDiverged"
+	// ReasonAwaitingBundleOverlap — secrets diverged, waiting for the trust-roots bundle to contain both anchors
+	ReasonAwaitingBundleOverlap Reason = "AwaitingBundleOverlap"
+	// ReasonDivergenceGracePeriod — secrets diverged, waiting for the divergence grace period to elapse
+	ReasonDivergenceGracePeriod Reason = "DivergenceGracePeriod"
+	// ReasonTrustStateUnchanged — the bundle overlaps, but with the same fingerprints as the last completed rotation
+	ReasonTrustStateUnchanged Reason = "TrustStateUnchanged"
+	// ReasonBundlePropagationTimeout — secrets diverged, but the bundle did not overlap within trigger.bundlePropagationTimeout
+	ReasonBundlePropagationTimeout Reason = "BundlePropagationTimeout"
+	// ReasonDuplicateCertificates — the bundle repeats a certificate, which alone is not an overlap
+	ReasonDuplicateCertificates Reason = "DuplicateCertificates"
+	// ReasonAwaitingCurrentSecret — the current trust anchor secret does not exist yet
+	ReasonAwaitingCurrentSecret Reason = "AwaitingCurrentSecret"
 
 	// --- Bootstrap ---
 	ReasonPreviousCreated   Reason = "PreviousSecretCreated"
@@ -79,17 +94,41 @@ const (
 	// --- RollingControlPlane ---
 	ReasonControlPlaneRestarting Reason = "ControlPlaneRestarting"
 	ReasonControlPlaneReady      Reason = "ControlPlaneReady"
+	// ReasonEnteringDestructivePhase — the identity issuer secret is about to be deleted; the rotation is committing
+	ReasonEnteringDestructivePhase Reason = "EnteringDestructivePhase"
+	// ReasonIssuerAnchorMismatch — the identity issuer does not chain to the current trust anchor
+	ReasonIssuerAnchorMismatch Reason = "IssuerAnchorMismatch"
 
 	// --- RollingDataPlane ---
 	ReasonDataPlaneBatchRestarting  Reason = "DataPlaneBatchRestarting"
 	ReasonDataPlaneThresholdReached Reason = "DataPlaneThresholdReached"
+	ReasonNoWorkloadsMatched        Reason = "NoWorkloadsMatched"
+	// ReasonOngoingRolloutsDeferred — Deployments found mid-rollout were skipped (rollout.ongoingRolloutPolicy=Skip)
+	// and are retried on a later reconcile
+	ReasonOngoingRolloutsDeferred Reason = "OngoingRolloutsDeferred"
 
 	// --- Verifying ---
 	ReasonVerificationSucceeded Reason = "VerificationSucceeded"
 	ReasonVerificationFailed    Reason = "VerificationFailed"
+	// ReasonVerifyOnly — the verify annotation is set; the mesh is checked read-only and nothing is rotated
+	ReasonVerifyOnly Reason = "VerifyOnly"
+	// ReasonMeshProxyCheck — the mesh-wide proxy check runs after the whole data plane was rolled
+	ReasonMeshProxyCheck Reason = "MeshProxyCheck"
+	// ReasonCanaryAwaitingApproval — the canary sample is restarted and checked; waiting for rollout.canaryApprovedRotation
+	ReasonCanaryAwaitingApproval Reason = "CanaryAwaitingApproval"
 
 	// --- Hold ---
 	ReasonHoldTimerRunning Reason = "HoldTimerRunning"
+	// ReasonBeforeRolloutDelay — the rotation started and waits for protection.beforeRolloutDelay before rolling out
+	ReasonBeforeRolloutDelay Reason = "BeforeRolloutDelay"
+	// ReasonAwaitingControlPlaneOwner — a non-owner CR waits for its owner to restart the control plane
+	ReasonAwaitingControlPlaneOwner Reason = "AwaitingControlPlaneOwner"
+	// ReasonAwaitingPeers — the control-plane owner waits for the other CRs' data planes before cleanup
+	ReasonAwaitingPeers Reason = "AwaitingPeers"
+	// ReasonAwaitingControlPlaneRollout — a new rotation waits for an unfinished control-plane rollout to settle
+	ReasonAwaitingControlPlaneRollout Reason = "AwaitingControlPlaneRollout"
+	// ReasonAwaitingRemainingKinds — the data plane was rolled for rollout.onlyKinds only; cleanup waits for the rest
+	ReasonAwaitingRemainingKinds Reason = "AwaitingRemainingKinds"
 
 	// --- Cleanup ---
 	ReasonPreviousDeleted Reason = "PreviousSecretDeleted"
@@ -97,7 +136,31 @@ const (
 	// --- Result ---
 	ReasonRotationSucceeded Reason = "RotationSucceeded"
 	ReasonRotationFailed    Reason = "RotationFailed"
+	// ReasonRolloutTimeout — a workload did not become ready in time
+	ReasonRolloutTimeout Reason = "RolloutTimeout"
+	// ReasonMisconfigured — the spec or a workload setup prevents the rollout; fix it to retry
+	ReasonMisconfigured Reason = "Misconfigured"
+	// ReasonWorkloadDegraded — a workload was too unhealthy to be restarted
+	ReasonWorkloadDegraded Reason = "WorkloadDegraded"
+	// ReasonWorkloadDisappeared — a workload was deleted while being rolled out
+	ReasonWorkloadDisappeared Reason = "WorkloadDisappeared"
+	// ReasonInsufficientResources — a restarted pod stayed unschedulable (e.g. insufficient CPU/memory)
+	ReasonInsufficientResources Reason = "InsufficientResources"
+	// ReasonAPIThrottled — the API server kept throttling requests (HTTP 429); the rollout is retried later
+	ReasonAPIThrottled Reason = "APIThrottled"
+	// ReasonRolledBack — the rotation failed for good and the previous trust anchor was restored
+	ReasonRolledBack Reason = "RolledBack"
+	// ReasonCurrentAnchorOlder — the current trust anchor was issued before the previous one (linkerd.verifyAnchorOrder)
+	ReasonCurrentAnchorOlder Reason = "CurrentAnchorOlder"
+	// ReasonCurrentSecretMissing — the current trust anchor secret did not appear within linkerd.currentSecretTimeout
+	ReasonCurrentSecretMissing Reason = "CurrentSecretMissing"
+	// ReasonInternalError — the reconcile panicked (an operator bug or a malformed object)
+	ReasonInternalError Reason = "InternalError"
 
 	// --- DryRun ---
 	ReasonDryRun Reason = "DryRunCompleted"
+
+	// --- AwaitingApproval ---
+	// ReasonPlanChanged — the computed data-plane plan differs from the approved one
+	ReasonPlanChanged Reason = "PlanChanged"
 )
@@ -23,6 +23,7 @@ package v1alpha1
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -40,9 +41,74 @@ func (in *AnnotationBumpOptions) DeepCopy() *AnnotationBumpOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveConfig) DeepCopyInto(out *EffectiveConfig) {
+	*out = *in
+	out.WorkloadTimeoutBase = in.WorkloadTimeoutBase
+	out.WorkloadTimeoutPerReplica = in.WorkloadTimeoutPerReplica
+	out.WorkloadTimeoutMax = in.WorkloadTimeoutMax
+	out.PreDeleteHookTimeout = in.PreDeleteHookTimeout
+	out.ReconcileBudget = in.ReconcileBudget
+	if in.ControlPlaneRestartOrder != nil {
+		in, out := &in.ControlPlaneRestartOrder, &out.ControlPlaneRestartOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveConfig.
+func (in *EffectiveConfig) DeepCopy() *EffectiveConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinkerdSpec) DeepCopyInto(out *LinkerdSpec) {
 	*out = *in
+	if in.BootstrapTimeout != nil {
+		in, out := &in.BootstrapTimeout, &out.BootstrapTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CurrentSecretTimeout != nil {
+		in, out := &in.CurrentSecretTimeout, &out.CurrentSecretTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SingleSecretMode != nil {
+		in, out := &in.SingleSecretMode, &out.SingleSecretMode
+		*out = new(SingleSecretModeSpec)
+		**out = **in
+	}
+	if in.ControlPlaneOwner != nil {
+		in, out := &in.ControlPlaneOwner, &out.ControlPlaneOwner
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManagedIssuer != nil {
+		in, out := &in.ManagedIssuer, &out.ManagedIssuer
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VerifyAnchorOrder != nil {
+		in, out := &in.VerifyAnchorOrder, &out.VerifyAnchorOrder
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TrustRootsConfigMapKeys != nil {
+		in, out := &in.TrustRootsConfigMapKeys, &out.TrustRootsConfigMapKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControlPlaneRestartOrder != nil {
+		in, out := &in.ControlPlaneRestartOrder, &out.ControlPlaneRestartOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkerdSpec.
@@ -117,10 +183,17 @@ func (in *LinkerdTrustRotationList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinkerdTrustRotationSpec) DeepCopyInto(out *LinkerdTrustRotationSpec) {
 	*out = *in
-	out.Linkerd = in.Linkerd
-	out.Trigger = in.Trigger
+	in.Linkerd.DeepCopyInto(&out.Linkerd)
+	in.Trigger.DeepCopyInto(&out.Trigger)
 	in.Rollout.DeepCopyInto(&out.Rollout)
 	in.Protection.DeepCopyInto(&out.Protection)
+	if in.CommonLabels != nil {
+		in, out := &in.CommonLabels, &out.CommonLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkerdTrustRotationSpec.
@@ -151,6 +224,17 @@ func (in *LinkerdTrustRotationStatus) DeepCopyInto(out *LinkerdTrustRotationStat
 		*out = new(string)
 		**out = **in
 	}
+	if in.PhaseEnteredAt != nil {
+		in, out := &in.PhaseEnteredAt, &out.PhaseEnteredAt
+		*out = (*in).DeepCopy()
+	}
+	if in.PhaseDurations != nil {
+		in, out := &in.PhaseDurations, &out.PhaseDurations
+		*out = make(map[string]v1.Duration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.StartedAt != nil {
 		in, out := &in.StartedAt, &out.StartedAt
 		*out = (*in).DeepCopy()
@@ -168,11 +252,27 @@ func (in *LinkerdTrustRotationStatus) DeepCopyInto(out *LinkerdTrustRotationStat
 		*out = new(ProgressStatus)
 		**out = **in
 	}
+	if in.ControlPlaneDoneAt != nil {
+		in, out := &in.ControlPlaneDoneAt, &out.ControlPlaneDoneAt
+		*out = (*in).DeepCopy()
+	}
 	if in.Trust != nil {
 		in, out := &in.Trust, &out.Trust
 		*out = new(TrustStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DivergedSince != nil {
+		in, out := &in.DivergedSince, &out.DivergedSince
+		*out = (*in).DeepCopy()
+	}
+	if in.CurrentSecretMissingSince != nil {
+		in, out := &in.CurrentSecretMissingSince, &out.CurrentSecretMissingSince
+		*out = (*in).DeepCopy()
+	}
+	if in.CleanedUpAt != nil {
+		in, out := &in.CleanedUpAt, &out.CleanedUpAt
+		*out = (*in).DeepCopy()
+	}
 	if in.Retries != nil {
 		in, out := &in.Retries, &out.Retries
 		*out = new(RetryStatus)
@@ -183,6 +283,39 @@ func (in *LinkerdTrustRotationStatus) DeepCopyInto(out *LinkerdTrustRotationStat
 		*out = new(RolloutCursor)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OngoingRolloutsDeferredSince != nil {
+		in, out := &in.OngoingRolloutsDeferredSince, &out.OngoingRolloutsDeferredSince
+		*out = (*in).DeepCopy()
+	}
+	if in.DryRunEstimatedDuration != nil {
+		in, out := &in.DryRunEstimatedDuration, &out.DryRunEstimatedDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LastReconcileErrorTime != nil {
+		in, out := &in.LastReconcileErrorTime, &out.LastReconcileErrorTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PlannedCounts != nil {
+		in, out := &in.PlannedCounts, &out.PlannedCounts
+		*out = new(WorkloadCounts)
+		**out = **in
+	}
+	if in.CompletedCounts != nil {
+		in, out := &in.CompletedCounts, &out.CompletedCounts
+		*out = new(WorkloadCounts)
+		**out = **in
+	}
+	if in.EffectiveConfig != nil {
+		in, out := &in.EffectiveConfig, &out.EffectiveConfig
+		*out = new(EffectiveConfig)
+		**out = **in
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(VerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkerdTrustRotationStatus.
@@ -210,19 +343,119 @@ func (in *ProgressStatus) DeepCopy() *ProgressStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodAnnotationCheck) DeepCopyInto(out *PodAnnotationCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodAnnotationCheck.
+func (in *PodAnnotationCheck) DeepCopy() *PodAnnotationCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PodAnnotationCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostRotationTarget) DeepCopyInto(out *PostRotationTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostRotationTarget.
+func (in *PostRotationTarget) DeepCopy() *PostRotationTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PostRotationTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostRotationTriggerSpec) DeepCopyInto(out *PostRotationTriggerSpec) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(PostRotationTarget)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostRotationTriggerSpec.
+func (in *PostRotationTriggerSpec) DeepCopy() *PostRotationTriggerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostRotationTriggerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreDeleteHookSpec) DeepCopyInto(out *PreDeleteHookSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreDeleteHookSpec.
+func (in *PreDeleteHookSpec) DeepCopy() *PreDeleteHookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PreDeleteHookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProtectionSpec) DeepCopyInto(out *ProtectionSpec) {
 	*out = *in
+	if in.LinkerdCheckCommand != nil {
+		in, out := &in.LinkerdCheckCommand, &out.LinkerdCheckCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LinkerdCheckArgs != nil {
+		in, out := &in.LinkerdCheckArgs, &out.LinkerdCheckArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.BeforeRolloutDelay != nil {
 		in, out := &in.BeforeRolloutDelay, &out.BeforeRolloutDelay
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.BetweenPhasesDelay != nil {
+		in, out := &in.BetweenPhasesDelay, &out.BetweenPhasesDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.HoldAfterCleanup != nil {
 		in, out := &in.HoldAfterCleanup, &out.HoldAfterCleanup
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.StabilizationPeriod != nil {
+		in, out := &in.StabilizationPeriod, &out.StabilizationPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.WorkloadTimeout != nil {
+		in, out := &in.WorkloadTimeout, &out.WorkloadTimeout
+		*out = new(WorkloadTimeoutSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostRotationTrigger != nil {
+		in, out := &in.PostRotationTrigger, &out.PostRotationTrigger
+		*out = new(PostRotationTriggerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectionSpec.
@@ -267,6 +500,11 @@ func (in *RolloutCursor) DeepCopyInto(out *RolloutCursor) {
 		*out = new(WorkRef)
 		**out = **in
 	}
+	if in.Completed != nil {
+		in, out := &in.Completed, &out.Completed
+		*out = make([]WorkRef, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutCursor.
@@ -283,6 +521,26 @@ func (in *RolloutCursor) DeepCopy() *RolloutCursor {
 func (in *RolloutSpec) DeepCopyInto(out *RolloutSpec) {
 	*out = *in
 	in.TargetAnnotationSelector.DeepCopyInto(&out.TargetAnnotationSelector)
+	if in.OnlyKinds != nil {
+		in, out := &in.OnlyKinds, &out.OnlyKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VerifyPodAnnotation != nil {
+		in, out := &in.VerifyPodAnnotation, &out.VerifyPodAnnotation
+		*out = new(PodAnnotationCheck)
+		**out = **in
+	}
+	if in.CanarySample != nil {
+		in, out := &in.CanarySample, &out.CanarySample
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.ReconcileBudget != nil {
+		in, out := &in.ReconcileBudget, &out.ReconcileBudget
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutSpec.
@@ -298,6 +556,16 @@ func (in *RolloutSpec) DeepCopy() *RolloutSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RotationTrigger) DeepCopyInto(out *RotationTrigger) {
 	*out = *in
+	if in.DivergenceGracePeriod != nil {
+		in, out := &in.DivergenceGracePeriod, &out.DivergenceGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BundlePropagationTimeout != nil {
+		in, out := &in.BundlePropagationTimeout, &out.BundlePropagationTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RotationTrigger.
@@ -310,9 +578,29 @@ func (in *RotationTrigger) DeepCopy() *RotationTrigger {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SingleSecretModeSpec) DeepCopyInto(out *SingleSecretModeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleSecretModeSpec.
+func (in *SingleSecretModeSpec) DeepCopy() *SingleSecretModeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SingleSecretModeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetAnnotationSelector) DeepCopyInto(out *TargetAnnotationSelector) {
 	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Targets != nil {
 		in, out := &in.Targets, &out.Targets
 		*out = make([]TargetScope, len(*in))
@@ -345,6 +633,26 @@ func (in *TargetScope) DeepCopyInto(out *TargetScope) {
 		*out = new(AnnotationBumpOptions)
 		**out = **in
 	}
+	if in.RunLinkerdCheckProxy != nil {
+		in, out := &in.RunLinkerdCheckProxy, &out.RunLinkerdCheckProxy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PreDeleteHook != nil {
+		in, out := &in.PreDeleteHook, &out.PreDeleteHook
+		*out = new(PreDeleteHookSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.ReadyConditions != nil {
+		in, out := &in.ReadyConditions, &out.ReadyConditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetScope.
@@ -377,6 +685,30 @@ func (in *TrustStatus) DeepCopy() *TrustStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationStatus) DeepCopyInto(out *VerificationStatus) {
+	*out = *in
+	if in.Findings != nil {
+		in, out := &in.Findings, &out.Findings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationStatus.
+func (in *VerificationStatus) DeepCopy() *VerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkRef) DeepCopyInto(out *WorkRef) {
 	*out = *in
@@ -391,3 +723,48 @@ func (in *WorkRef) DeepCopy() *WorkRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadCounts) DeepCopyInto(out *WorkloadCounts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadCounts.
+func (in *WorkloadCounts) DeepCopy() *WorkloadCounts {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadCounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadTimeoutSpec) DeepCopyInto(out *WorkloadTimeoutSpec) {
+	*out = *in
+	if in.Base != nil {
+		in, out := &in.Base, &out.Base
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PerReplica != nil {
+		in, out := &in.PerReplica, &out.PerReplica
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadTimeoutSpec.
+func (in *WorkloadTimeoutSpec) DeepCopy() *WorkloadTimeoutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadTimeoutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
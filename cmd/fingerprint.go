@@ -0,0 +1,98 @@
+/*
+Copyright 2025 Edenlab.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/config_map"
+	"linkerd-trust-rotator.operators.infra/internal/secret"
+)
+
+const fingerprintCommand = "fingerprint"
+
+// runFingerprint implements the read-only `fingerprint` debug subcommand.
+// It prints the fingerprint of a trust-anchor secret and/or the trust-roots ConfigMap bundle
+// using the same read paths as the controller, without modifying the cluster.
+func runFingerprint(args []string) int {
+	var namespace, secretName, secretKey, configMapName string
+
+	fs := flag.NewFlagSet(fingerprintCommand, flag.ContinueOnError)
+	fs.StringVar(&namespace, "namespace", "linkerd", "Namespace of the secret and/or ConfigMap.")
+	fs.StringVar(&secretName, "secret", "", "Name of the trust-anchor secret to fingerprint.")
+	fs.StringVar(&secretKey, "secret-key", "tls.crt", "Data key of the secret holding the PEM certificates.")
+	fs.StringVar(&configMapName, "configmap", "", "Name of the trust-roots ConfigMap to inspect.")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if len(secretName) == 0 && len(configMapName) == 0 {
+		fmt.Fprintln(os.Stderr, "at least one of --secret or --configmap is required")
+		fs.Usage()
+		return 2
+	}
+
+	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to create client: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	logger := logr.Discard()
+
+	if len(secretName) > 0 {
+		key := types.NamespacedName{Namespace: namespace, Name: secretName}
+		fp, err := secret.New(c, scheme, logger).FingerprintSecret(ctx, key, secretKey)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "secret %s: %v\n", key.String(), err)
+			return 1
+		}
+
+		fmt.Printf("secret %s[%s]: %s\n", key.String(), secretKey, fp)
+	}
+
+	if len(configMapName) > 0 {
+		obj := &trv1alpha1.LinkerdTrustRotation{
+			Spec: trv1alpha1.LinkerdTrustRotationSpec{
+				Linkerd: trv1alpha1.LinkerdSpec{Namespace: namespace, TrustRootsConfigMap: configMapName},
+			},
+		}
+
+		result, err := config_map.New(c, scheme, logger).LoadAndInspectCMBundle(ctx, obj)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "configmap %s/%s: %v\n", namespace, configMapName, err)
+			return 1
+		}
+
+		fmt.Printf("configmap %s/%s: bundleState=%s certs=%d\n", namespace, configMapName, result.State, len(result.Certs))
+		for _, fp := range result.Fps {
+			fmt.Printf("  sha256:%s\n", fp)
+		}
+	}
+
+	return 0
+}
@@ -36,6 +36,7 @@ import (
 
 	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
 	"linkerd-trust-rotator.operators.infra/internal/controller"
+	"linkerd-trust-rotator.operators.infra/internal/rollout"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -52,6 +53,10 @@ func init() {
 
 // nolint:gocyclo
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == fingerprintCommand {
+		os.Exit(runFingerprint(os.Args[2:]))
+	}
+
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	//var webhookCertPath, webhookCertName, webhookCertKey string
@@ -59,6 +64,9 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var requeueJitter float64
+	var rotationClass string
+	var allowedCRKinds string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -77,6 +85,12 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.Float64Var(&requeueJitter, "requeue-jitter", 0.2,
+		"Random spread applied to the steady-state requeue interval, as a fraction (0.2 = ±20%). Use 0 to disable.")
+	flag.StringVar(&rotationClass, "rotation-class", "",
+		"Only reconcile LinkerdTrustRotations whose spec.rotationClass matches. Empty matches CRs without a class.")
+	flag.StringVar(&allowedCRKinds, "allowed-cr-kinds", "",
+		"Comma-separated <group>/<version>/<Kind> list of custom resources target scopes may restart. Empty allows all.")
 	opts := zap.Options{
 		Development:     false,
 		StacktraceLevel: zapcore.FatalLevel,
@@ -86,6 +100,12 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	allowedGVKs, err := rollout.ParseGVKList(allowedCRKinds)
+	if err != nil {
+		setupLog.Error(err, "invalid --allowed-cr-kinds")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -160,8 +180,11 @@ func main() {
 	}
 
 	if err := (&controller.LinkerdTrustRotationReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		RequeueJitter:  requeueJitter,
+		RotationClass:  rotationClass,
+		AllowedCRKinds: allowedGVKs,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LinkerdTrustRotation")
 		os.Exit(1)
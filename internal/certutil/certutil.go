@@ -0,0 +1,132 @@
+package certutil
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const (
+	pemTypeCertificate = "CERTIFICATE"
+	pemTypePKCS7       = "PKCS7"
+)
+
+// ErrNoCertificates is returned by Parse when data holds no certificate in any supported encoding.
+var ErrNoCertificates = errors.New("no certificates found (tried PEM, DER and PKCS#7)")
+
+// oidSignedData identifies PKCS#7 SignedData content (RFC 2315).
+var oidSignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+
+// contentInfo is the PKCS#7 ContentInfo wrapper.
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+// signedData is the PKCS#7 SignedData structure; only the certificates are used.
+type signedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	ContentInfo      asn1.RawValue
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      asn1.RawValue
+}
+
+// Parse returns the certificates in data, detecting the encoding: PEM first (CERTIFICATE and PKCS7 blocks;
+// other blocks are skipped), then raw DER (one or more concatenated certificates), then DER PKCS#7.
+// Certificates keep their order, so fingerprints of PEM input are unchanged.
+func Parse(data []byte) ([]*x509.Certificate, error) {
+	if certs, found, err := parsePEM(data); found {
+		return certs, err
+	}
+
+	if certs, err := x509.ParseCertificates(data); err == nil && len(certs) > 0 {
+		return certs, nil
+	}
+
+	if certs, err := parsePKCS7(data); err == nil && len(certs) > 0 {
+		return certs, nil
+	}
+
+	return nil, ErrNoCertificates
+}
+
+// IsPEM reports whether data contains at least one PEM block.
+func IsPEM(data []byte) bool {
+	block, _ := pem.Decode(data)
+	return block != nil
+}
+
+// EncodePEM returns certs as concatenated PEM CERTIFICATE blocks.
+func EncodePEM(certs []*x509.Certificate) []byte {
+	var b bytes.Buffer
+	for _, c := range certs {
+		_ = pem.Encode(&b, &pem.Block{Type: pemTypeCertificate, Bytes: c.Raw})
+	}
+
+	return b.Bytes()
+}
+
+// parsePEM parses the CERTIFICATE and PKCS7 blocks of a PEM bundle; found is false if data holds no PEM block.
+func parsePEM(data []byte) (certs []*x509.Certificate, found bool, err error) {
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		found = true
+
+		switch block.Type {
+		case pemTypeCertificate:
+			c, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, true, fmt.Errorf("invalid certificate in PEM: %w", err)
+			}
+			certs = append(certs, c)
+		case pemTypePKCS7:
+			p7, err := parsePKCS7(block.Bytes)
+			if err != nil {
+				return nil, true, fmt.Errorf("invalid PKCS#7 block in PEM: %w", err)
+			}
+			certs = append(certs, p7...)
+		}
+	}
+
+	if found && len(certs) == 0 {
+		return nil, true, ErrNoCertificates
+	}
+
+	return certs, found, nil
+}
+
+// parsePKCS7 returns the certificates of a DER PKCS#7 SignedData structure, such as a .p7b bundle.
+func parsePKCS7(der []byte) ([]*x509.Certificate, error) {
+	var ci contentInfo
+	rest, err := asn1.Unmarshal(der, &ci)
+	if err != nil {
+		return nil, fmt.Errorf("parse PKCS#7 content info: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("trailing data after PKCS#7 content info")
+	}
+	if !ci.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("unsupported PKCS#7 content type %s", ci.ContentType)
+	}
+
+	var sd signedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return nil, fmt.Errorf("parse PKCS#7 signed data: %w", err)
+	}
+
+	certs, err := x509.ParseCertificates(sd.Certificates.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse PKCS#7 certificates: %w", err)
+	}
+
+	return certs, nil
+}
@@ -0,0 +1,140 @@
+package certutil
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func selfSignedDER(t *testing.T, cn string) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return der
+}
+
+// pkcs7 wraps DER certificates in a certs-only PKCS#7 SignedData structure, like a .p7b bundle.
+func pkcs7(t *testing.T, ders ...[]byte) []byte {
+	t.Helper()
+
+	data, err := asn1.Marshal(contentInfo{ContentType: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sd, err := asn1.Marshal(signedData{
+		Version:          1,
+		DigestAlgorithms: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true},
+		ContentInfo:      asn1.RawValue{FullBytes: data},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: bytes.Join(ders, nil)},
+		SignerInfos:      asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sd},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return out
+}
+
+func TestParse(t *testing.T) {
+	a, b := selfSignedDER(t, "root-a"), selfSignedDER(t, "root-b")
+	pemOf := func(typ string, der []byte) []byte { return pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}) }
+	key := pemOf("EC PRIVATE KEY", []byte("key"))
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    [][]byte
+		wantErr bool
+	}{
+		{name: "PEM", data: append(pemOf("CERTIFICATE", a), pemOf("CERTIFICATE", b)...), want: [][]byte{a, b}},
+		{name: "PEM skips other blocks", data: append(key, pemOf("CERTIFICATE", a)...), want: [][]byte{a}},
+		{name: "DER", data: a, want: [][]byte{a}},
+		{name: "concatenated DER", data: append(append([]byte(nil), a...), b...), want: [][]byte{a, b}},
+		{name: "PKCS#7", data: pkcs7(t, a, b), want: [][]byte{a, b}},
+		{name: "PEM PKCS#7", data: pemOf("PKCS7", pkcs7(t, b)), want: [][]byte{b}},
+		{name: "invalid PEM certificate", data: pemOf("CERTIFICATE", []byte("junk")), wantErr: true},
+		{name: "PEM without certificates", data: key, wantErr: true},
+		{name: "garbage", data: []byte("not a certificate"), wantErr: true},
+		{name: "empty", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certs, err := Parse(tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse = %d certs, want an error", len(certs))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(certs) != len(tt.want) {
+				t.Fatalf("Parse = %d certs, want %d", len(certs), len(tt.want))
+			}
+			for i := range certs {
+				if !bytes.Equal(certs[i].Raw, tt.want[i]) {
+					t.Errorf("cert %d differs", i)
+				}
+			}
+		})
+	}
+
+	if _, err := Parse([]byte("not a certificate")); !errors.Is(err, ErrNoCertificates) {
+		t.Errorf("Parse(garbage) = %v, want ErrNoCertificates", err)
+	}
+}
+
+func TestEncodePEM(t *testing.T) {
+	a := selfSignedDER(t, "root-a")
+	certs, err := Parse(pkcs7(t, a))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := EncodePEM(certs)
+	if !IsPEM(out) {
+		t.Fatalf("EncodePEM output is not PEM: %q", out)
+	}
+	back, err := Parse(out)
+	if err != nil || len(back) != 1 || !bytes.Equal(back[0].Raw, a) {
+		t.Fatalf("round trip = %d certs, %v", len(back), err)
+	}
+}
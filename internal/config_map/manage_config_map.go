@@ -5,20 +5,25 @@ import (
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/hex"
-	"encoding/pem"
+	"errors"
 	"fmt"
+	"path"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"linkerd-trust-rotator.operators.infra/api/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/certutil"
+	"linkerd-trust-rotator.operators.infra/internal/managed"
 )
 
 const (
@@ -40,13 +45,13 @@ type Result struct {
 	Certs []*x509.Certificate
 	Fps   []string
 	State v1alpha1.BundleState
+	// Duplicates is the number of certificates dropped because they repeat an earlier one.
+	Duplicates int
 }
 
-// LoadAndInspectCMBundle fetches the ConfigMap and inspects the ca-bundle.crt.
+// LoadAndInspectCMBundle fetches the ConfigMap and inspects the bundle merged from its trust-roots keys.
 // It returns parsed certs, their SHA-256 fingerprints, and the BundleState.
 func (m *ManageConfigMap) LoadAndInspectCMBundle(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) (*Result, error) {
-	var state v1alpha1.BundleState
-
 	cm := &v1.ConfigMap{}
 	cmNamespaced := types.NamespacedName{Namespace: obj.Spec.Linkerd.Namespace, Name: obj.Spec.Linkerd.TrustRootsConfigMap}
 	if err := m.Client.Get(ctx, cmNamespaced, cm); err != nil {
@@ -57,60 +62,232 @@ func (m *ManageConfigMap) LoadAndInspectCMBundle(ctx context.Context, obj *trv1a
 		return nil, fmt.Errorf("get configmap %s: %w", cmNamespaced.String(), err)
 	}
 
-	raw, ok := cm.Data[configMapDataKey]
-	if !ok {
-		return nil, fmt.Errorf("configmap %s has no key %q", cmNamespaced.String(), configMapDataKey)
+	raw, err := bundleData(cm, obj.Spec.Linkerd.TrustRootsConfigMapKeys)
+	if err != nil {
+		return nil, fmt.Errorf("configmap %s: %w", cmNamespaced.String(), err)
+	}
+
+	result, err := InspectBundle([]byte(raw))
+	if err != nil {
+		if errors.Is(err, certutil.ErrNoCertificates) {
+			return nil, fmt.Errorf("%w in %s/%s", certutil.ErrNoCertificates, cmNamespaced.Namespace, cmNamespaced.Name)
+		}
+
+		return nil, err
 	}
 
-	if strings.TrimSpace(raw) == "" {
-		return nil, fmt.Errorf("configmap %s key %q is empty", cmNamespaced.String(), configMapDataKey)
+	if result.Duplicates > 0 {
+		m.Logger.Info(fmt.Sprintf("Ignoring %d duplicate certificate(s) in %s", result.Duplicates, cmNamespaced.String()))
 	}
 
-	certs, err := parsePEMCerts([]byte(raw))
+	return result, nil
+}
+
+// InspectBundle parses a trust-roots PEM bundle and classifies it: one distinct certificate is a single
+// bundle, more are an overlap. Duplicate certificates are dropped and counted in Result.Duplicates.
+func InspectBundle(pemBytes []byte) (*Result, error) {
+	var state v1alpha1.BundleState
+
+	certs, err := certutil.Parse(pemBytes)
 	if err != nil {
 		return nil, fmt.Errorf("parse bundle: %w", err)
 	}
 
-	fps := make([]string, 0, len(certs))
-	for _, c := range certs {
-		sum := sha256.Sum256(c.Raw)
-		fps = append(fps, strings.ToLower(hex.EncodeToString(sum[:])))
-	}
-	// sort fingerprints for stable comparisons
-	sort.Strings(fps)
+	// trust-manager occasionally writes the same anchor twice; identical certs must not count as an overlap.
+	distinct := distinctCerts(certs)
+	duplicates := len(certs) - len(distinct)
 
-	switch len(certs) {
+	switch len(distinct) {
 	case 0:
-		return nil, fmt.Errorf("no CERTIFICATE blocks found in %s/%s", cmNamespaced.Namespace, cmNamespaced.Name)
+		return nil, certutil.ErrNoCertificates
 	case 1:
 		state = v1alpha1.BundleStateSingle
 	default:
 		state = v1alpha1.BundleStateOverlap
 	}
 
-	return &Result{Certs: certs, Fps: fps, State: state}, nil
+	return &Result{Certs: distinct, Fps: FingerprintCerts(distinct), State: state, Duplicates: duplicates}, nil
 }
 
-// parsePEMCerts extracts all x509 CERTIFICATE blocks from a PEM bundle.
-func parsePEMCerts(pemBytes []byte) ([]*x509.Certificate, error) {
+// bundleData concatenates the PEM contents of the ConfigMap keys matching patterns (exact keys or
+// path.Match globs, default: configMapDataKey) in key order. Every pattern must match a non-empty key.
+func bundleData(cm *v1.ConfigMap, patterns []string) (string, error) {
+	if len(patterns) == 0 {
+		patterns = []string{configMapDataKey}
+	}
+
+	keys := make([]string, 0, len(cm.Data))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
 	var (
-		out  []*x509.Certificate
-		rest = pemBytes
+		b       strings.Builder
+		matched = make(map[string]struct{}, len(keys))
 	)
-	for {
-		var blk *pem.Block
-		blk, rest = pem.Decode(rest)
-		if blk == nil {
-			break
+	for _, pattern := range patterns {
+		found := false
+		for _, k := range keys {
+			ok, err := path.Match(pattern, k)
+			if err != nil {
+				return "", fmt.Errorf("invalid key pattern %q: %w", pattern, err)
+			}
+			if !ok {
+				continue
+			}
+
+			found = true
+			if _, dup := matched[k]; dup {
+				continue
+			}
+			matched[k] = struct{}{}
+
+			if strings.TrimSpace(cm.Data[k]) == "" {
+				return "", fmt.Errorf("key %q is empty", k)
+			}
+			b.WriteString(strings.TrimSpace(cm.Data[k]))
+			b.WriteString("\n")
 		}
-		if blk.Type != "CERTIFICATE" {
-			continue
+
+		if !found {
+			return "", fmt.Errorf("no key matches %q", pattern)
 		}
-		cert, err := x509.ParseCertificate(blk.Bytes)
-		if err != nil {
-			return nil, fmt.Errorf("parse x509 cert: %w", err)
+	}
+
+	return b.String(), nil
+}
+
+// WriteOverlapBundle writes the concatenated current and previous anchors into the trust-roots ConfigMap,
+// creating it if missing. Both bundles are validated before anything is written.
+func (m *ManageConfigMap) WriteOverlapBundle(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, currentPEM, previousPEM []byte) error {
+	current, err := bundlePEM(currentPEM)
+	if err != nil {
+		return fmt.Errorf("current anchor: %w", err)
+	}
+
+	previous, err := bundlePEM(previousPEM)
+	if err != nil {
+		return fmt.Errorf("previous anchor: %w", err)
+	}
+
+	return m.writeBundle(ctx, obj, current+"\n"+previous+"\n")
+}
+
+// PruneBundle rewrites the trust-roots ConfigMap so that it holds only the current anchor.
+func (m *ManageConfigMap) PruneBundle(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, currentPEM []byte) error {
+	current, err := bundlePEM(currentPEM)
+	if err != nil {
+		return fmt.Errorf("current anchor: %w", err)
+	}
+
+	return m.writeBundle(ctx, obj, current+"\n")
+}
+
+// writeBundle stores bundle under configMapDataKey, creating the ConfigMap if missing
+// and skipping the update when the content is unchanged.
+func (m *ManageConfigMap) writeBundle(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, bundle string) error {
+	cm := &v1.ConfigMap{}
+	cmNamespaced := types.NamespacedName{Namespace: obj.Spec.Linkerd.Namespace, Name: obj.Spec.Linkerd.TrustRootsConfigMap}
+	if err := m.Client.Get(ctx, cmNamespaced, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("get configmap %s: %w", cmNamespaced.String(), err)
 		}
-		out = append(out, cert)
+
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cmNamespaced.Namespace,
+				Name:      cmNamespaced.Name,
+				Labels:    managed.Labels(obj),
+			},
+			Data: map[string]string{configMapDataKey: bundle},
+		}
+		if err := m.Client.Create(ctx, cm); err != nil {
+			return fmt.Errorf("create configmap %s: %w", cmNamespaced.String(), err)
+		}
+
+		m.Logger.Info(fmt.Sprintf("created trust bundle configmap %s", cmNamespaced.String()))
+
+		return nil
+	}
+
+	if cm.Data[configMapDataKey] == bundle {
+		return nil
+	}
+
+	patch := client.MergeFrom(cm.DeepCopy())
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[configMapDataKey] = bundle
+	if err := m.Client.Patch(ctx, cm, patch); err != nil {
+		return fmt.Errorf("patch configmap %s: %w", cmNamespaced.String(), err)
+	}
+
+	m.Logger.Info(fmt.Sprintf("updated trust bundle in configmap %s", cmNamespaced.String()))
+
+	return nil
+}
+
+// bundlePEM validates an anchor bundle and returns it as trimmed PEM for the ConfigMap: PEM is kept as is,
+// DER and PKCS#7 anchors are re-encoded as CERTIFICATE blocks.
+func bundlePEM(data []byte) (string, error) {
+	certs, err := certutil.Parse(data)
+	if err != nil {
+		return "", err
+	}
+
+	if !certutil.IsPEM(data) {
+		data = certutil.EncodePEM(certs)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// LastModified returns the latest write time of the trust-roots ConfigMap, taken from its managed fields
+// (falling back to the creation timestamp). When the bundle is in overlap, this is when the overlap was published.
+func LastModified(ctx context.Context, c client.Reader, obj *trv1alpha1.LinkerdTrustRotation) (time.Time, error) {
+	cm := &v1.ConfigMap{}
+	cmNamespaced := types.NamespacedName{Namespace: obj.Spec.Linkerd.Namespace, Name: obj.Spec.Linkerd.TrustRootsConfigMap}
+	if err := c.Get(ctx, cmNamespaced, cm); err != nil {
+		return time.Time{}, fmt.Errorf("get configmap %s: %w", cmNamespaced.String(), err)
+	}
+
+	last := cm.CreationTimestamp.Time
+	for _, f := range cm.ManagedFields {
+		if f.Time != nil && f.Time.After(last) {
+			last = f.Time.Time
+		}
+	}
+
+	return last, nil
+}
+
+// FingerprintCerts returns the sorted, lower-case hex SHA-256 fingerprints of the given certificates.
+func FingerprintCerts(certs []*x509.Certificate) []string {
+	fps := make([]string, 0, len(certs))
+	for _, c := range certs {
+		sum := sha256.Sum256(c.Raw)
+		fps = append(fps, strings.ToLower(hex.EncodeToString(sum[:])))
 	}
-	return out, nil
+	// sort fingerprints for stable comparisons
+	sort.Strings(fps)
+
+	return fps
+}
+
+// distinctCerts drops certificates whose DER encoding was already seen, keeping the first occurrence.
+func distinctCerts(certs []*x509.Certificate) []*x509.Certificate {
+	seen := make(map[[sha256.Size]byte]struct{}, len(certs))
+	out := make([]*x509.Certificate, 0, len(certs))
+	for _, c := range certs {
+		sum := sha256.Sum256(c.Raw)
+		if _, ok := seen[sum]; ok {
+			continue
+		}
+		seen[sum] = struct{}{}
+		out = append(out, c)
+	}
+
+	return out
 }
@@ -0,0 +1,210 @@
+package config_map
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/certutil"
+)
+
+const (
+	testNamespace = "linkerd"
+	testConfigMap = "linkerd-identity-trust-roots"
+)
+
+func selfSignedPEM(t *testing.T, cn string) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestLoadAndInspectCMBundle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	anchorA := selfSignedPEM(t, "root.linkerd.cluster.local")
+	anchorB := selfSignedPEM(t, "root.linkerd.cluster.local")
+
+	tests := []struct {
+		name    string
+		bundle  string
+		want    trv1alpha1.BundleState
+		wantFps int
+	}{
+		{name: "single anchor", bundle: anchorA, want: trv1alpha1.BundleStateSingle, wantFps: 1},
+		{name: "two distinct anchors", bundle: anchorA + anchorB, want: trv1alpha1.BundleStateOverlap, wantFps: 2},
+		{name: "duplicated anchor", bundle: anchorA + anchorA, want: trv1alpha1.BundleStateSingle, wantFps: 1},
+		{name: "overlap with a duplicate", bundle: anchorA + anchorB + anchorA, want: trv1alpha1.BundleStateOverlap, wantFps: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testConfigMap},
+				Data:       map[string]string{configMapDataKey: tt.bundle},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
+			obj := &trv1alpha1.LinkerdTrustRotation{}
+			obj.Spec.Linkerd.Namespace = testNamespace
+			obj.Spec.Linkerd.TrustRootsConfigMap = testConfigMap
+
+			res, err := New(c, scheme, logr.Discard()).LoadAndInspectCMBundle(context.Background(), obj)
+			if err != nil {
+				t.Fatalf("LoadAndInspectCMBundle: %v", err)
+			}
+
+			if res.State != tt.want {
+				t.Fatalf("state = %s, want %s", res.State, tt.want)
+			}
+
+			if len(res.Fps) != tt.wantFps {
+				t.Fatalf("fingerprints = %d, want %d", len(res.Fps), tt.wantFps)
+			}
+		})
+	}
+}
+
+func TestInspectBundle(t *testing.T) {
+	anchorA := selfSignedPEM(t, "root.linkerd.cluster.local")
+	anchorB := selfSignedPEM(t, "root.linkerd.cluster.local")
+	invalid := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")}))
+	key := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")}))
+
+	tests := []struct {
+		name           string
+		bundle         string
+		want           trv1alpha1.BundleState
+		wantFps        int
+		wantDuplicates int
+		wantErr        bool
+	}{
+		{name: "single anchor", bundle: anchorA, want: trv1alpha1.BundleStateSingle, wantFps: 1},
+		{name: "two distinct anchors", bundle: anchorA + anchorB, want: trv1alpha1.BundleStateOverlap, wantFps: 2},
+		{name: "duplicated anchor", bundle: anchorA + anchorA, want: trv1alpha1.BundleStateSingle, wantFps: 1, wantDuplicates: 1},
+		{name: "non-certificate blocks are skipped", bundle: key + anchorA, want: trv1alpha1.BundleStateSingle, wantFps: 1},
+		{name: "invalid certificate", bundle: anchorA + invalid, wantErr: true},
+		{name: "no certificates", bundle: key, wantErr: true},
+		{name: "empty", bundle: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := InspectBundle([]byte(tt.bundle))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("InspectBundle: %v", err)
+			}
+
+			if res.State != tt.want || len(res.Fps) != tt.wantFps || len(res.Certs) != tt.wantFps || res.Duplicates != tt.wantDuplicates {
+				t.Fatalf("InspectBundle = state %s, %d fingerprints, %d certs, %d duplicates; want %s, %d, %d, %d",
+					res.State, len(res.Fps), len(res.Certs), res.Duplicates, tt.want, tt.wantFps, tt.wantFps, tt.wantDuplicates)
+			}
+		})
+	}
+}
+
+func TestBundleData(t *testing.T) {
+	anchorA := selfSignedPEM(t, "root.linkerd.cluster.local")
+	anchorB := selfSignedPEM(t, "root.linkerd.cluster.local")
+
+	cm := &v1.ConfigMap{Data: map[string]string{
+		"issuer-a.crt": anchorA,
+		"issuer-b.crt": anchorB,
+		"README":       "not a certificate",
+	}}
+
+	tests := []struct {
+		name      string
+		patterns  []string
+		wantCerts int
+		wantErr   bool
+	}{
+		{name: "default key missing", wantErr: true},
+		{name: "explicit keys", patterns: []string{"issuer-a.crt", "issuer-b.crt"}, wantCerts: 2},
+		{name: "glob", patterns: []string{"*.crt"}, wantCerts: 2},
+		{name: "overlapping patterns", patterns: []string{"*.crt", "issuer-a.crt"}, wantCerts: 2},
+		{name: "unmatched pattern", patterns: []string{"*.pem"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := bundleData(cm, tt.patterns)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			certs, err := certutil.Parse([]byte(raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(certs) != tt.wantCerts {
+				t.Fatalf("certs = %d, want %d", len(certs), tt.wantCerts)
+			}
+		})
+	}
+}
+
+func TestBundlePEM(t *testing.T) {
+	anchor := selfSignedPEM(t, "root.linkerd.cluster.local")
+	block, _ := pem.Decode([]byte(anchor))
+
+	for name, data := range map[string][]byte{"PEM": []byte(anchor + "\n"), "DER": block.Bytes} {
+		got, err := bundlePEM(data)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got != strings.TrimSpace(anchor) {
+			t.Errorf("%s: bundlePEM = %q, want the trimmed PEM anchor", name, got)
+		}
+	}
+
+	if _, err := bundlePEM([]byte("not a certificate")); err == nil {
+		t.Error("expected an error for a bundle without certificates")
+	}
+}
@@ -0,0 +1,140 @@
+package config_map
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/managed"
+)
+
+const (
+	// LabelPlanRecord marks plan ConfigMaps written by PersistPlan; the value is the rotation ID.
+	LabelPlanRecord = "trust-anchor.linkerd.edenlab.io/plan-rotation-id"
+	// defaultPlanRetention is how many plan ConfigMaps are kept per CR (protection.planConfigMapRetention).
+	defaultPlanRetention = 10
+)
+
+// PersistPlan records the rotation's data-plane plan (protection.persistPlanToConfigMap) in an immutable
+// ConfigMap named <cr>-plan-<timestamp>, together with the rotation ID, the trust anchor fingerprints and
+// the effective config, then deletes the CR's oldest plan ConfigMaps beyond the retention. A plan already
+// recorded for the current rotation is not written again.
+func (m *ManageConfigMap) PersistPlan(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, plan, planHash string) error {
+	if !obj.Spec.Protection.PersistPlanToConfigMap || len(obj.Status.RotationID) == 0 {
+		return nil
+	}
+
+	ns := planNamespace(obj)
+	records, err := m.planRecords(ctx, obj, ns)
+	if err != nil {
+		return err
+	}
+
+	for _, cm := range records {
+		if cm.Labels[LabelPlanRecord] == obj.Status.RotationID {
+			return nil
+		}
+	}
+
+	effective, err := json.MarshalIndent(obj.Status.EffectiveConfig, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal effective config: %w", err)
+	}
+
+	currentFP, previousFP := trustFingerprints(obj)
+	immutable := true
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-plan-%s", obj.Name, time.Now().UTC().Format("20060102-150405")),
+			Namespace: ns,
+			Labels:    managed.MergeLabels(managed.Labels(obj), map[string]string{LabelPlanRecord: obj.Status.RotationID}),
+		},
+		Immutable: &immutable,
+		Data: map[string]string{
+			"rotationID":           obj.Status.RotationID,
+			"planHash":             planHash,
+			"plan.yaml":            plan,
+			"currentFP":            currentFP,
+			"previousFP":           previousFP,
+			"effectiveConfig.json": string(effective),
+		},
+	}
+
+	if _, err := managed.SetOwner(obj, cm, m.Scheme, false); err != nil {
+		return fmt.Errorf("set owner of configmap %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+
+	if err := m.Client.Create(ctx, cm); err != nil {
+		return fmt.Errorf("create plan configmap %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	m.Logger.Info(fmt.Sprintf("Recorded the plan of rotation %s in ConfigMap %s/%s", obj.Status.RotationID, cm.Namespace, cm.Name))
+
+	return m.prunePlanRecords(ctx, obj, append(records, *cm))
+}
+
+// planRecords lists the CR's plan ConfigMaps in ns.
+func (m *ManageConfigMap) planRecords(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, ns string) ([]v1.ConfigMap, error) {
+	var list v1.ConfigMapList
+	if err := m.Client.List(ctx, &list, client.InNamespace(ns), client.MatchingLabels{
+		managed.OwnerNameKey: obj.Name,
+		managed.OwnerNsKey:   obj.Namespace,
+	}, client.HasLabels{LabelPlanRecord}); err != nil {
+		return nil, fmt.Errorf("list plan configmaps in %q: %w", ns, err)
+	}
+
+	return list.Items, nil
+}
+
+// prunePlanRecords deletes the oldest plan ConfigMaps beyond protection.planConfigMapRetention.
+// Names end in a UTC timestamp, so they sort chronologically.
+func (m *ManageConfigMap) prunePlanRecords(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, records []v1.ConfigMap) error {
+	keep := defaultPlanRetention
+	if n := obj.Spec.Protection.PlanConfigMapRetention; n > 0 {
+		keep = int(n)
+	}
+
+	// newest first
+	sort.Slice(records, func(i, j int) bool { return records[i].Name > records[j].Name })
+
+	for i := keep; i < len(records); i++ {
+		old := &records[i]
+		if err := m.Client.Delete(ctx, old); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("delete plan configmap %s/%s: %w", old.Namespace, old.Name, err)
+		}
+		m.Logger.Info(fmt.Sprintf("Deleted plan ConfigMap %s/%s beyond protection.planConfigMapRetention=%d",
+			old.Namespace, old.Name, keep))
+	}
+
+	return nil
+}
+
+// planNamespace returns protection.planConfigMapNamespace, or the CR's namespace when unset.
+func planNamespace(obj *trv1alpha1.LinkerdTrustRotation) string {
+	if ns := obj.Spec.Protection.PlanConfigMapNamespace; len(ns) > 0 {
+		return ns
+	}
+
+	return obj.Namespace
+}
+
+// trustFingerprints returns the full trust anchor fingerprints recorded in status.
+func trustFingerprints(obj *trv1alpha1.LinkerdTrustRotation) (string, string) {
+	trust := obj.Status.Trust
+	if trust == nil {
+		return "", ""
+	}
+
+	current, previous := trust.CurrentFullFP, trust.PreviousFullFP
+	if len(current) == 0 {
+		current, previous = trust.CurrentFP, trust.PreviousFP
+	}
+
+	return current, previous
+}
@@ -0,0 +1,80 @@
+package config_map
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/managed"
+)
+
+func TestPersistPlan(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := trv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	obj := &trv1alpha1.LinkerdTrustRotation{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "rotation", UID: "uid"}}
+	obj.Spec.Protection.PersistPlanToConfigMap = true
+	obj.Spec.Protection.PlanConfigMapRetention = 2
+	obj.Status.RotationID = "rot-3"
+	obj.Status.Trust = &trv1alpha1.TrustStatus{CurrentFP: "sha256:aa", CurrentFullFP: "sha256:aaaa", PreviousFullFP: "sha256:bbbb"}
+	obj.Status.EffectiveConfig = &trv1alpha1.EffectiveConfig{CheckMode: trv1alpha1.CheckModeJob}
+
+	record := func(name, rotationID string) *v1.ConfigMap {
+		return &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name,
+			Labels: managed.MergeLabels(managed.Labels(obj), map[string]string{LabelPlanRecord: rotationID})}}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		record("rotation-plan-20250101-000000", "rot-1"),
+		record("rotation-plan-20250201-000000", "rot-2"),
+	).Build()
+	m := New(c, scheme, logr.Discard())
+	ctx := context.Background()
+
+	if err := m.PersistPlan(ctx, obj, "- kind: Deployment\n", "hash"); err != nil {
+		t.Fatal(err)
+	}
+
+	list := func() []v1.ConfigMap {
+		var cms v1.ConfigMapList
+		if err := c.List(ctx, &cms, client.InNamespace(testNamespace), client.HasLabels{LabelPlanRecord}); err != nil {
+			t.Fatal(err)
+		}
+		return cms.Items
+	}
+
+	cms := list()
+	if len(cms) != 2 || cms[0].Name != "rotation-plan-20250201-000000" {
+		t.Fatalf("plan configmaps = %d (first %s), want the oldest pruned to 2", len(cms), cms[0].Name)
+	}
+	got := cms[1]
+	if got.Labels[LabelPlanRecord] != "rot-3" || got.Immutable == nil || !*got.Immutable || len(got.OwnerReferences) != 1 {
+		t.Errorf("plan configmap %s: labels %v, immutable %v, owners %v", got.Name, got.Labels, got.Immutable, got.OwnerReferences)
+	}
+	if got.Data["plan.yaml"] != "- kind: Deployment\n" || got.Data["planHash"] != "hash" ||
+		got.Data["currentFP"] != "sha256:aaaa" || got.Data["previousFP"] != "sha256:bbbb" ||
+		!strings.Contains(got.Data["effectiveConfig.json"], `"checkMode": "Job"`) {
+		t.Errorf("plan configmap data = %v", got.Data)
+	}
+
+	// The same rotation is recorded once.
+	if err := m.PersistPlan(ctx, obj, "changed", "hash2"); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(list()); n != 2 {
+		t.Errorf("plan configmaps after a second call = %d, want 2", n)
+	}
+}
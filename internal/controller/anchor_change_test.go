@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/rollout"
+)
+
+// TestAnchorChangeAfterFailedRotation checks that a rotation failing after the control-plane phase does not
+// let the rotation for the next anchor skip the issuer deletion and the control-plane restart.
+func TestAnchorChangeAfterFailedRotation(t *testing.T) {
+	oldAnchor, firstAnchor, secondAnchor := anchorPEM(t), anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Protection.RunLinkerdCheckProxy = true
+		ltr.Spec.Protection.MaxRolloutFailures = 5
+	})
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+
+	// The data-plane check fails after the control plane was rotated for the first anchor.
+	sim.failProxyChecks = true
+	sim.rotateAnchor(firstAnchor)
+	var failed *trv1alpha1.LinkerdTrustRotation
+	for range 5 {
+		_, _ = sim.r.Reconcile(sim.ctx, sim.req)
+		if got := sim.get(); got.Status.Phase != nil && *got.Status.Phase == trv1alpha1.PhaseFailed {
+			failed = got
+			break
+		}
+	}
+	if failed == nil || !failed.Status.ControlPlaneDone || !failed.Status.IssuerSecretDeleted {
+		t.Fatalf("want a failed rotation past the control-plane phase (transitions: %v)", sim.transitions)
+	}
+	firstRotation, firstFP := failed.Status.RotationID, failed.Status.ControlPlaneRotatedFP
+
+	// Linkerd re-issued the issuer in the meantime; the next anchor must delete it again.
+	issuer := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: simLinkerdNs, Name: linkerdIdentityIssuerSecret},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{"tls.crt": []byte("issuer"), "tls.key": []byte("key")},
+	}
+	if err := sim.client.Create(sim.ctx, issuer); err != nil {
+		t.Fatal(err)
+	}
+
+	sim.failProxyChecks = false
+	sim.transitions = nil
+	sim.rotateAnchor(secondAnchor)
+	got := sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 5)
+
+	sim.expectTransitions(
+		string(trv1alpha1.PhaseRollingControlPlane)+"/"+string(trv1alpha1.ReasonEnteringDestructivePhase),
+		string(trv1alpha1.PhaseRollingControlPlane)+"/"+string(trv1alpha1.ReasonControlPlaneReady),
+		string(trv1alpha1.PhaseSucceeded)+"/Completed",
+	)
+	if got.Status.RotationID == firstRotation {
+		t.Error("rotation for the new anchor reused the failed rotation's ID")
+	}
+	if fp := rollout.CurrentFP(got); got.Status.ControlPlaneRotatedFP != fp || fp == firstFP {
+		t.Errorf("control plane rotated for %q, want the new anchor %q", got.Status.ControlPlaneRotatedFP, fp)
+	}
+	err := sim.client.Get(sim.ctx, types.NamespacedName{Namespace: simLinkerdNs, Name: linkerdIdentityIssuerSecret},
+		&corev1.Secret{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("issuer secret: got %v, want it deleted for the new anchor", err)
+	}
+}
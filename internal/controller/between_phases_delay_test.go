@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// TestBetweenPhasesDelay checks that the delay between the control-plane and data-plane phases holds the
+// rotation by requeueing against status.controlPlaneDoneAt, so a later reconcile (e.g. after an operator
+// restart) keeps waiting instead of skipping it.
+func TestBetweenPhasesDelay(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Protection.BetweenPhasesDelay = &metav1.Duration{Duration: time.Hour}
+	})
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+
+	sim.rotateAnchor(newAnchor)
+	for range 2 {
+		res, err := sim.r.Reconcile(sim.ctx, sim.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := sim.get()
+		if got.Status.Reason == nil || *got.Status.Reason != trv1alpha1.ReasonHoldTimerRunning {
+			t.Fatalf("reason %v, want %s (transitions: %v)", got.Status.Reason, trv1alpha1.ReasonHoldTimerRunning,
+				sim.transitions)
+		}
+		if !got.Status.ControlPlaneDone || got.Status.ControlPlaneDoneAt == nil {
+			t.Fatal("want the control-plane phase recorded as done with its completion time")
+		}
+		if res.RequeueAfter <= 0 || res.RequeueAfter > time.Hour {
+			t.Fatalf("requeue after %s, want the remaining delay", res.RequeueAfter)
+		}
+		if sim.restartedAt(simAppNs, "web") != "" {
+			t.Fatal("data plane restarted before the delay elapsed")
+		}
+	}
+
+	// Once the delay has elapsed, the data plane is rolled.
+	cr := sim.get()
+	cr.Status.ControlPlaneDoneAt = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	if err := sim.client.Status().Update(sim.ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	got := sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 3)
+	if sim.restartedAt(simAppNs, "web") == "" {
+		t.Error("data plane not restarted after the delay")
+	}
+	if got.Status.ControlPlaneDone || got.Status.ControlPlaneDoneAt != nil {
+		t.Error("control-plane completion not cleared after the rotation succeeded")
+	}
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func anchorPEM(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "root.linkerd.cluster.local"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// TestBootstrapOnlyFirstRun walks a fresh install with linkerd.bootstrapOnly: the first reconcile only
+// bootstraps the previous secret, the next one stays idle, and an anchor change is then detected as usual.
+func TestBootstrapOnlyFirstRun(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := trv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	current := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "linkerd", Name: "linkerd-trust-anchor"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{"tls.crt": anchorPEM(t), "tls.key": []byte("key")},
+	}
+	ltr := &trv1alpha1.LinkerdTrustRotation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "linkerd", Name: "rotation"},
+		Spec: trv1alpha1.LinkerdTrustRotationSpec{
+			DryRun:  true,
+			Trigger: trv1alpha1.RotationTrigger{OnTrustAnchorSecretsDiff: true},
+			Linkerd: trv1alpha1.LinkerdSpec{
+				Namespace:                 "linkerd",
+				TrustAnchorSecret:         "linkerd-trust-anchor",
+				PreviousTrustAnchorSecret: "linkerd-previous-anchor",
+				BootstrapPreviousSecret:   true,
+				BootstrapOnly:             true,
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(current, ltr).
+		WithStatusSubresource(&trv1alpha1.LinkerdTrustRotation{}).Build()
+	r := &LinkerdTrustRotationReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(ltr)}
+	ctx := context.Background()
+
+	reconcile := func(step string) *trv1alpha1.LinkerdTrustRotation {
+		t.Helper()
+
+		res, err := r.Reconcile(ctx, req)
+		if err != nil {
+			t.Fatalf("%s: reconcile: %v", step, err)
+		}
+		if res.RequeueAfter <= 0 {
+			t.Fatalf("%s: expected a requeue", step)
+		}
+
+		got := &trv1alpha1.LinkerdTrustRotation{}
+		if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+			t.Fatal(err)
+		}
+
+		return got
+	}
+
+	expect := func(step string, got *trv1alpha1.LinkerdTrustRotation, phase trv1alpha1.Phase, state trv1alpha1.BundleState) {
+		t.Helper()
+
+		if got.Status.Phase == nil || *got.Status.Phase != phase {
+			t.Fatalf("%s: phase = %v, want %s", step, got.Status.Phase, phase)
+		}
+		if got.Status.Trust == nil || got.Status.Trust.BundleState == nil || *got.Status.Trust.BundleState != state {
+			t.Fatalf("%s: bundle state = %+v, want %s", step, got.Status.Trust, state)
+		}
+	}
+
+	got := reconcile("first run")
+	expect("first run", got, trv1alpha1.PhaseBootstrap, trv1alpha1.BundleStateSingle)
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "linkerd", Name: "linkerd-previous-anchor"}, &corev1.Secret{}); err != nil {
+		t.Fatalf("first run: previous secret not bootstrapped: %v", err)
+	}
+
+	got = reconcile("steady state")
+	expect("steady state", got, trv1alpha1.PhaseIdle, trv1alpha1.BundleStateSingle)
+
+	current.Data["tls.crt"] = anchorPEM(t)
+	if err := c.Update(ctx, current); err != nil {
+		t.Fatal(err)
+	}
+
+	// DryRun stops right after detection, so the overlap shows up without a rollout.
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("anchor change: reconcile: %v", err)
+	}
+	got = &trv1alpha1.LinkerdTrustRotation{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Trust == nil || *got.Status.Trust.BundleState != trv1alpha1.BundleStateOverlap {
+		t.Fatalf("anchor change: bundle state = %+v, want overlap", got.Status.Trust)
+	}
+}
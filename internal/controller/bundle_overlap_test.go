@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// TestAwaitBundleOverlap checks that, with the bundle published by trust-manager, diverged secrets hold the
+// rotation in Detecting and requeue until the ConfigMap overlaps (reporting BundlePropagationTimeout once
+// trigger.bundlePropagationTimeout has passed), and that the rotation proceeds as soon as it does.
+func TestAwaitBundleOverlap(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Trigger.OnTrustRootsConfigMapChange = true
+		ltr.Spec.Trigger.BundlePropagationTimeout = &metav1.Duration{Duration: time.Hour}
+		ltr.Spec.Linkerd.ManageTrustBundle = false
+	})
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+
+	sim.rotateAnchor(newAnchor)
+	reconcileAwaiting := func(want trv1alpha1.Reason) {
+		t.Helper()
+
+		res, err := sim.r.Reconcile(sim.ctx, sim.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := sim.get()
+		if got.Status.Reason == nil || *got.Status.Reason != want {
+			t.Fatalf("reason %v, want %s (transitions: %v)", got.Status.Reason, want, sim.transitions)
+		}
+		if res.RequeueAfter <= 0 {
+			t.Fatal("want a requeue while the bundle does not overlap")
+		}
+		if sim.restartedAt(simLinkerdNs, "linkerd-identity") != "" || sim.restartedAt(simAppNs, "web") != "" {
+			t.Fatal("mesh restarted before the bundle overlapped")
+		}
+	}
+
+	for range 2 {
+		reconcileAwaiting(trv1alpha1.ReasonAwaitingBundleOverlap)
+	}
+
+	// Past the propagation timeout the wait is reported, but still nothing is restarted.
+	cr := sim.get()
+	cr.Status.DivergedSince = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	if err := sim.client.Status().Update(sim.ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	reconcileAwaiting(trv1alpha1.ReasonBundlePropagationTimeout)
+
+	var warned bool
+	events := sim.r.Recorder.(*record.FakeRecorder).Events
+	for len(events) > 0 {
+		if e := <-events; strings.Contains(e, string(trv1alpha1.ReasonBundlePropagationTimeout)) {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Error("want a BundlePropagationTimeout event")
+	}
+
+	// trust-manager publishes the overlap: the rotation proceeds.
+	cm := &corev1.ConfigMap{}
+	if err := sim.client.Get(sim.ctx, types.NamespacedName{Namespace: simLinkerdNs, Name: simTrustRoots}, cm); err != nil {
+		t.Fatal(err)
+	}
+	cm.Data["ca-bundle.crt"] = string(oldAnchor) + string(newAnchor)
+	if err := sim.client.Update(sim.ctx, cm); err != nil {
+		t.Fatal(err)
+	}
+
+	sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 5)
+	if sim.restartedAt(simAppNs, "web") == "" {
+		t.Error("data plane not restarted after the bundle overlapped")
+	}
+}
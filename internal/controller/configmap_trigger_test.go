@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// TestConfigMapTriggerIssuerCrossCheck checks that the ConfigMap-only trigger refuses to roll out an overlap
+// bundle the identity issuer does not chain to.
+func TestConfigMapTriggerIssuerCrossCheck(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, newAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Trigger = trv1alpha1.RotationTrigger{OnTrustRootsConfigMapChange: true}
+		ltr.Spec.Linkerd.ManageTrustBundle = false
+		ltr.Spec.DryRun = true
+	})
+
+	cm := &corev1.ConfigMap{}
+	if err := sim.client.Get(sim.ctx, types.NamespacedName{Namespace: simLinkerdNs, Name: simTrustRoots}, cm); err != nil {
+		t.Fatal(err)
+	}
+	cm.Data["ca-bundle.crt"] = string(oldAnchor) + string(newAnchor)
+	if err := sim.client.Update(sim.ctx, cm); err != nil {
+		t.Fatal(err)
+	}
+
+	// The sim's issuer secret holds no certificate at all.
+	if _, err := sim.r.Reconcile(sim.ctx, sim.req); err == nil {
+		t.Fatal("reconcile with an unrelated issuer succeeded")
+	}
+	got := sim.get()
+	if got.Status.Phase == nil || *got.Status.Phase != trv1alpha1.PhaseFailed ||
+		got.Status.Reason == nil || *got.Status.Reason != trv1alpha1.ReasonIssuerAnchorMismatch {
+		t.Fatalf("status = %v/%v, want Failed/IssuerAnchorMismatch", got.Status.Phase, got.Status.Reason)
+	}
+
+	issuer := &corev1.Secret{}
+	if err := sim.client.Get(sim.ctx, types.NamespacedName{Namespace: simLinkerdNs, Name: linkerdIdentityIssuerSecret},
+		issuer); err != nil {
+		t.Fatal(err)
+	}
+	issuer.Data["tls.crt"] = oldAnchor
+	if err := sim.client.Update(sim.ctx, issuer); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sim.r.Reconcile(sim.ctx, sim.req); err != nil {
+		t.Fatalf("reconcile with an issuer chaining to the bundle: %v", err)
+	}
+	if got := sim.get(); got.Status.Reason != nil && *got.Status.Reason == trv1alpha1.ReasonIssuerAnchorMismatch {
+		t.Errorf("issuer mismatch still reported: %v", *got.Status.Reason)
+	}
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// isControlPlaneOwner reports whether the CR restarts the shared control plane and cleans up (default: true).
+func isControlPlaneOwner(obj *trv1alpha1.LinkerdTrustRotation) bool {
+	return obj.Spec.Linkerd.ControlPlaneOwner == nil || *obj.Spec.Linkerd.ControlPlaneOwner
+}
+
+// isManagedIssuer reports whether the identity issuer is re-issued by cert-manager after deletion (default: true).
+func isManagedIssuer(obj *trv1alpha1.LinkerdTrustRotation) bool {
+	return obj.Spec.Linkerd.ManagedIssuer == nil || *obj.Spec.Linkerd.ManagedIssuer
+}
+
+// issuerStrategy returns how a managed issuer is handled around the control-plane restart
+// (default: DeleteThenRestart).
+func issuerStrategy(obj *trv1alpha1.LinkerdTrustRotation) trv1alpha1.IssuerStrategy {
+	if obj.Spec.Linkerd.IssuerStrategy == "" {
+		return trv1alpha1.IssuerStrategyDeleteThenRestart
+	}
+
+	return obj.Spec.Linkerd.IssuerStrategy
+}
+
+// peers returns the other CRs of the same rotation class managing the same Linkerd installation
+// (same control-plane namespace). CRs of another class belong to another operator instance and are ignored.
+func (r *LinkerdTrustRotationReconciler) peers(ctx context.Context,
+	obj *trv1alpha1.LinkerdTrustRotation) ([]trv1alpha1.LinkerdTrustRotation, error) {
+	var list trv1alpha1.LinkerdTrustRotationList
+	if err := r.Client.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("list LinkerdTrustRotations: %w", err)
+	}
+
+	var out []trv1alpha1.LinkerdTrustRotation
+	for _, item := range list.Items {
+		if item.UID == obj.UID || item.Spec.Linkerd.Namespace != obj.Spec.Linkerd.Namespace ||
+			!inRotationClass(&item, obj.Spec.RotationClass) {
+			continue
+		}
+		out = append(out, item)
+	}
+
+	return out, nil
+}
+
+// controlPlaneOwner returns the single peer owning the control plane of the CR's Linkerd installation.
+func (r *LinkerdTrustRotationReconciler) controlPlaneOwner(ctx context.Context,
+	obj *trv1alpha1.LinkerdTrustRotation) (*trv1alpha1.LinkerdTrustRotation, error) {
+	peers, err := r.peers(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []trv1alpha1.LinkerdTrustRotation
+	for _, p := range peers {
+		if isControlPlaneOwner(&p) {
+			owners = append(owners, p)
+		}
+	}
+
+	switch len(owners) {
+	case 0:
+		return nil, fmt.Errorf("no control-plane owner found for Linkerd namespace %s; set linkerd.controlPlaneOwner on one CR",
+			obj.Spec.Linkerd.Namespace)
+	case 1:
+		return &owners[0], nil
+	default:
+		return nil, fmt.Errorf("%d control-plane owners found for Linkerd namespace %s; exactly one is allowed",
+			len(owners), obj.Spec.Linkerd.Namespace)
+	}
+}
+
+// pendingPeers returns the namespaced names of non-owner, non-dry-run peers whose data plane
+// is not yet rotated for fp.
+func (r *LinkerdTrustRotationReconciler) pendingPeers(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation,
+	fp string) ([]string, error) {
+	peers, err := r.peers(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for _, p := range peers {
+		if !isControlPlaneOwner(&p) && !p.Spec.DryRun && p.Status.DataPlaneRotatedFP != fp {
+			pending = append(pending, fmt.Sprintf("%s/%s", p.Namespace, p.Name))
+		}
+	}
+	sort.Strings(pending)
+
+	return pending, nil
+}
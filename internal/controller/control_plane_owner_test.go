@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/rollout"
+)
+
+// addPeer creates another CR for the sim's Linkerd installation, copying the sim CR's spec with the default
+// control-plane ownership; mutate, if set, adjusts it before creation.
+func (s *rotationSim) addPeer(name string, mutate func(*trv1alpha1.LinkerdTrustRotation)) *trv1alpha1.LinkerdTrustRotation {
+	s.t.Helper()
+
+	peer := &trv1alpha1.LinkerdTrustRotation{Spec: *s.get().Spec.DeepCopy()}
+	peer.Namespace, peer.Name, peer.UID = simLinkerdNs, name, types.UID(name)
+	peer.Spec.Linkerd.ControlPlaneOwner = nil
+	if mutate != nil {
+		mutate(peer)
+	}
+
+	if err := s.client.Create(s.ctx, peer); err != nil {
+		s.t.Fatal(err)
+	}
+
+	return peer
+}
+
+// nonOwner marks a peer as not owning the control plane.
+func nonOwner(ltr *trv1alpha1.LinkerdTrustRotation) {
+	ltr.Spec.Linkerd.ControlPlaneOwner = new(bool)
+}
+
+func TestIsControlPlaneOwner(t *testing.T) {
+	yes, no := true, false
+	for _, tc := range []struct {
+		name  string
+		owner *bool
+		want  bool
+	}{
+		{name: "unset", want: true},
+		{name: "true", owner: &yes, want: true},
+		{name: "false", owner: &no, want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ltr := &trv1alpha1.LinkerdTrustRotation{}
+			ltr.Spec.Linkerd.ControlPlaneOwner = tc.owner
+			if got := isControlPlaneOwner(ltr); got != tc.want {
+				t.Fatalf("isControlPlaneOwner = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestControlPlaneOwner(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		peers   map[string]func(*trv1alpha1.LinkerdTrustRotation)
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "single owner",
+			peers: map[string]func(*trv1alpha1.LinkerdTrustRotation){"owner": nil},
+			want:  "owner",
+		},
+		{
+			name:    "no owner",
+			peers:   map[string]func(*trv1alpha1.LinkerdTrustRotation){"peer": nonOwner},
+			wantErr: true,
+		},
+		{
+			name:    "two owners",
+			peers:   map[string]func(*trv1alpha1.LinkerdTrustRotation){"owner-a": nil, "owner-b": nil},
+			wantErr: true,
+		},
+		{
+			name: "owner of another Linkerd installation ignored",
+			peers: map[string]func(*trv1alpha1.LinkerdTrustRotation){
+				"owner": nil,
+				"other-install": func(ltr *trv1alpha1.LinkerdTrustRotation) {
+					ltr.Spec.Linkerd.Namespace = "linkerd-canary"
+				},
+			},
+			want: "owner",
+		},
+		{
+			name: "owner of another rotation class ignored",
+			peers: map[string]func(*trv1alpha1.LinkerdTrustRotation){
+				"owner": nil,
+				"other-class": func(ltr *trv1alpha1.LinkerdTrustRotation) {
+					ltr.Spec.RotationClass = "canary"
+				},
+			},
+			want: "owner",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sim := newRotationSim(t, anchorPEM(t), nonOwner)
+			for name, mutate := range tc.peers {
+				sim.addPeer(name, mutate)
+			}
+
+			got, err := sim.r.controlPlaneOwner(sim.ctx, sim.get())
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("want an error, got owner %s", got.Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != tc.want {
+				t.Fatalf("owner = %s, want %s", got.Name, tc.want)
+			}
+		})
+	}
+}
+
+func TestPendingPeers(t *testing.T) {
+	const fp = "fp"
+
+	sim := newRotationSim(t, anchorPEM(t), nil)
+	for _, name := range []string{"web-b", "web-a"} {
+		sim.addPeer(name, nonOwner)
+	}
+	sim.addPeer("rotated", nonOwner)
+	sim.addPeer("dry-run", func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		nonOwner(ltr)
+		ltr.Spec.DryRun = true
+	})
+	sim.addPeer("second-owner", nil)
+	sim.addPeer("other-class", func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		nonOwner(ltr)
+		ltr.Spec.RotationClass = "canary"
+	})
+
+	rotated := &trv1alpha1.LinkerdTrustRotation{}
+	if err := sim.client.Get(sim.ctx, types.NamespacedName{Namespace: simLinkerdNs, Name: "rotated"}, rotated); err != nil {
+		t.Fatal(err)
+	}
+	rotated.Status.DataPlaneRotatedFP = fp
+	if err := sim.client.Status().Update(sim.ctx, rotated); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := sim.r.pendingPeers(sim.ctx, sim.get(), fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{simLinkerdNs + "/web-a", simLinkerdNs + "/web-b"}; !slices.Equal(got, want) {
+		t.Fatalf("pending = %v, want %v", got, want)
+	}
+}
+
+// TestRotationWithPeers runs a rotation with the owner and a non-owner CR of this operator's class, next to
+// an owner and a non-owner of another class: the other class neither counts as a second owner for the
+// non-owner nor holds the owner's cleanup, and the owner cleans up once the peer's data plane is rotated.
+func TestRotationWithPeers(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, nil)
+	peer := sim.addPeer("peer", nonOwner)
+	otherClass := func(ltr *trv1alpha1.LinkerdTrustRotation) { ltr.Spec.RotationClass = "canary" }
+	sim.addPeer("canary-owner", otherClass)
+	sim.addPeer("canary-peer", func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		otherClass(ltr)
+		nonOwner(ltr)
+	})
+
+	peerReq := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: peer.Namespace, Name: peer.Name}}
+	getPeer := func() *trv1alpha1.LinkerdTrustRotation {
+		got := &trv1alpha1.LinkerdTrustRotation{}
+		if err := sim.client.Get(sim.ctx, peerReq.NamespacedName, got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+	for range 3 {
+		if _, err := sim.r.Reconcile(sim.ctx, peerReq); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sim.rotateAnchor(newAnchor)
+	// The owner rotates its data plane, then waits for the peer before cleaning up.
+	sim.reconcileUntil(trv1alpha1.PhaseHold, 10)
+	if got := sim.get(); got.Status.Reason == nil || *got.Status.Reason != trv1alpha1.ReasonAwaitingPeers {
+		t.Fatalf("want the owner awaiting peers (transitions: %v)", sim.transitions)
+	}
+	if msg := *sim.get().Status.Message; msg != "Waiting for data planes of "+simLinkerdNs+"/peer before cleanup" {
+		t.Fatalf("unexpected owner message %q", msg)
+	}
+
+	fp := rollout.CurrentFP(sim.get())
+	for step := 0; getPeer().Status.DataPlaneRotatedFP != fp; step++ {
+		if step == 10 {
+			t.Fatalf("peer data plane not rotated (peer status: %+v)", getPeer().Status)
+		}
+		if _, err := sim.r.Reconcile(sim.ctx, peerReq); err != nil {
+			t.Fatalf("reconcile peer: %v", err)
+		}
+	}
+
+	sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 5)
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// TestNewRotationWaitsForControlPlaneRollout checks that a trigger arriving while a control-plane
+// Deployment is still rolling out holds the rotation until the rollout settles.
+func TestNewRotationWaitsForControlPlaneRollout(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, nil)
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+
+	setUpdated := func(n int32) {
+		t.Helper()
+
+		dep := &appsv1.Deployment{}
+		key := types.NamespacedName{Namespace: simLinkerdNs, Name: "linkerd-identity"}
+		if err := sim.client.Get(sim.ctx, key, dep); err != nil {
+			t.Fatal(err)
+		}
+		dep.Status.UpdatedReplicas = n
+		if err := sim.client.Status().Update(sim.ctx, dep); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	setUpdated(0)
+	sim.rotateAnchor(newAnchor)
+
+	res, err := sim.r.Reconcile(sim.ctx, sim.req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := sim.get()
+	if got.Status.Reason == nil || *got.Status.Reason != trv1alpha1.ReasonAwaitingControlPlaneRollout {
+		t.Fatalf("reason = %v, want AwaitingControlPlaneRollout (transitions: %v)", got.Status.Reason, sim.transitions)
+	}
+	if got.Status.RotationID != "" || res.RequeueAfter != controlPlaneSettleRequeue {
+		t.Errorf("rotation %q started / requeue %s while the control plane was rolling out",
+			got.Status.RotationID, res.RequeueAfter)
+	}
+	if sim.restartedAt(simLinkerdNs, "linkerd-identity") != "" {
+		t.Error("control plane restarted while a rollout was unfinished")
+	}
+
+	setUpdated(1)
+	sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 5)
+}
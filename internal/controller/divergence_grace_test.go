@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// TestDivergenceGracePeriod checks that a secret divergence is only treated as a rotation once it has persisted
+// for trigger.divergenceGracePeriod: a divergence reverted within the period is forgotten without touching
+// the mesh, and one outlasting it rotates as usual.
+func TestDivergenceGracePeriod(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Trigger.DivergenceGracePeriod = &metav1.Duration{Duration: time.Hour}
+	})
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+
+	sim.rotateAnchor(newAnchor)
+	for range 2 {
+		if _, err := sim.r.Reconcile(sim.ctx, sim.req); err != nil {
+			t.Fatal(err)
+		}
+
+		got := sim.get()
+		if got.Status.Reason == nil || *got.Status.Reason != trv1alpha1.ReasonDivergenceGracePeriod {
+			t.Fatalf("reason %v, want %s (transitions: %v)", got.Status.Reason, trv1alpha1.ReasonDivergenceGracePeriod,
+				sim.transitions)
+		}
+		if got.Status.DivergedSince == nil {
+			t.Fatal("want status.divergedSince set while the divergence is debounced")
+		}
+	}
+	if sim.restartedAt(simLinkerdNs, "linkerd-identity") != "" || sim.restartedAt(simAppNs, "web") != "" {
+		t.Fatal("mesh restarted within the grace period")
+	}
+
+	// A transient divergence that reverts is forgotten.
+	sim.rotateAnchor(oldAnchor)
+	got := sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+	if got.Status.DivergedSince != nil {
+		t.Fatal("status.divergedSince not cleared after the divergence reverted")
+	}
+
+	// A divergence outlasting the grace period rotates.
+	sim.rotateAnchor(newAnchor)
+	if _, err := sim.r.Reconcile(sim.ctx, sim.req); err != nil {
+		t.Fatal(err)
+	}
+	cr := sim.get()
+	if cr.Status.DivergedSince == nil {
+		t.Fatal("want status.divergedSince set for the new divergence")
+	}
+	cr.Status.DivergedSince = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	if err := sim.client.Status().Update(sim.ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 5)
+	if sim.restartedAt(simAppNs, "web") == "" {
+		t.Error("data plane not restarted after the grace period")
+	}
+	sim.expectTransitions(
+		string(trv1alpha1.PhaseDetecting)+"/"+string(trv1alpha1.ReasonDivergenceGracePeriod),
+		string(trv1alpha1.PhaseDetecting)+"/"+string(trv1alpha1.ReasonSecretsDiverged),
+	)
+}
@@ -18,7 +18,14 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"runtime/debug"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -28,13 +35,16 @@ import (
 	_ "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/certutil"
 	"linkerd-trust-rotator.operators.infra/internal/config_map"
 	"linkerd-trust-rotator.operators.infra/internal/rollout"
 	"linkerd-trust-rotator.operators.infra/internal/secret"
@@ -44,13 +54,46 @@ import (
 const (
 	frequency                   = time.Second * 10
 	linkerdIdentityIssuerSecret = "linkerd-identity-issuer"
+	// issuerReissueTimeout bounds the wait for the issuer secret to be re-issued before verification.
+	issuerReissueTimeout = time.Minute * 2
+	// degradedRequeue is how long to wait before retrying after a workload was degraded or deleted mid-rollout.
+	degradedRequeue = time.Minute * 1
+	// throttledRequeue is added to the server's Retry-After before retrying a throttled rollout.
+	throttledRequeue = time.Second * 5
+	// controlPlaneSettleRequeue is how often a new rotation rechecks an unfinished control-plane rollout.
+	controlPlaneSettleRequeue = time.Second * 30
+	// yieldRequeue is how soon a data-plane rollout resumes after using up its per-reconcile budget.
+	yieldRequeue = time.Second * 1
+	// ongoingRolloutRequeue is how soon Deployments deferred for an ongoing rollout are retried.
+	ongoingRolloutRequeue = time.Second * 30
 )
 
+// errAwaitingApproval is returned by restartDataPlane when the plan no longer matches rollout.approvedPlanHash
+// or the canary sample waits for rollout.canaryApprovedRotation.
+var errAwaitingApproval = errors.New("data-plane rollout awaiting approval")
+
 // LinkerdTrustRotationReconciler reconciles a LinkerdTrustRotation object
 type LinkerdTrustRotationReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// RequeueJitter spreads the steady-state requeue by ±RequeueJitter (e.g. 0.2 for ±20%),
+	// so many CRs do not reconcile in lockstep. Zero disables jitter.
+	RequeueJitter float64
+
+	// RotationClass limits reconciliation to CRs with the same spec.rotationClass,
+	// so several operator instances (e.g. stable and canary) can share a cluster.
+	RotationClass string
+
+	// AllowedCRKinds, if set, is the only CustomResource GVKs target scopes may select,
+	// so CR authors can't point the operator at arbitrary resources. Empty allows all.
+	AllowedCRKinds []schema.GroupVersionKind
+
+	// RolloutPollInterval and RolloutConfirmWindow override the rollout waiters' poll interval and
+	// Deployment confirmation window (see rollout.ManageRollout); zero keeps the defaults.
+	RolloutPollInterval  time.Duration
+	RolloutConfirmWindow time.Duration
 }
 
 // +kubebuilder:rbac:groups=trust-anchor.linkerd.edenlab.io,resources=linkerdtrustrotations,verbs=get;list;watch;create;update;patch;delete
@@ -67,26 +110,98 @@ type LinkerdTrustRotationReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.22.1/pkg/reconcile
-func (r *LinkerdTrustRotationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	var bundleStatus trv1alpha1.BundleState
-
+func (r *LinkerdTrustRotationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, retErr error) {
 	reqLogger := logf.FromContext(ctx)
 
 	statusMgr := status.New(r.Client, r.Scheme, reqLogger)
-	configMapMgr := config_map.New(r.Client, r.Scheme, reqLogger)
-	secretMgr := secret.New(r.Client, r.Scheme, reqLogger)
-	rolloutMgr := rollout.New(r.Client, r.Scheme, reqLogger, statusMgr)
 	lTR := &trv1alpha1.LinkerdTrustRotation{}
 
 	if err := r.Client.Get(ctx, req.NamespacedName, lTR); err != nil {
 		if apierrors.IsNotFound(err) {
 			reqLogger.Error(nil, fmt.Sprintf("Can not find CRD by name: %s", req.Name))
+			rollout.ForgetCheckJobLimiter(req.NamespacedName)
+			rollout.ForgetRotationMetrics(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 
 		return ctrl.Result{}, err
 	}
 
+	// The event filter drops other classes, but a requeue scheduled before a class change still arrives here.
+	if lTR.Spec.RotationClass != r.RotationClass {
+		reqLogger.Info("Skipping CR of another rotation class", "rotationClass", lTR.Spec.RotationClass)
+		return ctrl.Result{}, nil
+	}
+
+	defer r.recoverPanic(ctx, reqLogger, statusMgr, lTR, &retErr)
+
+	result, err := r.reconcileRotation(ctx, reqLogger, statusMgr, lTR)
+	if err != nil {
+		// Record the error in status, but never let a failed status update mask the original error.
+		if statusErr := statusMgr.SetReconcileError(ctx, lTR, err.Error()); statusErr != nil {
+			reqLogger.Error(statusErr, "Unable to record reconcile error in status")
+		}
+
+		return result, err
+	}
+
+	if err := statusMgr.SetReconcileError(ctx, lTR, ""); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// recoverPanic, deferred in Reconcile, turns a panic into a Failed status with ReasonInternalError
+// and a returned error, so the CR shows the failure and the request is retried with backoff.
+func (r *LinkerdTrustRotationReconciler) recoverPanic(ctx context.Context, reqLogger logr.Logger,
+	statusMgr *status.ManageStatus, lTR *trv1alpha1.LinkerdTrustRotation, retErr *error) {
+	p := recover()
+	if p == nil {
+		return
+	}
+
+	err := fmt.Errorf("reconcile panicked: %v", p)
+	reqLogger.Error(err, "Recovered from panic", "stack", string(debug.Stack()))
+
+	msg := fmt.Sprintf("Internal error: %v", p)
+	if statusErr := statusMgr.MarkFailed(ctx, lTR, trv1alpha1.ReasonInternalError, msg); statusErr != nil {
+		reqLogger.Error(statusErr, "Unable to record the panic in status")
+	}
+	r.Recorder.Event(lTR, corev1.EventTypeWarning, string(trv1alpha1.ReasonInternalError), msg)
+
+	*retErr = err
+}
+
+// reconcileRotation runs trust detection and, on overlap, the full rotation workflow.
+func (r *LinkerdTrustRotationReconciler) reconcileRotation(ctx context.Context, reqLogger logr.Logger,
+	statusMgr *status.ManageStatus, lTR *trv1alpha1.LinkerdTrustRotation) (ctrl.Result, error) {
+	var (
+		bundleStatus trv1alpha1.BundleState
+		// bundleHash and secretHash identify the observed trust state; an overlap already
+		// rotated for (same hash as last observed) is not rotated again.
+		bundleHash, secretHash string
+		// anchors holds the secret inspection result; it feeds the managed trust bundle.
+		anchors *secret.Result
+	)
+
+	configMapMgr := config_map.New(r.Client, r.Scheme, reqLogger)
+	secretMgr := secret.New(r.Client, r.Scheme, reqLogger)
+	rolloutMgr := r.rolloutManager(reqLogger, statusMgr)
+
+	if request, ok := lTR.Annotations[trv1alpha1.VerifyAnnotation]; ok {
+		return r.verifyOnly(ctx, reqLogger, statusMgr, lTR, request)
+	}
+
+	// The restored anchor leaves nothing to detect, so an interrupted rollback is finished first.
+	if lTR.Status.RollbackPending {
+		if err := r.finishRollback(ctx, reqLogger, statusMgr, lTR, "rollback resumed after an interruption"); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+	}
+
 	if err := statusMgr.SetPhase(ctx, lTR,
 		status.PhasePtr(trv1alpha1.PhaseIdle),
 		status.ReasonPtr(""),
@@ -99,18 +214,80 @@ func (r *LinkerdTrustRotationReconciler) Reconcile(ctx context.Context, req ctrl
 		return ctrl.Result{}, err
 	}
 
+	if err := statusMgr.SetEffectiveConfig(ctx, lTR, rollout.EffectiveConfig(lTR)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if lTR.Spec.Linkerd.ManageTrustBundle && !lTR.Spec.Trigger.OnTrustAnchorSecretsDiff {
+		return ctrl.Result{}, fmt.Errorf("linkerd.manageTrustBundle requires trigger.onTrustAnchorSecretsDiff to be true")
+	}
+
+	if lTR.Spec.Linkerd.VerifyIssuerChain && !lTR.Spec.Trigger.OnTrustAnchorSecretsDiff {
+		return ctrl.Result{}, fmt.Errorf("linkerd.verifyIssuerChain requires trigger.onTrustAnchorSecretsDiff to be true")
+	}
+
+	if err := rollout.ValidateReadyExpressions(lTR); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	switch {
 	case lTR.Spec.Trigger.OnTrustAnchorSecretsDiff && !lTR.Spec.Trigger.OnTrustRootsConfigMapChange:
 		secretResult, err := secretMgr.EnsureTrustSecrets(ctx, lTR)
+		if errors.Is(err, secret.ErrCurrentSecretMissing) {
+			return r.awaitCurrentSecret(ctx, statusMgr, lTR)
+		}
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		anchors = secretResult
+
+		if err := statusMgr.SetCurrentSecretMissingSince(ctx, lTR, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if err := r.reportBootstrap(ctx, statusMgr, lTR, secretResult); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if err := r.checkAnchorOrder(ctx, statusMgr, lTR, secretResult); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if lTR.Spec.Linkerd.BootstrapOnly && secretResult.Bootstrapped {
+			return r.bootstrapBaseline(ctx, statusMgr, lTR, secretResult)
+		}
+
+		diverged, err := r.divergenceSettled(ctx, statusMgr, lTR, secretResult.Diverged,
+			fingerprintHash(secretResult.CurrentFP, secretResult.PreviousFP))
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 
 		bundleStatus = trv1alpha1.BundleStateSingle
-		if secretResult.Diverged {
+		if diverged {
 			bundleStatus = trv1alpha1.BundleStateOverlap
 		}
 
+		if diverged && lTR.Spec.Trigger.BundlePropagationTimeout != nil && !lTR.Spec.Linkerd.ManageTrustBundle {
+			// Proxies restarted before trust-manager publishes the overlap could not validate the new anchor.
+			configMapResult, err := configMapMgr.LoadAndInspectCMBundle(ctx, lTR)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			if err := recordDuplicateCerts(ctx, statusMgr, lTR, configMapResult); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			if configMapResult.State != trv1alpha1.BundleStateOverlap {
+				bundleStatus = trv1alpha1.BundleStateSingle
+				if err := r.awaitBundleOverlap(ctx, statusMgr, lTR); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+		}
+		secretHash = fingerprintHash(secretResult.CurrentFP, secretResult.PreviousFP)
+
 		if err := statusMgr.SetTrustInfo(ctx, lTR, status.BundlePtr(bundleStatus), secretResult.CurrentFP, secretResult.PreviousFP); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -120,10 +297,27 @@ func (r *LinkerdTrustRotationReconciler) Reconcile(ctx context.Context, req ctrl
 			return ctrl.Result{}, err
 		}
 
+		if err := recordDuplicateCerts(ctx, statusMgr, lTR, configMapResult); err != nil {
+			return ctrl.Result{}, err
+		}
+
 		bundleStatus = trv1alpha1.BundleStateSingle
 		if configMapResult.State == trv1alpha1.BundleStateOverlap {
 			bundleStatus = trv1alpha1.BundleStateOverlap
+
+			// Without the secret trigger nothing else ties the bundle to the issuer proxies will get certificates from.
+			if err := secretMgr.IssuerSignedByBundle(ctx, lTR, linkerdIdentityIssuerSecret,
+				certutil.EncodePEM(configMapResult.Certs)); err != nil {
+				err = fmt.Errorf("trust roots bundle overlaps, but the identity issuer does not chain to any of its anchors: %w", err)
+				r.Recorder.Event(lTR, corev1.EventTypeWarning, string(trv1alpha1.ReasonIssuerAnchorMismatch), err.Error())
+				if err := statusMgr.MarkFailed(ctx, lTR, trv1alpha1.ReasonIssuerAnchorMismatch, err.Error()); err != nil {
+					return ctrl.Result{}, err
+				}
+
+				return ctrl.Result{}, err
+			}
 		}
+		bundleHash = fingerprintHash(configMapResult.Fps...)
 
 		var currentFP, previousFP string
 		switch len(configMapResult.Fps) {
@@ -142,20 +336,67 @@ func (r *LinkerdTrustRotationReconciler) Reconcile(ctx context.Context, req ctrl
 		}
 	case lTR.Spec.Trigger.OnTrustAnchorSecretsDiff && lTR.Spec.Trigger.OnTrustRootsConfigMapChange:
 		secretResult, err := secretMgr.EnsureTrustSecrets(ctx, lTR)
+		if errors.Is(err, secret.ErrCurrentSecretMissing) {
+			return r.awaitCurrentSecret(ctx, statusMgr, lTR)
+		}
 		if err != nil {
 			return ctrl.Result{}, err
 		}
+		anchors = secretResult
 
-		configMapResult, err := configMapMgr.LoadAndInspectCMBundle(ctx, lTR)
+		if err := statusMgr.SetCurrentSecretMissingSince(ctx, lTR, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if err := r.reportBootstrap(ctx, statusMgr, lTR, secretResult); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if err := r.checkAnchorOrder(ctx, statusMgr, lTR, secretResult); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if lTR.Spec.Linkerd.BootstrapOnly && secretResult.Bootstrapped {
+			return r.bootstrapBaseline(ctx, statusMgr, lTR, secretResult)
+		}
+
+		diverged, err := r.divergenceSettled(ctx, statusMgr, lTR, secretResult.Diverged,
+			fingerprintHash(secretResult.CurrentFP, secretResult.PreviousFP))
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 
 		bundleStatus = trv1alpha1.BundleStateSingle
-		if secretResult.Diverged && configMapResult.State == trv1alpha1.BundleStateOverlap {
-			bundleStatus = trv1alpha1.BundleStateOverlap
+		if lTR.Spec.Linkerd.ManageTrustBundle {
+			// The operator writes the overlap itself, so the secrets alone drive detection.
+			if diverged {
+				bundleStatus = trv1alpha1.BundleStateOverlap
+			}
+		} else {
+			configMapResult, err := configMapMgr.LoadAndInspectCMBundle(ctx, lTR)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			if err := recordDuplicateCerts(ctx, statusMgr, lTR, configMapResult); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			if diverged && configMapResult.State == trv1alpha1.BundleStateOverlap {
+				bundleStatus = trv1alpha1.BundleStateOverlap
+			}
+			bundleHash = fingerprintHash(configMapResult.Fps...)
+
+			if diverged && configMapResult.State != trv1alpha1.BundleStateOverlap {
+				// Not idle: the anchor changed and we are waiting for trust-manager to publish the overlap.
+				if err := r.awaitBundleOverlap(ctx, statusMgr, lTR); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
 		}
 
+		secretHash = fingerprintHash(secretResult.CurrentFP, secretResult.PreviousFP)
+
 		if err := statusMgr.SetTrustInfo(ctx, lTR, status.BundlePtr(bundleStatus), secretResult.CurrentFP, secretResult.PreviousFP); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -164,25 +405,49 @@ func (r *LinkerdTrustRotationReconciler) Reconcile(ctx context.Context, req ctrl
 			fmt.Errorf("no rotation trigger enabled: at least one of trigger.onConfigMapChange or trigger.requireSecretsDivergence must be true")
 	}
 
+	if lTR.Status.CleanedUpAt != nil {
+		// The previous anchor is gone, so the bundle no longer overlaps; finish the pending re-trigger.
+		return r.resumeAfterCleanup(ctx, reqLogger, statusMgr, secretMgr, rolloutMgr, lTR, -1, bundleHash, secretHash)
+	}
+
+	if bundleStatus == trv1alpha1.BundleStateOverlap && !observedChange(lTR, bundleHash, secretHash) {
+		reqLogger.Info("Trust state unchanged since the last completed rotation; skipping",
+			"bundleHash", bundleHash, "secretHash", secretHash)
+		if err := statusMgr.RecordSkippedTrigger(ctx, lTR, trv1alpha1.ReasonTrustStateUnchanged,
+			fingerprintHash(bundleHash, secretHash)); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+	}
+
+	if bundleStatus != trv1alpha1.BundleStateOverlap && lTR.Status.DivergedSince == nil {
+		// Settled single state: this is the baseline the next change is compared against.
+		if err := statusMgr.SetObservedHashes(ctx, lTR, bundleHash, secretHash); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	if bundleStatus == trv1alpha1.BundleStateOverlap {
-		if lTR.Spec.DryRun {
-			var workItemDryRun []rollout.WorkItemDryRun
+		// Fail fast with an actionable message instead of a forbidden error mid-selection.
+		if err := rolloutMgr.PreflightRBAC(ctx, lTR); err != nil {
+			return ctrl.Result{}, err
+		}
 
-			plane, err := rolloutMgr.SelectLinkerdDataPlane(ctx, lTR)
+		if lTR.Spec.DryRun {
+			plan, err := rolloutMgr.SelectLinkerdDataPlane(ctx, lTR)
 			if err != nil {
 				return ctrl.Result{}, err
 			}
 
-			for _, item := range plane.Queue {
-				workItemDryRun = append(workItemDryRun, *item.WorkItemDryRun)
-			}
-
-			dryRun, err := yaml.Marshal(workItemDryRun)
+			dryRun, err := dryRunOutput(plan)
 			if err != nil {
 				return ctrl.Result{}, err
 			}
 
-			if err := statusMgr.SetDryRunOutput(ctx, lTR, string(dryRun)); err != nil {
+			estimate := rollout.EstimateDuration(lTR, len(plan.Queue))
+			reqLogger.Info("Dry run completed", "workloads", len(plan.Queue), "estimate", estimate.String())
+
+			if err := statusMgr.SetDryRunOutput(ctx, lTR, "# "+estimate.String()+"\n"+dryRun, plan.Hash, estimate.Total); err != nil {
 				return ctrl.Result{}, err
 			}
 
@@ -202,9 +467,70 @@ func (r *LinkerdTrustRotationReconciler) Reconcile(ctx context.Context, req ctrl
 				return ctrl.Result{}, err
 			}
 
+			if err := r.rollback(ctx, reqLogger, statusMgr, lTR, msg); err != nil {
+				return ctrl.Result{}, err
+			}
+
 			return ctrl.Result{RequeueAfter: time.Minute * 1}, nil
 		}
 
+		owner, fp := isControlPlaneOwner(lTR), rollout.CurrentFP(lTR)
+		if !owner && len(fp) > 0 && lTR.Status.DataPlaneRotatedFP == fp {
+			// Done for this anchor; the shared cleanup is left to the control-plane owner.
+			if err := statusMgr.RecordSkippedTrigger(ctx, lTR, trv1alpha1.ReasonAwaitingControlPlaneOwner, fp); err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := statusMgr.SetPhase(ctx, lTR,
+				status.PhasePtr(trv1alpha1.PhaseHold),
+				status.ReasonPtr(trv1alpha1.ReasonAwaitingControlPlaneOwner),
+				status.StringPtr("Data plane rotated; waiting for the control-plane owner to clean up the previous anchor"),
+			); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+		}
+
+		// A new rotation must not stack on a control-plane rollout that is still in flight.
+		if !status.RotationInProgress(&lTR.Status, fp) {
+			pending, err := rolloutMgr.UnsettledControlPlane(ctx, lTR)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			if len(pending) > 0 {
+				if err := statusMgr.SetPhase(ctx, lTR,
+					status.PhasePtr(trv1alpha1.PhaseHold),
+					status.ReasonPtr(trv1alpha1.ReasonAwaitingControlPlaneRollout),
+					status.StringPtr(fmt.Sprintf("Waiting for prior control-plane rollout to settle: %s",
+						strings.Join(pending, ", "))),
+				); err != nil {
+					return ctrl.Result{}, err
+				}
+
+				return ctrl.Result{RequeueAfter: controlPlaneSettleRequeue}, nil
+			}
+		}
+
+		if err := statusMgr.StartRotation(ctx, lTR, fp); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// Requeue rather than sleep, so the worker stays free and the wait is visible in the status.
+		if d := lTR.Spec.Protection.BeforeRolloutDelay; d != nil && d.Duration > 0 && lTR.Status.StartedAt != nil {
+			if remaining := d.Duration - time.Since(lTR.Status.StartedAt.Time); remaining > 0 {
+				if err := statusMgr.SetPhase(ctx, lTR,
+					status.PhasePtr(trv1alpha1.PhaseHold),
+					status.ReasonPtr(trv1alpha1.ReasonBeforeRolloutDelay),
+					status.StringPtr(fmt.Sprintf("Waiting %s after the rotation started before rolling out", d.Duration)),
+				); err != nil {
+					return ctrl.Result{}, err
+				}
+
+				return ctrl.Result{RequeueAfter: remaining}, nil
+			}
+		}
+
 		if err := statusMgr.SetPhase(ctx, lTR,
 			status.PhasePtr(trv1alpha1.PhaseDetecting),
 			status.ReasonPtr(trv1alpha1.ReasonSecretsDiverged),
@@ -215,61 +541,722 @@ func (r *LinkerdTrustRotationReconciler) Reconcile(ctx context.Context, req ctrl
 			return ctrl.Result{}, err
 		}
 
-		if err := waitWithPurpose(ctx, reqLogger, lTR.Spec.Protection.BeforeRolloutDelay, "before rollout delay"); err != nil {
-			return ctrl.Result{}, err
+		// Nothing is touched before the reviewed plan is confirmed to still be the one to run.
+		if len(lTR.Spec.Rollout.ApprovedPlanHash) > 0 {
+			plan, err := rolloutMgr.SelectLinkerdDataPlane(ctx, lTR)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			approved, err := r.planApproved(ctx, statusMgr, lTR, plan)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !approved {
+				return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+			}
 		}
 
-		if err := secretMgr.DeleteSecrets(ctx, lTR, linkerdIdentityIssuerSecret); err != nil {
-			return ctrl.Result{}, err
+		if owner && lTR.Spec.Linkerd.ManageTrustBundle {
+			if err := configMapMgr.WriteOverlapBundle(ctx, lTR, anchors.CurrentPEM, anchors.PreviousPEM); err != nil {
+				return ctrl.Result{}, err
+			}
 		}
 
-		if err := rolloutMgr.RestartLinkerdControlPlane(ctx, lTR); err != nil {
-			if err := statusMgr.MarkFailed(ctx, lTR, trv1alpha1.ReasonRotationFailed,
-				err.Error()); err != nil {
+		// The issuer secret deletion is destructive, so it runs only once per rotation;
+		// retries resume at the control-plane restart or straight at the data plane.
+		// Only the control-plane owner touches the shared control plane; other CRs wait for it.
+		switch {
+		case lTR.Status.ControlPlaneDone && lTR.Status.ControlPlaneRotatedFP == fp:
+			reqLogger.Info("Control plane already rotated in this rotation, resuming data plane")
+		case !owner:
+			cpOwner, err := r.controlPlaneOwner(ctx, lTR)
+			if err != nil {
 				return ctrl.Result{}, err
 			}
 
-			return ctrl.Result{}, err
+			if cpOwner.Status.ControlPlaneRotatedFP != fp {
+				if err := statusMgr.SetPhase(ctx, lTR,
+					status.PhasePtr(trv1alpha1.PhaseHold),
+					status.ReasonPtr(trv1alpha1.ReasonAwaitingControlPlaneOwner),
+					status.StringPtr(fmt.Sprintf("Waiting for control-plane owner %s/%s to restart the control plane",
+						cpOwner.Namespace, cpOwner.Name)),
+				); err != nil {
+					return ctrl.Result{}, err
+				}
+
+				return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+			}
+		default:
+			managedIssuer := isManagedIssuer(lTR)
+			strategy := issuerStrategy(lTR)
+			if managedIssuer && strategy == trv1alpha1.IssuerStrategyDeleteThenRestart {
+				if err := r.deleteIssuerSecret(ctx, statusMgr, secretMgr, rolloutMgr, lTR); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+
+			if !managedIssuer && !lTR.Spec.Linkerd.VerifyIssuerChain && anchors != nil {
+				// The external issuer is expected to be rotated already; a mismatch is worth a warning, not a failure.
+				if err := secretMgr.WaitIssuerSignedByAnchor(ctx, lTR, linkerdIdentityIssuerSecret, anchors.CurrentPEM,
+					0); err != nil {
+					reqLogger.Info(fmt.Sprintf("External identity issuer not rotated yet: %v", err))
+					r.Recorder.Event(lTR, corev1.EventTypeWarning, string(trv1alpha1.ReasonIssuerAnchorMismatch), err.Error())
+				}
+			}
+
+			// With the other strategies the issuer is only expected to match after the restart.
+			if lTR.Spec.Linkerd.VerifyIssuerChain && strategy == trv1alpha1.IssuerStrategyDeleteThenRestart {
+				if err := secretMgr.WaitIssuerSignedByAnchor(ctx, lTR, linkerdIdentityIssuerSecret, anchors.CurrentPEM,
+					issuerReissueTimeout); err != nil {
+					if err := statusMgr.MarkFailed(ctx, lTR, trv1alpha1.ReasonRotationFailed,
+						err.Error()); err != nil {
+						return ctrl.Result{}, err
+					}
+
+					return ctrl.Result{}, err
+				}
+			}
+
+			if err := rolloutMgr.RestartLinkerdControlPlane(ctx, lTR); err != nil {
+				return r.failRollout(ctx, statusMgr, lTR, err)
+			}
+
+			if managedIssuer && strategy != trv1alpha1.IssuerStrategyDeleteThenRestart {
+				if strategy == trv1alpha1.IssuerStrategyRestartThenDelete {
+					if err := r.deleteIssuerSecret(ctx, statusMgr, secretMgr, rolloutMgr, lTR); err != nil {
+						return ctrl.Result{}, err
+					}
+				}
+
+				if anchors != nil {
+					if err := secretMgr.WaitIssuerSignedByAnchor(ctx, lTR, linkerdIdentityIssuerSecret, anchors.CurrentPEM,
+						issuerReissueTimeout); err != nil {
+						if err := statusMgr.MarkFailed(ctx, lTR, trv1alpha1.ReasonIssuerAnchorMismatch,
+							err.Error()); err != nil {
+							return ctrl.Result{}, err
+						}
+
+						return ctrl.Result{}, err
+					}
+				}
+			}
+
+			if err := statusMgr.SetControlPlaneDone(ctx, lTR, true); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			if err := statusMgr.SetRotatedFP(ctx, lTR, &fp, nil); err != nil {
+				return ctrl.Result{}, err
+			}
 		}
 
-		if err := rolloutMgr.RestartLinkerdDataPlane(ctx, lTR); err != nil {
-			if err := statusMgr.MarkFailed(ctx, lTR, trv1alpha1.ReasonRotationFailed,
-				err.Error()); err != nil {
+		// Like the pre-rollout delay, this requeues against the status timestamp instead of sleeping,
+		// so a restart of the operator does not skip it.
+		if d := lTR.Spec.Protection.BetweenPhasesDelay; d != nil && d.Duration > 0 && lTR.Status.ControlPlaneDoneAt != nil &&
+			(lTR.Status.DataPlaneRotatedFP != fp || len(fp) == 0) {
+			if remaining := d.Duration - time.Since(lTR.Status.ControlPlaneDoneAt.Time); remaining > 0 {
+				if err := statusMgr.SetPhase(ctx, lTR,
+					status.PhasePtr(trv1alpha1.PhaseHold),
+					status.ReasonPtr(trv1alpha1.ReasonHoldTimerRunning),
+					status.StringPtr(fmt.Sprintf("Waiting %s after control plane restart before rolling data plane", d.Duration)),
+				); err != nil {
+					return ctrl.Result{}, err
+				}
+
+				return ctrl.Result{RequeueAfter: remaining}, nil
+			}
+		}
+
+		// matched stays -1 when the data plane was already rolled for this anchor (owner waiting for peers).
+		matched := -1
+		if lTR.Status.DataPlaneRotatedFP != fp || len(fp) == 0 {
+			n, err := r.restartDataPlane(ctx, statusMgr, rolloutMgr, lTR)
+			if errors.Is(err, errAwaitingApproval) {
+				return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+			}
+			if errors.Is(err, rollout.ErrReconcileBudgetExhausted) {
+				return ctrl.Result{RequeueAfter: yieldRequeue}, nil
+			}
+			if errors.Is(err, rollout.ErrOngoingRolloutsDeferred) {
+				return ctrl.Result{RequeueAfter: ongoingRolloutRequeue}, nil
+			}
+			if err != nil {
+				return r.failRollout(ctx, statusMgr, lTR, err)
+			}
+			matched = n
+
+			// The other kinds still run with the old certificates, so the data plane isn't done for this anchor.
+			if kinds := lTR.Spec.Rollout.OnlyKinds; len(kinds) > 0 {
+				if err := statusMgr.SetPhase(ctx, lTR,
+					status.PhasePtr(trv1alpha1.PhaseHold),
+					status.ReasonPtr(trv1alpha1.ReasonAwaitingRemainingKinds),
+					status.StringPtr(fmt.Sprintf("Data plane rolled for %s only; clear rollout.onlyKinds to roll "+
+						"the remaining kinds and clean up", strings.Join(kinds, ", "))),
+				); err != nil {
+					return ctrl.Result{}, err
+				}
+
+				return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+			}
+
+			if err := statusMgr.SetRotatedFP(ctx, lTR, nil, &fp); err != nil {
 				return ctrl.Result{}, err
 			}
+		}
+
+		// A failed mesh-wide check leaves the previous anchor in place, so the mesh stays in the overlap.
+		if rollout.MeshProxyCheckEnabled(lTR) && (lTR.Status.MeshProxyCheckedFP != fp || len(fp) == 0) {
+			if err := rolloutMgr.RunMeshProxyCheck(ctx, lTR); err != nil {
+				return r.failRollout(ctx, statusMgr, lTR, err)
+			}
+
+			if err := statusMgr.SetMeshProxyCheckedFP(ctx, lTR, fp); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 
+		if !owner {
+			return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+		}
+
+		pending, err := r.pendingPeers(ctx, lTR, fp)
+		if err != nil {
 			return ctrl.Result{}, err
 		}
 
-		if err := secretMgr.DeleteSecrets(ctx, lTR, lTR.Spec.Linkerd.PreviousTrustAnchorSecret); err != nil {
+		if len(pending) > 0 {
+			if err := statusMgr.SetPhase(ctx, lTR,
+				status.PhasePtr(trv1alpha1.PhaseHold),
+				status.ReasonPtr(trv1alpha1.ReasonAwaitingPeers),
+				status.StringPtr(fmt.Sprintf("Waiting for data planes of %s before cleanup", strings.Join(pending, ", "))),
+			); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+		}
+
+		if err := secretMgr.CleanupPrevious(ctx, lTR); err != nil {
 			return ctrl.Result{}, err
 		}
 
-		if lTR.Spec.Protection.RetriggerRolloutAfterCleanup {
-			if err := waitWithPurpose(ctx, reqLogger, lTR.Spec.Protection.HoldAfterCleanup, "hold after cleanup"); err != nil {
+		if lTR.Spec.Linkerd.ManageTrustBundle {
+			if err := configMapMgr.PruneBundle(ctx, lTR, anchors.CurrentPEM); err != nil {
 				return ctrl.Result{}, err
 			}
+		}
 
-			if _, err := secretMgr.EnsureTrustSecrets(ctx, lTR); err != nil {
+		if lTR.Spec.Protection.RetriggerRolloutAfterCleanup {
+			now := metav1.NewTime(time.Now().UTC())
+			if err := statusMgr.SetCleanedUpAt(ctx, lTR, &now); err != nil {
 				return ctrl.Result{}, err
 			}
 
-			if err := rolloutMgr.RestartLinkerdDataPlane(ctx, lTR); err != nil {
-				if err := statusMgr.MarkFailed(ctx, lTR, trv1alpha1.ReasonRotationFailed,
-					err.Error()); err != nil {
-					return ctrl.Result{}, err
-				}
+			return r.resumeAfterCleanup(ctx, reqLogger, statusMgr, secretMgr, rolloutMgr, lTR, matched, bundleHash, secretHash)
+		}
+
+		if err := r.completeRotation(ctx, reqLogger, statusMgr, rolloutMgr, lTR, matched, bundleHash, secretHash); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+}
 
+// resumeAfterCleanup re-triggers the data-plane rollout (protection.retriggerRolloutAfterCleanup) once
+// protection.holdAfterCleanup has passed since status.cleanedUpAt, requeueing while the hold runs.
+// matched is the number of workloads of the first data-plane rollout, or -1 if unknown.
+func (r *LinkerdTrustRotationReconciler) resumeAfterCleanup(ctx context.Context, reqLogger logr.Logger,
+	statusMgr *status.ManageStatus, secretMgr *secret.ManageSecret, rolloutMgr *rollout.ManageRollout,
+	lTR *trv1alpha1.LinkerdTrustRotation, matched int, bundleHash, secretHash string) (ctrl.Result, error) {
+	if d := lTR.Spec.Protection.HoldAfterCleanup; d != nil && d.Duration > 0 {
+		if remaining := d.Duration - time.Since(lTR.Status.CleanedUpAt.Time); remaining > 0 {
+			if err := statusMgr.SetPhase(ctx, lTR,
+				status.PhasePtr(trv1alpha1.PhaseHold),
+				status.ReasonPtr(trv1alpha1.ReasonHoldTimerRunning),
+				status.StringPtr(fmt.Sprintf("Waiting %s after cleanup before re-triggering the data-plane rollout", d.Duration)),
+			); err != nil {
 				return ctrl.Result{}, err
 			}
+
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
+	if _, err := secretMgr.EnsureTrustSecrets(ctx, lTR); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if _, err := r.restartDataPlane(ctx, statusMgr, rolloutMgr, lTR); errors.Is(err, errAwaitingApproval) {
+		return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+	} else if errors.Is(err, rollout.ErrReconcileBudgetExhausted) {
+		return ctrl.Result{RequeueAfter: yieldRequeue}, nil
+	} else if errors.Is(err, rollout.ErrOngoingRolloutsDeferred) {
+		return ctrl.Result{RequeueAfter: ongoingRolloutRequeue}, nil
+	} else if err != nil {
+		return r.failRollout(ctx, statusMgr, lTR, err)
+	}
+
+	if err := statusMgr.SetCleanedUpAt(ctx, lTR, nil); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.completeRotation(ctx, reqLogger, statusMgr, rolloutMgr, lTR, matched, bundleHash, secretHash); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+}
+
+// completeRotation marks the rotation succeeded, records the observed hashes and runs the post-rotation trigger.
+// matched is the number of data-plane workloads rolled (-1 if unknown); 0 is called out in the message.
+func (r *LinkerdTrustRotationReconciler) completeRotation(ctx context.Context, reqLogger logr.Logger,
+	statusMgr *status.ManageStatus, rolloutMgr *rollout.ManageRollout, lTR *trv1alpha1.LinkerdTrustRotation,
+	matched int, bundleHash, secretHash string) error {
+	msg := "Linkerd trust anchor certificate rotation completed successfully"
+	if matched == 0 {
+		msg = fmt.Sprintf("%s (%s)", msg, rollout.NoWorkloadsMatchedMessage)
+	}
+
+	if err := statusMgr.MarkSucceeded(ctx, lTR, msg); err != nil {
+		return err
+	}
+	rollout.ForgetRemaining(client.ObjectKeyFromObject(lTR))
+
+	if err := statusMgr.SetObservedHashes(ctx, lTR, bundleHash, secretHash); err != nil {
+		return err
+	}
+
+	// The rotation already succeeded; a failing downstream trigger is only reported.
+	if err := rolloutMgr.RunPostRotationTrigger(ctx, lTR); err != nil {
+		reqLogger.Error(err, "Post-rotation trigger failed")
+		r.Recorder.Event(lTR, corev1.EventTypeWarning, "PostRotationTriggerFailed", err.Error())
+	}
+
+	return nil
+}
+
+// divergenceSettled tracks when the secret divergence was first observed and reports whether it
+// has persisted for trigger.divergenceGracePeriod (always true without a grace period). secretHash
+// identifies the divergence, so waiting for the same one is counted as a single skipped trigger.
+func (r *LinkerdTrustRotationReconciler) divergenceSettled(ctx context.Context, statusMgr *status.ManageStatus,
+	lTR *trv1alpha1.LinkerdTrustRotation, diverged bool, secretHash string) (bool, error) {
+	if !diverged {
+		return false, statusMgr.SetDivergedSince(ctx, lTR, nil)
+	}
+
+	since := lTR.Status.DivergedSince
+	if since == nil {
+		now := metav1.NewTime(time.Now().UTC())
+		if err := statusMgr.SetDivergedSince(ctx, lTR, &now); err != nil {
+			return false, err
+		}
+		since = &now
+	}
+
+	grace := lTR.Spec.Trigger.DivergenceGracePeriod
+	if grace == nil || grace.Duration <= 0 {
+		return true, nil
+	}
+
+	if remaining := grace.Duration - time.Since(since.Time); remaining > 0 {
+		if err := statusMgr.RecordSkippedTrigger(ctx, lTR, trv1alpha1.ReasonDivergenceGracePeriod, secretHash); err != nil {
+			return false, err
+		}
+
+		return false, statusMgr.SetPhase(ctx, lTR,
+			status.PhasePtr(trv1alpha1.PhaseDetecting),
+			status.ReasonPtr(trv1alpha1.ReasonDivergenceGracePeriod),
+			status.StringPtr(fmt.Sprintf("Trust anchor secrets differ; waiting %s more before treating it as a rotation",
+				remaining.Round(time.Second))),
+		)
+	}
+
+	return true, nil
+}
+
+// recordDuplicateCerts counts a bundle that only looked like an overlap because it repeats a certificate
+// as a skipped trigger.
+func recordDuplicateCerts(ctx context.Context, statusMgr *status.ManageStatus, lTR *trv1alpha1.LinkerdTrustRotation,
+	bundle *config_map.Result) error {
+	if bundle.Duplicates == 0 || bundle.State == trv1alpha1.BundleStateOverlap {
+		return nil
+	}
+
+	return statusMgr.RecordSkippedTrigger(ctx, lTR, trv1alpha1.ReasonDuplicateCertificates, fingerprintHash(bundle.Fps...))
+}
+
+// awaitBundleOverlap reports that the secrets differ but the trust-roots bundle does not overlap yet.
+// Past trigger.bundlePropagationTimeout (counted from status.divergedSince) it reports
+// BundlePropagationTimeout and emits a Warning event, as trust-manager is likely not running.
+func (r *LinkerdTrustRotationReconciler) awaitBundleOverlap(ctx context.Context, statusMgr *status.ManageStatus,
+	lTR *trv1alpha1.LinkerdTrustRotation) error {
+	cm := lTR.Spec.Linkerd.TrustRootsConfigMap
+	reason := trv1alpha1.ReasonAwaitingBundleOverlap
+	msg := fmt.Sprintf("Trust anchor secrets differ; waiting for ConfigMap %s to contain both anchors", cm)
+
+	timeout, since := lTR.Spec.Trigger.BundlePropagationTimeout, lTR.Status.DivergedSince
+	if timeout != nil && timeout.Duration > 0 && since != nil && time.Since(since.Time) > timeout.Duration {
+		reason = trv1alpha1.ReasonBundlePropagationTimeout
+		msg = fmt.Sprintf("Trust bundle in ConfigMap %s did not overlap within %s of the secrets diverging; is trust-manager running?",
+			cm, timeout.Duration)
+		r.Recorder.Event(lTR, corev1.EventTypeWarning, string(reason), msg)
+	}
+
+	return statusMgr.SetPhase(ctx, lTR,
+		status.PhasePtr(trv1alpha1.PhaseDetecting),
+		status.ReasonPtr(reason),
+		status.StringPtr(msg),
+	)
+}
+
+// awaitCurrentSecret reports that the current trust anchor secret does not exist yet (e.g. on a fresh install)
+// and requeues. Past linkerd.currentSecretTimeout (counted from status.currentSecretMissingSince) it fails.
+func (r *LinkerdTrustRotationReconciler) awaitCurrentSecret(ctx context.Context, statusMgr *status.ManageStatus,
+	lTR *trv1alpha1.LinkerdTrustRotation) (ctrl.Result, error) {
+	name := fmt.Sprintf("%s/%s", lTR.Spec.Linkerd.Namespace, lTR.Spec.Linkerd.TrustAnchorSecret)
+
+	since := lTR.Status.CurrentSecretMissingSince
+	if since == nil {
+		now := metav1.NewTime(time.Now().UTC())
+		if err := statusMgr.SetCurrentSecretMissingSince(ctx, lTR, &now); err != nil {
+			return ctrl.Result{}, err
 		}
+		since = &now
+	}
 
-		if err := statusMgr.MarkSucceeded(ctx, lTR, "Linkerd trust anchor certificate rotation completed successfully"); err != nil {
+	if timeout := secret.CurrentSecretTimeout(lTR); time.Since(since.Time) > timeout {
+		msg := fmt.Sprintf("current trust anchor secret %s did not appear within %s", name, timeout)
+		if err := statusMgr.MarkFailed(ctx, lTR, trv1alpha1.ReasonCurrentSecretMissing, msg); err != nil {
 			return ctrl.Result{}, err
 		}
+
+		return ctrl.Result{}, errors.New(msg)
+	}
+
+	if err := statusMgr.SetPhase(ctx, lTR,
+		status.PhasePtr(trv1alpha1.PhaseDetecting),
+		status.ReasonPtr(trv1alpha1.ReasonAwaitingCurrentSecret),
+		status.StringPtr(fmt.Sprintf("waiting for current trust anchor secret %s to exist", name)),
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+}
+
+// checkAnchorOrder refuses diverged trust anchor secrets whose current anchor is older than the previous one
+// (linkerd.verifyAnchorOrder, default: true), marking the CR failed and emitting a warning event.
+func (r *LinkerdTrustRotationReconciler) checkAnchorOrder(ctx context.Context, statusMgr *status.ManageStatus,
+	lTR *trv1alpha1.LinkerdTrustRotation, secretResult *secret.Result) error {
+	if !secretResult.Diverged || (lTR.Spec.Linkerd.VerifyAnchorOrder != nil && !*lTR.Spec.Linkerd.VerifyAnchorOrder) {
+		return nil
+	}
+
+	err := secret.CheckAnchorOrder(secretResult.CurrentPEM, secretResult.PreviousPEM)
+	if err == nil {
+		return nil
+	}
+
+	msg := fmt.Sprintf("Refusing to rotate: %v; check that the trust anchor secrets are not swapped", err)
+	r.Recorder.Event(lTR, corev1.EventTypeWarning, string(trv1alpha1.ReasonCurrentAnchorOlder), msg)
+	if err := statusMgr.MarkFailed(ctx, lTR, trv1alpha1.ReasonCurrentAnchorOlder, msg); err != nil {
+		return err
+	}
+
+	return errors.New(msg)
+}
+
+// reportBootstrap surfaces a previous-secret bootstrap done by this reconcile in status and as an event.
+func (r *LinkerdTrustRotationReconciler) reportBootstrap(ctx context.Context, statusMgr *status.ManageStatus,
+	lTR *trv1alpha1.LinkerdTrustRotation, secretResult *secret.Result) error {
+	if !secretResult.Bootstrapped {
+		return nil
+	}
+
+	msg := fmt.Sprintf("Bootstrapped previous trust anchor secret %s from %s",
+		lTR.Spec.Linkerd.PreviousTrustAnchorSecret, lTR.Spec.Linkerd.TrustAnchorSecret)
+	if lTR.Spec.Linkerd.SingleSecretMode != nil {
+		msg = fmt.Sprintf("Bootstrapped the previous trust anchor key of secret %s from the current one",
+			lTR.Spec.Linkerd.TrustAnchorSecret)
+	}
+	r.Recorder.Event(lTR, corev1.EventTypeNormal, string(trv1alpha1.ReasonPreviousCreated), msg)
+
+	return statusMgr.SetPhase(ctx, lTR,
+		status.PhasePtr(trv1alpha1.PhaseBootstrap),
+		status.ReasonPtr(trv1alpha1.ReasonPreviousCreated),
+		status.StringPtr(msg),
+	)
+}
+
+// bootstrapBaseline ends a reconcile that bootstrapped the previous secret (linkerd.bootstrapOnly):
+// it records the single bundle state and requeues, leaving rotation to a later anchor change.
+func (r *LinkerdTrustRotationReconciler) bootstrapBaseline(ctx context.Context, statusMgr *status.ManageStatus,
+	lTR *trv1alpha1.LinkerdTrustRotation, secretResult *secret.Result) (ctrl.Result, error) {
+	if err := statusMgr.SetTrustInfo(ctx, lTR, status.BundlePtr(trv1alpha1.BundleStateSingle),
+		secretResult.CurrentFP, secretResult.PreviousFP); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: jitter(frequency, r.RequeueJitter)}, nil
+}
+
+// failRollout marks the rotation failed with a reason matching the rollout error kind and picks
+// the retry strategy: misconfigurations wait for a spec change, degraded, deleted or unschedulable workloads
+// are retried after degradedRequeue, and everything else goes through the usual error backoff.
+func (r *LinkerdTrustRotationReconciler) failRollout(ctx context.Context, statusMgr *status.ManageStatus,
+	lTR *trv1alpha1.LinkerdTrustRotation, err error) (ctrl.Result, error) {
+	kind := rollout.KindOf(err)
+
+	if kind == rollout.ErrorKindThrottled {
+		// The API server asked us to slow down; the rotation itself hasn't failed.
+		r.Recorder.Event(lTR, corev1.EventTypeWarning, string(trv1alpha1.ReasonAPIThrottled), err.Error())
+		return ctrl.Result{RequeueAfter: jitter(rollout.RetryAfter(err)+throttledRequeue, r.RequeueJitter)}, nil
+	}
+
+	reason := trv1alpha1.ReasonRotationFailed
+	switch kind {
+	case rollout.ErrorKindTimeout:
+		reason = trv1alpha1.ReasonRolloutTimeout
+	case rollout.ErrorKindMisconfigured:
+		reason = trv1alpha1.ReasonMisconfigured
+	case rollout.ErrorKindCheckFailed:
+		reason = trv1alpha1.ReasonVerificationFailed
+	case rollout.ErrorKindDegraded:
+		reason = trv1alpha1.ReasonWorkloadDegraded
+	case rollout.ErrorKindDisappeared:
+		reason = trv1alpha1.ReasonWorkloadDisappeared
+	case rollout.ErrorKindUnschedulable:
+		reason = trv1alpha1.ReasonInsufficientResources
+	}
+
+	if err := statusMgr.MarkFailed(ctx, lTR, reason, err.Error()); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	switch kind {
+	case rollout.ErrorKindMisconfigured:
+		// Retrying can't help, but fixing the spec can: a spec change triggers a new reconcile that resumes
+		// the rotation, so it is not rolled back.
+		return ctrl.Result{}, reconcile.TerminalError(err)
+	case rollout.ErrorKindDegraded, rollout.ErrorKindDisappeared, rollout.ErrorKindUnschedulable:
+		// Waiting for the workload to recover, the plan to drop it or capacity to be added beats a fast backoff.
+		r.Recorder.Event(lTR, corev1.EventTypeWarning, string(reason), err.Error())
+		return ctrl.Result{RequeueAfter: jitter(degradedRequeue, r.RequeueJitter)}, nil
+	default:
+		return ctrl.Result{}, err
+	}
+}
+
+// rollback returns the mesh to the previous trust anchor after a rotation failed for good
+// (protection.rollbackOnFailure): the previous anchor is restored as current (the new one is saved to
+// secret.RolledBackSecretName), the bundle keeps both anchors, and the issuer is re-issued and the
+// control plane restarted from the restored anchor.
+// Data-plane restarts are not undone. Only the control-plane owner rolls back.
+// Once the anchor is restored, status.rollbackPending makes the next reconcile finish an interrupted
+// rollback (see finishRollback), since the secrets no longer differ afterwards.
+func (r *LinkerdTrustRotationReconciler) rollback(ctx context.Context, reqLogger logr.Logger, statusMgr *status.ManageStatus,
+	lTR *trv1alpha1.LinkerdTrustRotation, cause string) error {
+	if !lTR.Spec.Protection.RollbackOnFailure || !isControlPlaneOwner(lTR) {
+		return nil
+	}
+
+	if !lTR.Status.RollbackPending {
+		secretMgr := secret.New(r.Client, r.Scheme, reqLogger)
+		configMapMgr := config_map.New(r.Client, r.Scheme, reqLogger)
+
+		anchors, err := secretMgr.EnsureTrustSecrets(ctx, lTR)
+		if err != nil {
+			return r.rollbackFailed(lTR, err)
+		}
+
+		if lTR.Spec.Linkerd.ManageTrustBundle {
+			// Proxies restarted during the rotation must keep trusting both anchors.
+			if err := configMapMgr.WriteOverlapBundle(ctx, lTR, anchors.PreviousPEM, anchors.CurrentPEM); err != nil {
+				return r.rollbackFailed(lTR, err)
+			}
+		}
+
+		if err := secretMgr.RestorePrevious(ctx, lTR); err != nil {
+			return r.rollbackFailed(lTR, err)
+		}
+
+		if err := statusMgr.SetRollbackPending(ctx, lTR); err != nil {
+			return r.rollbackFailed(lTR, err)
+		}
+	}
+
+	return r.finishRollback(ctx, reqLogger, statusMgr, lTR, cause)
+}
+
+// finishRollback re-issues the identity issuer and restarts the control plane from the restored anchor,
+// then marks the rotation rolled back. Every step is safe to repeat after an interruption.
+func (r *LinkerdTrustRotationReconciler) finishRollback(ctx context.Context, reqLogger logr.Logger,
+	statusMgr *status.ManageStatus, lTR *trv1alpha1.LinkerdTrustRotation, cause string) error {
+	secretMgr := secret.New(r.Client, r.Scheme, reqLogger)
+	rolloutMgr := r.rolloutManager(reqLogger, statusMgr)
+
+	strategy := issuerStrategy(lTR)
+	if isManagedIssuer(lTR) && strategy == trv1alpha1.IssuerStrategyDeleteThenRestart {
+		if err := secretMgr.DeleteSecrets(ctx, lTR, linkerdIdentityIssuerSecret); err != nil {
+			return r.rollbackFailed(lTR, err)
+		}
+	}
+
+	if err := rolloutMgr.RestartLinkerdControlPlane(ctx, lTR); err != nil {
+		return r.rollbackFailed(lTR, err)
+	}
+
+	if isManagedIssuer(lTR) && strategy == trv1alpha1.IssuerStrategyRestartThenDelete {
+		if err := secretMgr.DeleteSecrets(ctx, lTR, linkerdIdentityIssuerSecret); err != nil {
+			return r.rollbackFailed(lTR, err)
+		}
+	}
+
+	anchors, err := secretMgr.EnsureTrustSecrets(ctx, lTR)
+	if err != nil {
+		return r.rollbackFailed(lTR, err)
+	}
+
+	msg := fmt.Sprintf("Rotation failed (%s); rolled back to the previous trust anchor %s, the new anchor is kept in secret %s/%s",
+		cause, anchors.CurrentFP, lTR.Spec.Linkerd.Namespace, secret.RolledBackSecretName(lTR))
+	r.Recorder.Event(lTR, corev1.EventTypeWarning, string(trv1alpha1.ReasonRolledBack), msg)
+	if err := statusMgr.MarkRolledBack(ctx, lTR, msg); err != nil {
+		return err
+	}
+	rollout.ForgetRemaining(client.ObjectKeyFromObject(lTR))
+
+	return nil
+}
+
+// rollbackFailed reports a rollback that could not be completed; the mesh may be left between anchors.
+func (r *LinkerdTrustRotationReconciler) rollbackFailed(lTR *trv1alpha1.LinkerdTrustRotation, err error) error {
+	r.Recorder.Event(lTR, corev1.EventTypeWarning, "RollbackFailed", err.Error())
+	return fmt.Errorf("rollback: %w", err)
+}
+
+// deleteIssuerSecret deletes the identity issuer secret so cert-manager re-issues it from the current anchor.
+// It runs only once per rotation: retries skip it once status.issuerSecretDeleted is set.
+// Right before the deletion it marks the point of no return with a Warning event and the
+// EnteringDestructivePhase reason, naming the control-plane Deployments about to be restarted.
+func (r *LinkerdTrustRotationReconciler) deleteIssuerSecret(ctx context.Context, statusMgr *status.ManageStatus,
+	secretMgr *secret.ManageSecret, rolloutMgr *rollout.ManageRollout, lTR *trv1alpha1.LinkerdTrustRotation) error {
+	if lTR.Status.IssuerSecretDeleted {
+		return nil
+	}
+
+	deployments, err := rolloutMgr.SelectLinkerdControlPlane(ctx, lTR)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(deployments.Items))
+	for _, dp := range deployments.Items {
+		names = append(names, dp.Name)
+	}
+	sort.Strings(names)
+
+	msg := fmt.Sprintf("Deleting identity issuer secret %s/%s; the control plane (%s) and then the data plane "+
+		"will be restarted on the new trust anchor", lTR.Spec.Linkerd.Namespace, linkerdIdentityIssuerSecret,
+		strings.Join(names, ", "))
+	r.Recorder.Event(lTR, corev1.EventTypeWarning, string(trv1alpha1.ReasonEnteringDestructivePhase), msg)
+	if err := statusMgr.SetPhase(ctx, lTR,
+		status.PhasePtr(trv1alpha1.PhaseRollingControlPlane),
+		status.ReasonPtr(trv1alpha1.ReasonEnteringDestructivePhase),
+		status.StringPtr(msg),
+	); err != nil {
+		return err
+	}
+
+	if err := secretMgr.DeleteSecrets(ctx, lTR, linkerdIdentityIssuerSecret); err != nil {
+		return err
+	}
+
+	return statusMgr.SetIssuerSecretDeleted(ctx, lTR, true)
+}
+
+// restartDataPlane builds the data-plane plan, records it (protection.persistPlanToConfigMap), warns when it
+// is empty, and executes it.
+// It returns the number of workloads in the plan, or errAwaitingApproval if the plan changed since approval
+// or the canary sample awaits approval. rollout.ErrReconcileBudgetExhausted and rollout.ErrOngoingRolloutsDeferred
+// are passed through so the caller requeues without failing the rotation.
+func (r *LinkerdTrustRotationReconciler) restartDataPlane(ctx context.Context, statusMgr *status.ManageStatus,
+	rolloutMgr *rollout.ManageRollout, lTR *trv1alpha1.LinkerdTrustRotation) (int, error) {
+	plan, err := rolloutMgr.SelectLinkerdDataPlane(ctx, lTR)
+	if err != nil {
+		return 0, err
+	}
+
+	approved, err := r.planApproved(ctx, statusMgr, lTR, plan)
+	if err != nil {
+		return 0, err
+	}
+	if !approved {
+		return 0, errAwaitingApproval
+	}
+
+	if lTR.Spec.Protection.PersistPlanToConfigMap {
+		out, err := dryRunOutput(plan)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := config_map.New(r.Client, r.Scheme, logf.FromContext(ctx)).PersistPlan(ctx, lTR, out, plan.Hash); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(plan.Queue) == 0 {
+		r.Recorder.Event(lTR, corev1.EventTypeWarning, string(trv1alpha1.ReasonNoWorkloadsMatched),
+			fmt.Sprintf("%s during trust anchor overlap; check rollout.targetAnnotationSelector", rollout.NoWorkloadsMatchedMessage))
+	}
+
+	if err := rolloutMgr.ExecuteDataPlanePlan(ctx, lTR, plan); errors.Is(err, rollout.ErrCanaryAwaitingApproval) {
+		return len(plan.Queue), errAwaitingApproval
+	} else if err != nil {
+		return len(plan.Queue), err
 	}
 
-	return ctrl.Result{RequeueAfter: frequency}, nil
+	return len(plan.Queue), nil
+}
+
+// planApproved reports whether the plan matches rollout.approvedPlanHash (always true without one).
+// Otherwise it pauses the rotation in AwaitingApproval and publishes the new plan for review.
+func (r *LinkerdTrustRotationReconciler) planApproved(ctx context.Context, statusMgr *status.ManageStatus,
+	lTR *trv1alpha1.LinkerdTrustRotation, plan *rollout.Plan) (bool, error) {
+	approved := lTR.Spec.Rollout.ApprovedPlanHash
+	if len(approved) == 0 || plan.Hash == approved {
+		return true, nil
+	}
+
+	out, err := dryRunOutput(plan)
+	if err != nil {
+		return false, err
+	}
+
+	msg := fmt.Sprintf("Data-plane plan changed since approval (approved %s, now %s); review status.dryRunPlan "+
+		"and set rollout.approvedPlanHash to %s to proceed", approved, plan.Hash, plan.Hash)
+	return false, statusMgr.AwaitPlanApproval(ctx, lTR, out, plan.Hash, msg)
+}
+
+// dryRunOutput renders the plan queue as the YAML shown in status.dryRunPlan.
+func dryRunOutput(plan *rollout.Plan) (string, error) {
+	var workItemDryRun []rollout.WorkItemDryRun
+	for _, item := range plan.Queue {
+		workItemDryRun = append(workItemDryRun, *item.WorkItemDryRun)
+	}
+
+	out, err := yaml.Marshal(workItemDryRun)
+	if err != nil {
+		return "", err
+	}
+
+	if n := plan.Stats.SkippedByKind; n > 0 {
+		return fmt.Sprintf("# %d workloads skipped by rollout.onlyKinds\n%s", n, out), nil
+	}
+
+	return string(out), nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -277,29 +1264,59 @@ func (r *LinkerdTrustRotationReconciler) SetupWithManager(mgr ctrl.Manager) erro
 	r.Recorder = mgr.GetEventRecorderFor("linkerdtrustrotation")
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&trv1alpha1.LinkerdTrustRotation{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithEventFilter(predicate.And(
+			rotationClassMatches(r.RotationClass),
+			predicate.Or(predicate.GenerationChangedPredicate{}, controlAnnotationsChanged()),
+		)).
 		Named("linkerdtrustrotation").
 		Complete(r)
 }
 
-// waitWithPurpose waits for the given duration (if > 0) while respecting context cancellation.
-// `purpose` is a short label used in logs, e.g. "pre-rollout delay" or "hold-before-cleanup".
-func waitWithPurpose(ctx context.Context, logger logr.Logger, d *metav1.Duration, purpose string) error {
-	if d == nil || d.Duration <= 0 {
-		return nil
+// jitter returns d randomly spread within ±factor of d. Non-positive factors return d unchanged;
+// factors above 1 are capped so the result never drops to zero.
+func jitter(d time.Duration, factor float64) time.Duration {
+	if factor <= 0 {
+		return d
 	}
 
-	logger.Info(fmt.Sprintf("Waiting, purpose %s, duration %s", purpose, d.Duration.String()))
+	if factor > 1 {
+		factor = 1
+	}
 
-	timer := time.NewTimer(d.Duration)
-	defer timer.Stop()
+	spread := (rand.Float64()*2 - 1) * factor * float64(d)
+	if out := d + time.Duration(spread); out > 0 {
+		return out
+	}
 
-	select {
-	case <-ctx.Done():
-		logger.Info(fmt.Sprintf("Wait cancelled by context, purpose %s, err: %v", purpose, ctx.Err()))
-		return ctx.Err()
-	case <-timer.C:
-		logger.Info(fmt.Sprintf("Wait finished, purpose %s", purpose))
-		return nil
+	return d
+}
+
+// fingerprintHash returns a short, stable hash of a set of trust anchor fingerprints.
+func fingerprintHash(fps ...string) string {
+	sorted := append([]string(nil), fps...)
+	sort.Strings(sorted)
+
+	sum := sha256.Sum256([]byte(strings.Join(sorted, ",")))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// observedChange reports whether the observed trust state differs from the one recorded after the last
+// settled state or completed rotation. Empty hashes (not observed by this trigger) never count as a change.
+func observedChange(lTR *trv1alpha1.LinkerdTrustRotation, bundleHash, secretHash string) bool {
+	if bundleHash == "" && secretHash == "" {
+		return true
 	}
+
+	return (bundleHash != "" && bundleHash != lTR.Status.LastObservedBundleHash) ||
+		(secretHash != "" && secretHash != lTR.Status.LastObservedSecretHash)
+}
+
+// rolloutManager returns a rollout manager configured from the reconciler's settings.
+func (r *LinkerdTrustRotationReconciler) rolloutManager(logger logr.Logger, statusMgr *status.ManageStatus) *rollout.ManageRollout {
+	m := rollout.New(r.Client, r.Scheme, logger, statusMgr)
+	m.AllowedCRKinds = r.AllowedCRKinds
+	m.PollInterval = r.RolloutPollInterval
+	m.ConfirmWindow = r.RolloutConfirmWindow
+
+	return m
 }
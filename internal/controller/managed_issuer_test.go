@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// TestManagedIssuer checks that the identity issuer secret is deleted during a rotation only when cert-manager
+// re-issues it; an externally managed issuer is kept, the control plane is still restarted, and an issuer
+// not signed by the new anchor is reported with a Warning event.
+func TestManagedIssuer(t *testing.T) {
+	destructive := string(trv1alpha1.PhaseRollingControlPlane) + "/" + string(trv1alpha1.ReasonEnteringDestructivePhase)
+	for _, tc := range []struct {
+		name        string
+		managed     bool
+		wantDeleted bool
+	}{
+		{name: "managed", managed: true, wantDeleted: true},
+		{name: "external", managed: false, wantDeleted: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+			sim := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+				ltr.Spec.Linkerd.ManagedIssuer = &tc.managed
+			})
+			sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+
+			sim.rotateAnchor(newAnchor)
+			sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 5)
+
+			issuer := &corev1.Secret{}
+			err := sim.client.Get(sim.ctx, types.NamespacedName{Namespace: simLinkerdNs,
+				Name: linkerdIdentityIssuerSecret}, issuer)
+			if tc.wantDeleted {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("want the managed issuer secret deleted, got err %v", err)
+				}
+				sim.expectTransitions(destructive)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("external issuer secret: %v", err)
+			}
+			if !bytes.Equal(issuer.Data["tls.crt"], []byte("issuer")) {
+				t.Error("external issuer secret modified")
+			}
+			if slices.Contains(sim.transitions, destructive) {
+				t.Errorf("destructive phase entered for an external issuer (transitions: %v)", sim.transitions)
+			}
+			if sim.restartedAt(simLinkerdNs, "linkerd-identity") == "" {
+				t.Error("control plane not restarted")
+			}
+
+			var mismatch bool
+			events := sim.r.Recorder.(*record.FakeRecorder).Events
+			for len(events) > 0 {
+				if e := <-events; strings.Contains(e, string(trv1alpha1.ReasonIssuerAnchorMismatch)) {
+					mismatch = true
+				}
+			}
+			if !mismatch {
+				t.Error("want an IssuerAnchorMismatch event for an issuer not signed by the new anchor")
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// setProgressing sets the Progressing condition reason of a Deployment; anything but NewReplicaSetAvailable
+// makes it mid-rollout.
+func (s *rotationSim) setProgressing(ns, name, reason string) {
+	s.t.Helper()
+
+	dep := &appsv1.Deployment{}
+	if err := s.client.Get(s.ctx, types.NamespacedName{Namespace: ns, Name: name}, dep); err != nil {
+		s.t.Fatal(err)
+	}
+	dep.Status.Conditions = []appsv1.DeploymentCondition{
+		{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: reason},
+	}
+	if err := s.client.Status().Update(s.ctx, dep); err != nil {
+		s.t.Fatal(err)
+	}
+}
+
+// TestOngoingRolloutSkipped checks that rollout.ongoingRolloutPolicy=Skip defers a Deployment that is
+// mid-rollout, restarts the rest of the queue and picks the deferred one up once its rollout settled.
+func TestOngoingRolloutSkipped(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Rollout.OngoingRolloutPolicy = trv1alpha1.OngoingRolloutPolicySkip
+	})
+	if err := sim.client.Create(sim.ctx, simDeployment(simAppNs, "api", nil,
+		map[string]string{"linkerd.io/inject": "enabled"})); err != nil {
+		t.Fatal(err)
+	}
+
+	sim.setProgressing(simAppNs, "api", "ReplicaSetUpdated")
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+	sim.rotateAnchor(newAnchor)
+
+	deferred := false
+	for range 10 {
+		res, err := sim.r.Reconcile(sim.ctx, sim.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := sim.get()
+		if got.Status.Reason != nil && *got.Status.Reason == trv1alpha1.ReasonOngoingRolloutsDeferred {
+			if res.RequeueAfter != ongoingRolloutRequeue {
+				t.Errorf("requeue after deferral = %s, want %s", res.RequeueAfter, ongoingRolloutRequeue)
+			}
+			if sim.restartedAt(simAppNs, "api") != "" || sim.restartedAt(simAppNs, "web") == "" {
+				t.Error("want only the settled Deployment web restarted")
+			}
+			// api is first in the queue, so the cursor index must not cover it
+			if cur := got.Status.Cursor; cur == nil || cur.Next != 0 || len(cur.Completed) != 1 {
+				t.Errorf("cursor = %+v, want next 0 with web completed", cur)
+			}
+			deferred = true
+			break
+		}
+	}
+	if !deferred {
+		t.Fatalf("mid-rollout Deployment was never deferred (transitions: %v)", sim.transitions)
+	}
+
+	sim.setProgressing(simAppNs, "api", "NewReplicaSetAvailable")
+	got := sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 5)
+	if sim.restartedAt(simAppNs, "api") == "" {
+		t.Error("deferred Deployment was not restarted after its rollout settled")
+	}
+	if got.Status.OngoingRolloutsDeferredSince != nil {
+		t.Error("status.ongoingRolloutsDeferredSince not cleared after a pass without deferrals")
+	}
+	if !slices.Contains(sim.transitions, string(trv1alpha1.PhaseRollingDataPlane)+"/"+
+		string(trv1alpha1.ReasonOngoingRolloutsDeferred)) {
+		t.Errorf("transitions %v lack the deferral", sim.transitions)
+	}
+}
+
+// TestOngoingRolloutDeferralTimesOut checks that a Deployment stuck mid-rollout is not deferred forever:
+// once the workload timeout has elapsed since the first deferral, it fails the rollout as a timeout.
+func TestOngoingRolloutDeferralTimesOut(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Rollout.OngoingRolloutPolicy = trv1alpha1.OngoingRolloutPolicySkip
+	})
+	if err := sim.client.Create(sim.ctx, simDeployment(simAppNs, "api", nil,
+		map[string]string{"linkerd.io/inject": "enabled"})); err != nil {
+		t.Fatal(err)
+	}
+
+	sim.setProgressing(simAppNs, "api", "ReplicaSetUpdated")
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+	sim.rotateAnchor(newAnchor)
+
+	var since *metav1.Time
+	for range 10 {
+		if _, err := sim.r.Reconcile(sim.ctx, sim.req); err != nil {
+			t.Fatal(err)
+		}
+		if since = sim.get().Status.OngoingRolloutsDeferredSince; since != nil {
+			break
+		}
+	}
+	if since == nil {
+		t.Fatalf("first deferral not recorded (transitions: %v)", sim.transitions)
+	}
+
+	// Deferring again keeps the time of the first deferral.
+	if _, err := sim.r.Reconcile(sim.ctx, sim.req); err != nil {
+		t.Fatal(err)
+	}
+	cr := sim.get()
+	if got := cr.Status.OngoingRolloutsDeferredSince; got == nil || !got.Equal(since) {
+		t.Fatalf("deferred since %v, want the first deferral %v", got, since)
+	}
+
+	cr.Status.OngoingRolloutsDeferredSince = &metav1.Time{Time: time.Now().Add(-24 * time.Hour)}
+	if err := sim.client.Status().Update(sim.ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sim.r.Reconcile(sim.ctx, sim.req); err == nil {
+		t.Fatal("want the stuck Deployment to fail the rollout")
+	}
+	got := sim.get()
+	if got.Status.Reason == nil || *got.Status.Reason != trv1alpha1.ReasonRolloutTimeout {
+		t.Fatalf("reason %v, want %s (transitions: %v)", got.Status.Reason, trv1alpha1.ReasonRolloutTimeout, sim.transitions)
+	}
+	if sim.restartedAt(simAppNs, "api") != "" {
+		t.Error("stuck Deployment was restarted")
+	}
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/metrics"
+)
+
+// phaseSamples returns how many durations the phase duration histogram has observed for phase.
+func phaseSamples(t *testing.T, phase trv1alpha1.Phase) uint64 {
+	t.Helper()
+
+	m := &dto.Metric{}
+	if err := metrics.PhaseDuration.WithLabelValues(string(phase)).(prometheus.Metric).Write(m); err != nil {
+		t.Fatal(err)
+	}
+
+	return m.GetHistogram().GetSampleCount()
+}
+
+// TestPhaseDurationAcrossReconciles checks that a phase held over several reconciles, each starting with the
+// Idle reset, is timed from its first entry and observed once, when the rotation moves on.
+func TestPhaseDurationAcrossReconciles(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Protection.BeforeRolloutDelay = &metav1.Duration{Duration: time.Hour}
+	})
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+	before := phaseSamples(t, trv1alpha1.PhaseHold)
+
+	sim.rotateAnchor(newAnchor)
+	entered := sim.reconcileUntil(trv1alpha1.PhaseHold, 3).Status.PhaseEnteredAt
+	for range 3 {
+		if _, err := sim.r.Reconcile(sim.ctx, sim.req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cr := sim.get()
+	if cr.Status.TrackedPhase != trv1alpha1.PhaseHold || !cr.Status.PhaseEnteredAt.Equal(entered) {
+		t.Fatalf("tracked %s entered at %v, want Hold entered at %v", cr.Status.TrackedPhase,
+			cr.Status.PhaseEnteredAt, entered)
+	}
+	if got := phaseSamples(t, trv1alpha1.PhaseHold); got != before {
+		t.Fatalf("Hold observed %d times while held, want none", got-before)
+	}
+
+	// Move the delay into the past so the rotation rolls out.
+	cr.Status.StartedAt = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	if err := sim.client.Status().Update(sim.ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	cr = sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 6)
+
+	if got := phaseSamples(t, trv1alpha1.PhaseHold); got != before+1 {
+		t.Fatalf("Hold observed %d times, want once", got-before)
+	}
+	if _, ok := cr.Status.PhaseDurations[string(trv1alpha1.PhaseHold)]; !ok {
+		t.Fatalf("no Hold duration in %v", cr.Status.PhaseDurations)
+	}
+}
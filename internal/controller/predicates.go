@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"maps"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// controlAnnotationsChanged fires on updates that change any annotation under
+// trv1alpha1.ControlAnnotationPrefix, so annotation-driven controls are seen without
+// waiting for the periodic requeue. Other annotations, such as the plan hash written by the operator, are ignored.
+func controlAnnotationsChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+
+			return !maps.Equal(controlAnnotations(e.ObjectOld.GetAnnotations()),
+				controlAnnotations(e.ObjectNew.GetAnnotations()))
+		},
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// rotationClassMatches keeps only events for CRs whose spec.rotationClass equals the operator's class.
+func rotationClassMatches(class string) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		lTR, ok := obj.(*trv1alpha1.LinkerdTrustRotation)
+		return ok && inRotationClass(lTR, class)
+	})
+}
+
+// inRotationClass reports whether the CR's spec.rotationClass equals class.
+func inRotationClass(obj *trv1alpha1.LinkerdTrustRotation, class string) bool {
+	return obj.Spec.RotationClass == class
+}
+
+// controlAnnotations returns the subset of annotations under trv1alpha1.ControlAnnotationPrefix.
+func controlAnnotations(annotations map[string]string) map[string]string {
+	out := make(map[string]string)
+	for k, v := range annotations {
+		if strings.HasPrefix(k, trv1alpha1.ControlAnnotationPrefix) {
+			out[k] = v
+		}
+	}
+
+	return out
+}
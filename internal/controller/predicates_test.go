@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func annotatedLTR(annotations map[string]string) *trv1alpha1.LinkerdTrustRotation {
+	return &trv1alpha1.LinkerdTrustRotation{ObjectMeta: metav1.ObjectMeta{Name: "rotation", Annotations: annotations}}
+}
+
+func TestControlAnnotationsChanged(t *testing.T) {
+	other := trv1alpha1.ControlAnnotationPrefix + "other"
+	for _, tc := range []struct {
+		name     string
+		old, new map[string]string
+		want     bool
+	}{
+		{name: "no annotations", want: false},
+		{
+			name: "control annotation unchanged",
+			old:  map[string]string{trv1alpha1.VerifyAnnotation: "1"},
+			new:  map[string]string{trv1alpha1.VerifyAnnotation: "1"},
+			want: false,
+		},
+		{
+			name: "other annotation changed",
+			old:  map[string]string{trv1alpha1.VerifyAnnotation: "1", "plan-hash": "a"},
+			new:  map[string]string{trv1alpha1.VerifyAnnotation: "1", "plan-hash": "b"},
+			want: false,
+		},
+		{
+			name: "control annotation added",
+			new:  map[string]string{trv1alpha1.VerifyAnnotation: "1"},
+			want: true,
+		},
+		{
+			name: "control annotation value changed",
+			old:  map[string]string{trv1alpha1.VerifyAnnotation: "1"},
+			new:  map[string]string{trv1alpha1.VerifyAnnotation: "2"},
+			want: true,
+		},
+		{
+			name: "control annotation removed",
+			old:  map[string]string{trv1alpha1.VerifyAnnotation: "1", other: "true"},
+			new:  map[string]string{trv1alpha1.VerifyAnnotation: "1"},
+			want: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e := event.UpdateEvent{ObjectOld: annotatedLTR(tc.old), ObjectNew: annotatedLTR(tc.new)}
+			if got := controlAnnotationsChanged().Update(e); got != tc.want {
+				t.Fatalf("Update = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	p := controlAnnotationsChanged()
+	obj := annotatedLTR(map[string]string{trv1alpha1.VerifyAnnotation: "1"})
+	if p.Create(event.CreateEvent{Object: obj}) || p.Delete(event.DeleteEvent{Object: obj}) ||
+		p.Generic(event.GenericEvent{Object: obj}) {
+		t.Fatal("want only update events to pass")
+	}
+	if p.Update(event.UpdateEvent{ObjectNew: obj}) {
+		t.Fatal("want an update without the old object to be dropped")
+	}
+}
+
+func TestRotationClassMatches(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		class, crClass string
+		want           bool
+	}{
+		{name: "empty class, CR without class", want: true},
+		{name: "empty class, CR with class", crClass: "canary", want: false},
+		{name: "matching class", class: "canary", crClass: "canary", want: true},
+		{name: "non-matching class", class: "canary", crClass: "stable", want: false},
+		{name: "class, CR without class", class: "canary", want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := annotatedLTR(nil)
+			obj.Spec.RotationClass = tc.crClass
+
+			p := rotationClassMatches(tc.class)
+			got := []bool{
+				p.Create(event.CreateEvent{Object: obj}),
+				p.Update(event.UpdateEvent{ObjectOld: obj, ObjectNew: obj}),
+				p.Delete(event.DeleteEvent{Object: obj}),
+				p.Generic(event.GenericEvent{Object: obj}),
+			}
+			for i, g := range got {
+				if g != tc.want {
+					t.Fatalf("event %d passed = %v, want %v", i, g, tc.want)
+				}
+			}
+		})
+	}
+
+	if rotationClassMatches("").Create(event.CreateEvent{Object: &corev1.ConfigMap{}}) {
+		t.Fatal("want objects other than LinkerdTrustRotation to be dropped")
+	}
+}
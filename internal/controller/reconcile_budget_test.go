@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// TestReconcileBudgetYields checks that rollout.maxWorkloadsPerReconcile splits the data-plane rollout
+// across reconciles, resuming from the cursor without failing the rotation.
+func TestReconcileBudgetYields(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Rollout.MaxWorkloadsPerReconcile = 1
+	})
+	if err := sim.client.Create(sim.ctx, simDeployment(simAppNs, "api", nil,
+		map[string]string{"linkerd.io/inject": "enabled"})); err != nil {
+		t.Fatal(err)
+	}
+
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+	sim.rotateAnchor(newAnchor)
+
+	yielded := false
+	for range 10 {
+		res, err := sim.r.Reconcile(sim.ctx, sim.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := sim.get()
+		if cur := got.Status.Cursor; cur != nil && cur.Next == 1 && cur.Total == 2 {
+			if res.RequeueAfter != yieldRequeue {
+				t.Errorf("requeue after yield = %s, want %s", res.RequeueAfter, yieldRequeue)
+			}
+			if got.Status.Phase == nil || *got.Status.Phase != trv1alpha1.PhaseRollingDataPlane {
+				t.Errorf("phase after yield = %v, want %s", got.Status.Phase, trv1alpha1.PhaseRollingDataPlane)
+			}
+			if sim.restartedAt(simAppNs, "api") != "" && sim.restartedAt(simAppNs, "web") != "" {
+				t.Error("both workloads restarted in one reconcile")
+			}
+			yielded = true
+			break
+		}
+	}
+	if !yielded {
+		t.Fatalf("rollout never yielded after one workload (transitions: %v)", sim.transitions)
+	}
+
+	got := sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 5)
+	if want := []int{0, 1, 2, 0}; !slices.Equal(sim.cursorNext, want) {
+		t.Errorf("cursor positions = %v, want %v", sim.cursorNext, want)
+	}
+	if got.Status.Cursor == nil || got.Status.Cursor.Total != 2 {
+		t.Errorf("cursor = %+v, want a 2-workload plan", got.Status.Cursor)
+	}
+	for _, name := range []string{"api", "web"} {
+		if sim.restartedAt(simAppNs, name) == "" {
+			t.Errorf("Deployment %s/%s was not restarted", simAppNs, name)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/status"
+)
+
+func TestRecoverPanicMarksFailed(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := trv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	ltr := &trv1alpha1.LinkerdTrustRotation{ObjectMeta: metav1.ObjectMeta{Namespace: "linkerd", Name: "rotation"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ltr).
+		WithStatusSubresource(&trv1alpha1.LinkerdTrustRotation{}).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &LinkerdTrustRotationReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+	ctx := context.Background()
+
+	err := func() (retErr error) {
+		defer r.recoverPanic(ctx, logr.Discard(), status.New(c, scheme, logr.Discard()), ltr, &retErr)
+		var w *trv1alpha1.WorkRef
+		_ = w.Name // nil dereference
+		return nil
+	}()
+	if err == nil {
+		t.Fatal("expected the panic to be returned as an error")
+	}
+
+	got := &trv1alpha1.LinkerdTrustRotation{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(ltr), got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase == nil || *got.Status.Phase != trv1alpha1.PhaseFailed ||
+		got.Status.Reason == nil || *got.Status.Reason != trv1alpha1.ReasonInternalError {
+		t.Fatalf("status = %v/%v, want Failed/InternalError", got.Status.Phase, got.Status.Reason)
+	}
+	if len(recorder.Events) != 1 {
+		t.Fatalf("events = %d, want 1", len(recorder.Events))
+	}
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/secret"
+)
+
+// TestRollbackResumesAfterFailedRestart checks that a rollback interrupted by a failed control-plane restart,
+// after the previous anchor was already restored, is finished by the next reconcile.
+func TestRollbackResumesAfterFailedRestart(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Protection.RunLinkerdCheckProxy = true
+		ltr.Spec.Protection.RollbackOnFailure = true
+	})
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+
+	sim.failProxyChecks = true
+	sim.rotateAnchor(newAnchor)
+	for range 5 {
+		_, _ = sim.r.Reconcile(sim.ctx, sim.req)
+		if got := sim.get(); got.Status.Reason != nil && *got.Status.Reason == trv1alpha1.ReasonVerificationFailed {
+			break
+		}
+	}
+	if got := sim.get(); got.Status.Reason == nil || *got.Status.Reason != trv1alpha1.ReasonVerificationFailed {
+		t.Fatalf("want a failed data-plane check (transitions: %v)", sim.transitions)
+	}
+
+	// Retries are exhausted: the rollback restores the anchor, then the control-plane restart fails.
+	sim.failControlPlaneRestarts = 1
+	if _, err := sim.r.Reconcile(sim.ctx, sim.req); err == nil {
+		t.Fatal("want the rollback to fail on the control-plane restart")
+	}
+	got := sim.get()
+	if !got.Status.RollbackPending {
+		t.Fatal("want status.rollbackPending after the anchor was restored")
+	}
+	current := &corev1.Secret{}
+	if err := sim.client.Get(sim.ctx, types.NamespacedName{Namespace: simLinkerdNs, Name: simCurrentSecret}, current); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(current.Data["tls.crt"], oldAnchor) {
+		t.Fatal("want the previous anchor restored as current")
+	}
+
+	// The secrets no longer differ; the pending flag alone must finish the rollback.
+	sim.transitions = nil
+	if _, err := sim.r.Reconcile(sim.ctx, sim.req); err != nil {
+		t.Fatal(err)
+	}
+	got = sim.get()
+	if got.Status.Reason == nil || *got.Status.Reason != trv1alpha1.ReasonRolledBack {
+		t.Fatalf("reason %v, want %s (transitions: %v)", got.Status.Reason, trv1alpha1.ReasonRolledBack, sim.transitions)
+	}
+	if got.Status.RollbackPending {
+		t.Error("status.rollbackPending not cleared after the rollback finished")
+	}
+	restarting := string(trv1alpha1.PhaseRollingControlPlane) + "/" + string(trv1alpha1.ReasonControlPlaneRestarting)
+	if !slices.Contains(sim.transitions, restarting) {
+		t.Errorf("control plane not restarted from the restored anchor (transitions: %v)", sim.transitions)
+	}
+}
+
+// TestRollbackKeepsNewAnchor checks that a rollback saves the new anchor it replaces, so putting it back
+// into the current secret retries the rotation.
+func TestRollbackKeepsNewAnchor(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Protection.RunLinkerdCheckProxy = true
+		ltr.Spec.Protection.RollbackOnFailure = true
+	})
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+
+	sim.failProxyChecks = true
+	sim.rotateAnchor(newAnchor)
+	for range 10 {
+		_, _ = sim.r.Reconcile(sim.ctx, sim.req)
+		if got := sim.get(); got.Status.Reason != nil && *got.Status.Reason == trv1alpha1.ReasonRolledBack {
+			break
+		}
+	}
+	if got := sim.get(); got.Status.Reason == nil || *got.Status.Reason != trv1alpha1.ReasonRolledBack {
+		t.Fatalf("want the rotation rolled back (transitions: %v)", sim.transitions)
+	}
+
+	saved := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: simLinkerdNs, Name: secret.RolledBackSecretName(sim.get())}
+	if err := sim.client.Get(sim.ctx, key, saved); err != nil {
+		t.Fatalf("rolled-back secret: %v", err)
+	}
+	if !bytes.Equal(saved.Data["tls.crt"], newAnchor) || len(saved.Data["tls.key"]) == 0 {
+		t.Fatal("want the new anchor and its key saved by the rollback")
+	}
+
+	// With the cause fixed, the saved anchor is put back and rotated to.
+	sim.failProxyChecks = false
+	sim.rotateAnchor(saved.Data["tls.crt"])
+	sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 10)
+}
+
+// TestMisconfigurationNotRolledBack checks that a rotation failing on a spec error keeps the new anchor, and
+// resumes once the spec is fixed.
+func TestMisconfigurationNotRolledBack(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Protection.RunLinkerdCheckProxy = true
+		ltr.Spec.Protection.RollbackOnFailure = true
+		ltr.Spec.Protection.LinkerdCheckArgs = []string{"check", "--namespace={{.Unknown}}"}
+	})
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+
+	sim.rotateAnchor(newAnchor)
+	for range 5 {
+		_, _ = sim.r.Reconcile(sim.ctx, sim.req)
+		if got := sim.get(); got.Status.Reason != nil && *got.Status.Reason == trv1alpha1.ReasonMisconfigured {
+			break
+		}
+	}
+	if got := sim.get(); got.Status.Reason == nil || *got.Status.Reason != trv1alpha1.ReasonMisconfigured {
+		t.Fatalf("want a Misconfigured failure (transitions: %v)", sim.transitions)
+	}
+
+	current := &corev1.Secret{}
+	if err := sim.client.Get(sim.ctx, types.NamespacedName{Namespace: simLinkerdNs, Name: simCurrentSecret}, current); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(current.Data["tls.crt"], newAnchor) {
+		t.Fatal("new anchor replaced after a misconfiguration")
+	}
+
+	cr := sim.get()
+	cr.Spec.Protection.LinkerdCheckArgs = nil
+	if err := sim.client.Update(sim.ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 10)
+}
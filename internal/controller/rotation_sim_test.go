@@ -0,0 +1,378 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/rollout"
+)
+
+const (
+	simLinkerdNs      = "linkerd"
+	simAppNs          = "apps"
+	simCurrentSecret  = "linkerd-trust-anchor"
+	simPreviousSecret = "linkerd-previous-anchor"
+	simTrustRoots     = "linkerd-identity-trust-roots"
+)
+
+// rotationSim drives the reconciler against a fake cluster holding a Linkerd control plane, one meshed
+// Deployment and the trust secrets/ConfigMap. Workloads are always reported rolled out, check Jobs complete
+// as soon as they are created and access reviews are allowed, so a rotation runs to completion.
+type rotationSim struct {
+	t      *testing.T
+	ctx    context.Context
+	client client.Client
+	r      *LinkerdTrustRotationReconciler
+	req    ctrl.Request
+
+	// transitions records every distinct "<phase>/<reason>" written to the CR status, in order.
+	transitions []string
+	// cursorNext records every distinct status.cursor.next written, in order.
+	cursorNext []int
+
+	// failProxyChecks makes `linkerd check --proxy` Jobs fail instead of complete.
+	failProxyChecks bool
+	// failControlPlaneRestarts is the number of upcoming control-plane Deployment patches to reject.
+	failControlPlaneRestarts int
+}
+
+// newRotationSim sets up the fake cluster with anchor as the current trust anchor; mutate, if set,
+// adjusts the CR spec before it is created.
+func newRotationSim(t *testing.T, anchor []byte, mutate func(*trv1alpha1.LinkerdTrustRotation)) *rotationSim {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := trv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	ltr := &trv1alpha1.LinkerdTrustRotation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: simLinkerdNs, Name: "rotation", UID: "rotation"},
+		Spec: trv1alpha1.LinkerdTrustRotationSpec{
+			Trigger: trv1alpha1.RotationTrigger{OnTrustAnchorSecretsDiff: true},
+			Linkerd: trv1alpha1.LinkerdSpec{
+				Namespace:                 simLinkerdNs,
+				TrustAnchorSecret:         simCurrentSecret,
+				PreviousTrustAnchorSecret: simPreviousSecret,
+				TrustRootsConfigMap:       simTrustRoots,
+				BootstrapPreviousSecret:   true,
+				ManageTrustBundle:         true,
+			},
+			Rollout: trv1alpha1.RolloutSpec{
+				TargetAnnotationSelector: trv1alpha1.TargetAnnotationSelector{
+					Key:   "linkerd.io/inject",
+					Value: "enabled",
+					Targets: []trv1alpha1.TargetScope{
+						{KindType: string(rollout.KindDeployment), AllowedNamespaces: []string{simAppNs}},
+					},
+				},
+			},
+		},
+	}
+	if mutate != nil {
+		mutate(ltr)
+	}
+
+	objs := []client.Object{
+		ltr,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: simLinkerdNs, Name: simCurrentSecret},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{"tls.crt": anchor, "tls.key": []byte("key")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: simLinkerdNs, Name: linkerdIdentityIssuerSecret},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{"tls.crt": []byte("issuer"), "tls.key": []byte("key")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: simLinkerdNs, Name: simTrustRoots},
+			Data:       map[string]string{"ca-bundle.crt": string(anchor)},
+		},
+		simDeployment(simLinkerdNs, "linkerd-identity", map[string]string{rollout.LabelCPNamespace: simLinkerdNs}, nil),
+		simDeployment(simAppNs, "web", nil, map[string]string{"linkerd.io/inject": "enabled"}),
+	}
+
+	sim := &rotationSim{t: t, ctx: context.Background()}
+	sim.client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithStatusSubresource(&trv1alpha1.LinkerdTrustRotation{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create:            sim.create,
+			Patch:             sim.patch,
+			SubResourcePatch:  sim.patchStatus,
+			SubResourceUpdate: sim.updateStatus,
+		}).Build()
+	sim.r = &LinkerdTrustRotationReconciler{Client: sim.client, Scheme: scheme, Recorder: record.NewFakeRecorder(100),
+		RolloutPollInterval: 10 * time.Millisecond, RolloutConfirmWindow: 20 * time.Millisecond}
+	sim.req = ctrl.Request{NamespacedName: client.ObjectKeyFromObject(ltr)}
+
+	return sim
+}
+
+// simDeployment returns a single-replica Deployment whose status already reports a finished rollout;
+// the fake client never bumps the generation, so it stays rolled out after every restart.
+func simDeployment(ns, name string, labels, templateAnnotations map[string]string) *appsv1.Deployment {
+	replicas := int32(1)
+	selector := map[string]string{"app": name}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: selector, Annotations: templateAnnotations},
+			},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1},
+	}
+}
+
+// create completes (or, with failProxyChecks, fails) check Jobs on creation and allows every access review.
+func (s *rotationSim) create(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+	switch o := obj.(type) {
+	case *authorizationv1.SelfSubjectAccessReview:
+		o.Status.Allowed = true
+		return nil
+	case *batchv1.Job:
+		done := batchv1.JobComplete
+		if s.failProxyChecks && slices.Contains(o.Spec.Template.Spec.Containers[0].Args, "--proxy") {
+			done = batchv1.JobFailed
+		}
+		o.Status.Conditions = append(o.Status.Conditions, batchv1.JobCondition{Type: done, Status: corev1.ConditionTrue})
+	}
+
+	return c.Create(ctx, obj, opts...)
+}
+
+// patch rejects control-plane Deployment patches while failControlPlaneRestarts is positive.
+func (s *rotationSim) patch(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+	opts ...client.PatchOption) error {
+	if dp, ok := obj.(*appsv1.Deployment); ok && dp.Namespace == simLinkerdNs && s.failControlPlaneRestarts > 0 {
+		s.failControlPlaneRestarts--
+		return apierrors.NewInternalError(fmt.Errorf("injected control-plane restart failure"))
+	}
+
+	return c.Patch(ctx, obj, patch, opts...)
+}
+
+func (s *rotationSim) patchStatus(ctx context.Context, c client.Client, sub string, obj client.Object,
+	patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if err := c.SubResource(sub).Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+
+	s.record(obj)
+	return nil
+}
+
+func (s *rotationSim) updateStatus(ctx context.Context, c client.Client, sub string, obj client.Object,
+	opts ...client.SubResourceUpdateOption) error {
+	if err := c.SubResource(sub).Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+
+	s.record(obj)
+	return nil
+}
+
+// record appends the CR phase/reason and cursor position if they differ from the last recorded ones.
+func (s *rotationSim) record(obj client.Object) {
+	ltr, ok := obj.(*trv1alpha1.LinkerdTrustRotation)
+	if !ok {
+		return
+	}
+
+	if cur := ltr.Status.Cursor; cur != nil {
+		if n := len(s.cursorNext); n == 0 || s.cursorNext[n-1] != cur.Next {
+			s.cursorNext = append(s.cursorNext, cur.Next)
+		}
+	}
+
+	if ltr.Status.Phase == nil {
+		return
+	}
+
+	entry := string(*ltr.Status.Phase)
+	if ltr.Status.Reason != nil {
+		entry += "/" + string(*ltr.Status.Reason)
+	}
+
+	if n := len(s.transitions); n == 0 || s.transitions[n-1] != entry {
+		s.transitions = append(s.transitions, entry)
+	}
+}
+
+// get returns the CR as stored in the fake cluster.
+func (s *rotationSim) get() *trv1alpha1.LinkerdTrustRotation {
+	s.t.Helper()
+
+	got := &trv1alpha1.LinkerdTrustRotation{}
+	if err := s.client.Get(s.ctx, s.req.NamespacedName, got); err != nil {
+		s.t.Fatal(err)
+	}
+
+	return got
+}
+
+// reconcileUntil reconciles until the CR reaches phase, failing after maxSteps reconciles or on an error.
+func (s *rotationSim) reconcileUntil(phase trv1alpha1.Phase, maxSteps int) *trv1alpha1.LinkerdTrustRotation {
+	s.t.Helper()
+
+	for step := 1; step <= maxSteps; step++ {
+		if _, err := s.r.Reconcile(s.ctx, s.req); err != nil {
+			s.t.Fatalf("reconcile %d: %v (transitions: %v)", step, err, s.transitions)
+		}
+
+		if got := s.get(); got.Status.Phase != nil && *got.Status.Phase == phase {
+			return got
+		}
+	}
+
+	s.t.Fatalf("phase %s not reached after %d reconciles (transitions: %v)", phase, maxSteps, s.transitions)
+	return nil
+}
+
+// rotateAnchor replaces the current trust anchor, as cert-manager or an operator would.
+func (s *rotationSim) rotateAnchor(anchor []byte) {
+	s.t.Helper()
+
+	secret := &corev1.Secret{}
+	if err := s.client.Get(s.ctx, types.NamespacedName{Namespace: simLinkerdNs, Name: simCurrentSecret}, secret); err != nil {
+		s.t.Fatal(err)
+	}
+
+	secret.Data["tls.crt"] = anchor
+	if err := s.client.Update(s.ctx, secret); err != nil {
+		s.t.Fatal(err)
+	}
+}
+
+// expectTransitions checks that want appears in order (not necessarily adjacent) in the recorded transitions.
+func (s *rotationSim) expectTransitions(want ...string) {
+	s.t.Helper()
+
+	i := 0
+	for _, got := range s.transitions {
+		if i < len(want) && got == want[i] {
+			i++
+		}
+	}
+
+	if i < len(want) {
+		s.t.Fatalf("transition %q not seen in order; transitions: %v", want[i], s.transitions)
+	}
+}
+
+// restartedAt returns the restart annotation of a Deployment's pod template.
+func (s *rotationSim) restartedAt(ns, name string) string {
+	s.t.Helper()
+
+	dep := &appsv1.Deployment{}
+	if err := s.client.Get(s.ctx, types.NamespacedName{Namespace: ns, Name: name}, dep); err != nil {
+		s.t.Fatal(err)
+	}
+
+	return dep.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"]
+}
+
+// TestRotationEndToEnd drives a full rotation: bootstrap of the previous secret, detection of the overlap
+// after the anchor changes, control-plane restart, data-plane restart and cleanup back to a single anchor.
+func TestRotationEndToEnd(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, nil)
+
+	got := sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+	if got.Status.Trust == nil || got.Status.Trust.BundleState == nil ||
+		*got.Status.Trust.BundleState != trv1alpha1.BundleStateSingle {
+		t.Fatalf("bootstrap: trust = %+v, want a single anchor", got.Status.Trust)
+	}
+	if err := sim.client.Get(sim.ctx, types.NamespacedName{Namespace: simLinkerdNs, Name: simPreviousSecret},
+		&corev1.Secret{}); err != nil {
+		t.Fatalf("bootstrap: previous secret not created: %v", err)
+	}
+
+	sim.rotateAnchor(newAnchor)
+	got = sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 5)
+
+	sim.expectTransitions(
+		fmt.Sprintf("%s/%s", trv1alpha1.PhaseBootstrap, trv1alpha1.ReasonPreviousCreated),
+		fmt.Sprintf("%s/%s", trv1alpha1.PhaseDetecting, trv1alpha1.ReasonSecretsDiverged),
+		fmt.Sprintf("%s/%s", trv1alpha1.PhaseRollingControlPlane, trv1alpha1.ReasonEnteringDestructivePhase),
+		fmt.Sprintf("%s/%s", trv1alpha1.PhaseRollingControlPlane, trv1alpha1.ReasonControlPlaneRestarting),
+		fmt.Sprintf("%s/%s", trv1alpha1.PhaseRollingControlPlane, trv1alpha1.ReasonControlPlaneReady),
+		fmt.Sprintf("%s/%s", trv1alpha1.PhaseRollingDataPlane, trv1alpha1.ReasonDataPlaneBatchRestarting),
+		fmt.Sprintf("%s/%s", trv1alpha1.PhaseRollingDataPlane, trv1alpha1.ReasonDataPlaneThresholdReached),
+		fmt.Sprintf("%s/Completed", trv1alpha1.PhaseSucceeded),
+	)
+
+	// The cursor advances past the only workload, then a finished data plane resets it for the next
+	// rotation but keeps the plan size.
+	if want := []int{0, 1, 0}; !slices.Equal(sim.cursorNext, want) {
+		t.Errorf("cursor positions = %v, want %v", sim.cursorNext, want)
+	}
+	if cur := got.Status.Cursor; cur == nil || cur.Total != 1 || cur.Next != 0 || len(cur.Completed) != 0 {
+		t.Errorf("cursor = %+v, want a reset cursor for a 1-workload plan", got.Status.Cursor)
+	}
+	if got.Status.DataPlaneRotatedFP == "" || got.Status.DataPlaneRotatedFP != got.Status.ControlPlaneRotatedFP {
+		t.Errorf("rotated fingerprints = %q/%q, want both set to the new anchor",
+			got.Status.ControlPlaneRotatedFP, got.Status.DataPlaneRotatedFP)
+	}
+
+	for _, dep := range []types.NamespacedName{
+		{Namespace: simLinkerdNs, Name: "linkerd-identity"},
+		{Namespace: simAppNs, Name: "web"},
+	} {
+		if sim.restartedAt(dep.Namespace, dep.Name) == "" {
+			t.Errorf("Deployment %s was not restarted", dep)
+		}
+	}
+
+	err := sim.client.Get(sim.ctx, types.NamespacedName{Namespace: simLinkerdNs, Name: linkerdIdentityIssuerSecret},
+		&corev1.Secret{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("issuer secret: got %v, want it deleted for re-issuance", err)
+	}
+
+	destructive := false
+	for events := sim.r.Recorder.(*record.FakeRecorder).Events; len(events) > 0; {
+		if e := <-events; strings.HasPrefix(e, "Warning "+string(trv1alpha1.ReasonEnteringDestructivePhase)) &&
+			strings.Contains(e, linkerdIdentityIssuerSecret) && strings.Contains(e, "linkerd-identity") {
+			destructive = true
+		}
+	}
+	if !destructive {
+		t.Error("no EnteringDestructivePhase warning naming the issuer secret and control plane")
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := sim.client.Get(sim.ctx, types.NamespacedName{Namespace: simLinkerdNs, Name: simTrustRoots}, cm); err != nil {
+		t.Fatal(err)
+	}
+	if bundle := cm.Data["ca-bundle.crt"]; strings.TrimSpace(bundle) != strings.TrimSpace(string(newAnchor)) {
+		t.Errorf("trust roots bundle not pruned to the new anchor:\n%s", bundle)
+	}
+}
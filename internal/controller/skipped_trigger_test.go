@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/status"
+)
+
+func TestDivergenceGraceCountsSkippedTriggers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := trv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	ltr := &trv1alpha1.LinkerdTrustRotation{ObjectMeta: metav1.ObjectMeta{Namespace: "linkerd", Name: "rotation"}}
+	ltr.Spec.Trigger.DivergenceGracePeriod = &metav1.Duration{Duration: time.Hour}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ltr).
+		WithStatusSubresource(&trv1alpha1.LinkerdTrustRotation{}).Build()
+	r := &LinkerdTrustRotationReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+	statusMgr := status.New(c, scheme, logr.Discard())
+	ctx := context.Background()
+
+	// Every reconcile within the grace period sees the same divergence; it is one skipped trigger.
+	for range 3 {
+		settled, err := r.divergenceSettled(ctx, statusMgr, ltr, true, "hash-a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if settled {
+			t.Fatal("divergence settled within the grace period")
+		}
+	}
+	if ltr.Status.SkippedTriggerCount != 1 || ltr.Status.LastSkipReason != trv1alpha1.ReasonDivergenceGracePeriod {
+		t.Errorf("skipped = %d/%q, want 1/DivergenceGracePeriod", ltr.Status.SkippedTriggerCount, ltr.Status.LastSkipReason)
+	}
+
+	// No divergence is no trigger at all.
+	if _, err := r.divergenceSettled(ctx, statusMgr, ltr, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	if ltr.Status.SkippedTriggerCount != 1 {
+		t.Errorf("skipped = %d after the divergence cleared, want 1", ltr.Status.SkippedTriggerCount)
+	}
+
+	// A different divergence is a new trigger.
+	for range 2 {
+		if _, err := r.divergenceSettled(ctx, statusMgr, ltr, true, "hash-b"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ltr.Status.SkippedTriggerCount != 2 {
+		t.Errorf("skipped = %d after a second divergence, want 2", ltr.Status.SkippedTriggerCount)
+	}
+}
+
+// TestSkippedTriggerCountedOnce checks in a full reconcile loop that a divergence waiting out the grace period
+// is counted once however often it is reconciled, and that a bundle repeating a single anchor is counted as
+// a skipped duplicate-certificate trigger.
+func TestSkippedTriggerCountedOnce(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Trigger.DivergenceGracePeriod = &metav1.Duration{Duration: time.Hour}
+	})
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+
+	sim.rotateAnchor(newAnchor)
+	for range 4 {
+		if _, err := sim.r.Reconcile(sim.ctx, sim.req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := sim.get(); got.Status.SkippedTriggerCount != 1 {
+		t.Fatalf("skipped = %d after 4 reconciles of one divergence, want 1", got.Status.SkippedTriggerCount)
+	}
+
+	dup := newRotationSim(t, oldAnchor, func(ltr *trv1alpha1.LinkerdTrustRotation) {
+		ltr.Spec.Trigger.OnTrustAnchorSecretsDiff = false
+		ltr.Spec.Trigger.OnTrustRootsConfigMapChange = true
+		ltr.Spec.Linkerd.ManageTrustBundle = false
+	})
+	cm := &corev1.ConfigMap{}
+	if err := dup.client.Get(dup.ctx, types.NamespacedName{Namespace: simLinkerdNs, Name: simTrustRoots}, cm); err != nil {
+		t.Fatal(err)
+	}
+	cm.Data["ca-bundle.crt"] = string(oldAnchor) + string(oldAnchor)
+	if err := dup.client.Update(dup.ctx, cm); err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		if _, err := dup.r.Reconcile(dup.ctx, dup.req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := dup.get()
+	if got.Status.SkippedTriggerCount != 1 || got.Status.LastSkipReason != trv1alpha1.ReasonDuplicateCertificates {
+		t.Errorf("skipped = %d/%q, want 1/DuplicateCertificates", got.Status.SkippedTriggerCount, got.Status.LastSkipReason)
+	}
+}
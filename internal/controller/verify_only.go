@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/config_map"
+	"linkerd-trust-rotator.operators.infra/internal/secret"
+	"linkerd-trust-rotator.operators.infra/internal/status"
+)
+
+// verifyOnly answers trv1alpha1.VerifyAnnotation: it inspects the trust secrets and bundle read-only, runs the
+// mesh-wide linkerd check and records the findings in status.verification. Nothing is rotated, even on
+// overlap; each annotation value is verified once.
+func (r *LinkerdTrustRotationReconciler) verifyOnly(ctx context.Context, reqLogger logr.Logger, statusMgr *status.ManageStatus,
+	lTR *trv1alpha1.LinkerdTrustRotation, request string) (ctrl.Result, error) {
+	if v := lTR.Status.Verification; v != nil && v.Request == request {
+		return ctrl.Result{}, nil
+	}
+
+	if err := statusMgr.SetPhase(ctx, lTR,
+		status.PhasePtr(trv1alpha1.PhaseVerifying),
+		status.ReasonPtr(trv1alpha1.ReasonVerifyOnly),
+		status.StringPtr("Verify-only: checking the trust state and running linkerd check; nothing is rotated"),
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var findings []string
+
+	var anchors *secret.Result
+	if lTR.Spec.Trigger.OnTrustAnchorSecretsDiff {
+		var err error
+		anchors, err = secret.New(r.Client, r.Scheme, reqLogger).InspectTrustSecrets(ctx, lTR)
+		if err != nil {
+			findings = append(findings, fmt.Sprintf("trust anchor secrets: %v", err))
+		}
+	}
+
+	bundle, err := config_map.New(r.Client, r.Scheme, reqLogger).LoadAndInspectCMBundle(ctx, lTR)
+	if err != nil {
+		findings = append(findings, fmt.Sprintf("trust-roots bundle: %v", err))
+	}
+
+	findings = append(findings, trustFindings(anchors, bundle)...)
+
+	rolloutMgr := r.rolloutManager(reqLogger, statusMgr)
+	if err := rolloutMgr.RunVerifyCheck(ctx, lTR); err != nil {
+		findings = append(findings, fmt.Sprintf("linkerd check: %v", err))
+	}
+
+	if bundle != nil {
+		currentFP, previousFP := "", ""
+		if anchors != nil {
+			currentFP, previousFP = anchors.CurrentFP, anchors.PreviousFP
+		}
+		if err := statusMgr.SetTrustInfo(ctx, lTR, status.BundlePtr(bundle.State), currentFP, previousFP); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	now := metav1.NewTime(time.Now().UTC())
+	verification := &trv1alpha1.VerificationStatus{
+		Request:     request,
+		Healthy:     len(findings) == 0,
+		Findings:    findings,
+		CompletedAt: &now,
+	}
+	if err := statusMgr.SetVerification(ctx, lTR, verification); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if verification.Healthy {
+		r.Recorder.Event(lTR, corev1.EventTypeNormal, string(trv1alpha1.ReasonVerificationSucceeded),
+			"Verify-only check passed")
+	} else {
+		r.Recorder.Event(lTR, corev1.EventTypeWarning, string(trv1alpha1.ReasonVerificationFailed),
+			strings.Join(findings, "; "))
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// trustFindings reports inconsistencies between the trust anchor secrets and the trust-roots bundle;
+// either may be nil when it could not be inspected.
+func trustFindings(anchors *secret.Result, bundle *config_map.Result) []string {
+	if bundle == nil {
+		return nil
+	}
+
+	var findings []string
+
+	if bundle.Duplicates > 0 {
+		findings = append(findings, fmt.Sprintf("trust-roots bundle repeats %d certificate(s)", bundle.Duplicates))
+	}
+
+	if anchors == nil {
+		return findings
+	}
+
+	if fp, err := secret.FingerprintAnchorCert(anchors.CurrentPEM); err == nil &&
+		!slices.Contains(bundle.Fps, strings.TrimPrefix(fp, "sha256:")) {
+		findings = append(findings, fmt.Sprintf("current trust anchor %s is not in the trust-roots bundle", fp))
+	}
+
+	switch {
+	case anchors.Diverged && bundle.State != trv1alpha1.BundleStateOverlap:
+		findings = append(findings, "trust anchor secrets differ, but the trust-roots bundle holds a single anchor")
+	case !anchors.Diverged && bundle.State == trv1alpha1.BundleStateOverlap:
+		findings = append(findings, "trust-roots bundle holds several anchors, but the trust anchor secrets match")
+	}
+
+	return findings
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// TestVerifyOnly checks that the verify annotation reports an anchor change without rotating it.
+func TestVerifyOnly(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, nil)
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+
+	sim.rotateAnchor(newAnchor)
+	ltr := sim.get()
+	ltr.Annotations = map[string]string{trv1alpha1.VerifyAnnotation: "1"}
+	if err := sim.client.Update(sim.ctx, ltr); err != nil {
+		t.Fatal(err)
+	}
+
+	got := sim.reconcileUntil(trv1alpha1.PhaseVerifying, 1)
+	v := got.Status.Verification
+	if v == nil || v.Request != "1" || v.Healthy || len(v.Findings) != 2 {
+		t.Fatalf("verification = %+v, want 2 findings for request 1", v)
+	}
+	if got.Status.Reason == nil || *got.Status.Reason != trv1alpha1.ReasonVerificationFailed {
+		t.Errorf("reason = %v, want VerificationFailed", got.Status.Reason)
+	}
+	if got.Status.Trust == nil || got.Status.Trust.CurrentFP == got.Status.Trust.PreviousFP {
+		t.Errorf("trust = %+v, want the diverged fingerprints", got.Status.Trust)
+	}
+
+	// The same request is not verified again.
+	if _, err := sim.r.Reconcile(sim.ctx, sim.req); err != nil {
+		t.Fatal(err)
+	}
+	if again := sim.get().Status.Verification; !again.CompletedAt.Equal(v.CompletedAt) {
+		t.Errorf("request 1 verified twice")
+	}
+
+	for _, name := range []string{simPreviousSecret, linkerdIdentityIssuerSecret} {
+		if err := sim.client.Get(sim.ctx, types.NamespacedName{Namespace: simLinkerdNs, Name: name}, &corev1.Secret{}); err != nil {
+			t.Errorf("secret %s touched by verify-only: %v", name, err)
+		}
+	}
+	if sim.restartedAt(simLinkerdNs, "linkerd-identity") != "" || sim.restartedAt(simAppNs, "web") != "" {
+		t.Error("verify-only restarted a workload")
+	}
+}
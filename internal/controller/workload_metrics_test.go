@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/metrics"
+)
+
+// rotationSeries returns how many series of c carry the rotation label value rotation.
+func rotationSeries(t *testing.T, c prometheus.Collector, rotation string) int {
+	t.Helper()
+
+	ch := make(chan prometheus.Metric, 64)
+	c.Collect(ch)
+	close(ch)
+
+	n := 0
+	for m := range ch {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		for _, l := range pb.GetLabel() {
+			if l.GetName() == "rotation" && l.GetValue() == rotation {
+				n++
+			}
+		}
+	}
+
+	return n
+}
+
+// TestWorkloadMetricsForgotten checks that the remaining-workload gauges of a rotation are dropped once it
+// succeeds, and its rolled-workload counters once the CR is deleted.
+func TestWorkloadMetricsForgotten(t *testing.T) {
+	oldAnchor, newAnchor := anchorPEM(t), anchorPEM(t)
+	sim := newRotationSim(t, oldAnchor, nil)
+	rotation := sim.req.NamespacedName.String()
+	sim.reconcileUntil(trv1alpha1.PhaseIdle, 3)
+
+	sim.rotateAnchor(newAnchor)
+	sim.reconcileUntil(trv1alpha1.PhaseSucceeded, 5)
+
+	if n := rotationSeries(t, metrics.WorkloadsRemaining, rotation); n != 0 {
+		t.Fatalf("%d remaining-workload series left after the rotation succeeded", n)
+	}
+	if rotationSeries(t, metrics.WorkloadsRolled, rotation) == 0 {
+		t.Fatal("want rolled-workload series for the rotation")
+	}
+
+	if err := sim.client.Delete(sim.ctx, sim.get()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sim.r.Reconcile(sim.ctx, sim.req); err != nil {
+		t.Fatal(err)
+	}
+	if n := rotationSeries(t, metrics.WorkloadsRolled, rotation); n != 0 {
+		t.Fatalf("%d rolled-workload series left after the CR was deleted", n)
+	}
+}
@@ -0,0 +1,55 @@
+package managed
+
+import (
+	"maps"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+const (
+	ManagedByKey   = "app.kubernetes.io/managed-by"
+	ManagedByValue = "linkerd-trust-rotator"
+	OwnerNameKey   = "trust-anchor.linkerd.edenlab.io/owner-name"
+	OwnerNsKey     = "trust-anchor.linkerd.edenlab.io/owner-namespace"
+)
+
+// Labels returns the labels applied to every object the operator creates on behalf of the CR:
+// spec.commonLabels plus the managed-by and owner labels (the latter always win).
+func Labels(obj *trv1alpha1.LinkerdTrustRotation) map[string]string {
+	out := make(map[string]string, len(obj.Spec.CommonLabels)+3)
+	maps.Copy(out, obj.Spec.CommonLabels)
+
+	out[ManagedByKey] = ManagedByValue
+	out[OwnerNameKey] = obj.Name
+	out[OwnerNsKey] = obj.Namespace
+
+	return out
+}
+
+// MergeLabels returns a copy of base with extra added on top.
+func MergeLabels(base, extra map[string]string) map[string]string {
+	out := make(map[string]string, len(base)+len(extra))
+	maps.Copy(out, base)
+	maps.Copy(out, extra)
+
+	return out
+}
+
+// SetOwner makes owner the owner (or controller) of obj so it is garbage-collected with the CR.
+// Cross-namespace owner references are not allowed by Kubernetes, so it is a no-op (returning false)
+// when the namespaces differ; such objects remain discoverable by Labels.
+func SetOwner(owner, obj client.Object, scheme *runtime.Scheme, controller bool) (bool, error) {
+	if owner.GetNamespace() != obj.GetNamespace() {
+		return false, nil
+	}
+
+	if controller {
+		return true, controllerutil.SetControllerReference(owner, obj, scheme)
+	}
+
+	return true, controllerutil.SetOwnerReference(owner, obj, scheme)
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// PhaseDuration observes how long a rotation stayed in a phase before moving on.
+var PhaseDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name: "linkerd_trust_rotator_phase_duration_seconds",
+		Help: "Time spent in a rotation phase before leaving it.",
+		// 1s .. ~4.5h
+		Buckets: prometheus.ExponentialBuckets(1, 2, 15),
+	},
+	[]string{"phase"},
+)
+
+// WorkloadsRemaining is the number of data-plane workloads of a kind still to be restarted in the current plan.
+var WorkloadsRemaining = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "linkerd_trust_rotator_workloads_remaining",
+		Help: "Data-plane workloads left to restart in the current plan, by kind.",
+	},
+	[]string{"rotation", "kind"},
+)
+
+// WorkloadsRolled counts the data-plane workloads restarted, by kind.
+var WorkloadsRolled = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "linkerd_trust_rotator_workloads_rolled_total",
+		Help: "Data-plane workloads restarted, by kind.",
+	},
+	[]string{"rotation", "kind"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(PhaseDuration, WorkloadsRemaining, WorkloadsRolled)
+}
+
+// ObservePhaseDuration records the time spent in phase.
+func ObservePhaseDuration(phase string, d time.Duration) {
+	PhaseDuration.WithLabelValues(phase).Observe(d.Seconds())
+}
+
+// SetWorkloadsRemaining records how many workloads of kind the rotation (namespace/name) has left.
+func SetWorkloadsRemaining(rotation, kind string, n int) {
+	WorkloadsRemaining.WithLabelValues(rotation, kind).Set(float64(n))
+}
+
+// IncWorkloadsRolled counts a restarted workload of kind for the rotation (namespace/name).
+func IncWorkloadsRolled(rotation, kind string) {
+	WorkloadsRolled.WithLabelValues(rotation, kind).Inc()
+}
+
+// DeleteWorkloadsRemaining drops the workloads-remaining series of the rotation (namespace/name).
+func DeleteWorkloadsRemaining(rotation string) {
+	WorkloadsRemaining.DeletePartialMatch(prometheus.Labels{"rotation": rotation})
+}
+
+// DeleteRotation drops all per-rotation series of the rotation (namespace/name).
+func DeleteRotation(rotation string) {
+	DeleteWorkloadsRemaining(rotation)
+	WorkloadsRolled.DeletePartialMatch(prometheus.Labels{"rotation": rotation})
+}
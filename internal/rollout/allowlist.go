@@ -0,0 +1,63 @@
+package rollout
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// ParseGVKList parses a comma-separated list of "<group>/<version>/<Kind>" entries
+// (e.g. "kafka.strimzi.io/v1beta2/Kafka"). An empty string yields nil.
+func ParseGVKList(s string) ([]schema.GroupVersionKind, error) {
+	var gvks []schema.GroupVersionKind
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+
+		parts := strings.Split(entry, "/")
+		if len(parts) != 3 || len(parts[0]) == 0 || len(parts[1]) == 0 || len(parts[2]) == 0 {
+			return nil, fmt.Errorf("invalid GVK %q, want <group>/<version>/<Kind>", entry)
+		}
+
+		gvks = append(gvks, schema.GroupVersionKind{Group: parts[0], Version: parts[1], Kind: parts[2]})
+	}
+
+	return gvks, nil
+}
+
+// CheckAllowedCRKinds rejects CustomResource scopes whose GVK isn't in AllowedCRKinds.
+// Without an allowlist every GVK is allowed.
+func (m *ManageRollout) CheckAllowedCRKinds(obj *trv1alpha1.LinkerdTrustRotation) error {
+	if len(m.AllowedCRKinds) == 0 {
+		return nil
+	}
+
+	for _, scope := range obj.Spec.Rollout.TargetAnnotationSelector.Targets {
+		if scope.KindType != string(KindCR) {
+			continue
+		}
+
+		gvk := schema.GroupVersionKind{Group: scope.APIGroup, Version: scope.Version, Kind: scope.Kind}
+		if !gvkAllowed(m.AllowedCRKinds, gvk) {
+			return newError(ErrorKindMisconfigured, "targets[%s]: %s is not in the operator's allowed CR kinds",
+				scope.KindType, gvk.String())
+		}
+	}
+
+	return nil
+}
+
+func gvkAllowed(allowed []schema.GroupVersionKind, gvk schema.GroupVersionKind) bool {
+	for _, a := range allowed {
+		if a == gvk {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,61 @@
+package rollout
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func TestParseGVKList(t *testing.T) {
+	got, err := ParseGVKList(" kafka.strimzi.io/v1beta2/Kafka, ,apps.example.com/v1/App")
+	if err != nil {
+		t.Fatalf("ParseGVKList() error = %v", err)
+	}
+	want := []schema.GroupVersionKind{
+		{Group: "kafka.strimzi.io", Version: "v1beta2", Kind: "Kafka"},
+		{Group: "apps.example.com", Version: "v1", Kind: "App"},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("ParseGVKList() = %v, want %v", got, want)
+	}
+
+	if got, err := ParseGVKList(""); err != nil || got != nil {
+		t.Errorf("ParseGVKList(\"\") = %v, %v, want nil, nil", got, err)
+	}
+
+	for _, bad := range []string{"Kafka", "v1/Kafka", "kafka.strimzi.io//Kafka"} {
+		if _, err := ParseGVKList(bad); err == nil {
+			t.Errorf("ParseGVKList(%q) error = nil, want error", bad)
+		}
+	}
+}
+
+func TestCheckAllowedCRKinds(t *testing.T) {
+	obj := &trv1alpha1.LinkerdTrustRotation{}
+	obj.Spec.Rollout.TargetAnnotationSelector.Targets = []trv1alpha1.TargetScope{
+		{KindType: string(KindDeployment)},
+		{KindType: string(KindCR), APIGroup: "kafka.strimzi.io", Version: "v1beta2", Kind: "Kafka"},
+	}
+
+	m := &ManageRollout{Logger: logr.Discard()}
+	if err := m.CheckAllowedCRKinds(obj); err != nil {
+		t.Fatalf("CheckAllowedCRKinds() without an allowlist error = %v", err)
+	}
+
+	m.AllowedCRKinds = []schema.GroupVersionKind{{Group: "kafka.strimzi.io", Version: "v1beta2", Kind: "Kafka"}}
+	if err := m.CheckAllowedCRKinds(obj); err != nil {
+		t.Fatalf("CheckAllowedCRKinds() for an allowed GVK error = %v", err)
+	}
+
+	m.AllowedCRKinds = []schema.GroupVersionKind{{Group: "apps.example.com", Version: "v1", Kind: "App"}}
+	err := m.CheckAllowedCRKinds(obj)
+	if err == nil {
+		t.Fatal("CheckAllowedCRKinds() error = nil for a GVK outside the allowlist, want error")
+	}
+	if KindOf(err) != ErrorKindMisconfigured {
+		t.Errorf("KindOf() = %s, want %s", KindOf(err), ErrorKindMisconfigured)
+	}
+}
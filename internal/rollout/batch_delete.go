@@ -0,0 +1,143 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// podBatchRestart describes the pods of one workload restarted by restartPodsByDelete.
+type podBatchRestart struct {
+	kind      Kind
+	namespace string
+	name      string
+	selector  labels.Selector
+
+	// replicas is how many ready pods must be back after each batch.
+	replicas int32
+
+	// batchSize returns how many pods the next batch may hold (at least 1, or an error).
+	batchSize func(ctx context.Context) (int, error)
+
+	// ready reports whether a replacement pod counts as ready.
+	ready func(*corev1.Pod) bool
+}
+
+// restartPodsByDelete deletes the workload's pods in batches, oldest first, and lets its controller recreate
+// them. Pods are evicted (honoring PDBs) unless direct is set. Each batch must be fully replaced, with at
+// least r.replicas pods ready per r.ready, within timeout before the next one starts.
+func (m *ManageRollout) restartPodsByDelete(ctx context.Context, r podBatchRestart, direct bool, timeout time.Duration) error {
+	pods, err := m.listActivePods(ctx, r.namespace, r.selector)
+	if err != nil {
+		return fmt.Errorf("list pods for %s %s/%s: %w", r.kind, r.namespace, r.name, err)
+	}
+
+	if len(pods) == 0 {
+		m.Logger.Info(fmt.Sprintf("No pods found for %s %s/%s (nothing to delete)", r.kind, r.namespace, r.name))
+		return nil
+	}
+
+	// Oldest first, so the longest-running proxies pick up the new trust anchor first.
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp)
+	})
+
+	for len(pods) > 0 {
+		size, err := r.batchSize(ctx)
+		if err != nil {
+			return fmt.Errorf("rolloutDelete %s/%s: %w", r.namespace, r.name, err)
+		}
+		size = min(size, len(pods))
+
+		batch := pods[:size]
+		pods = pods[size:]
+
+		deleted := make(map[types.UID]struct{}, len(batch))
+		for i := range batch {
+			p := batch[i]
+			if err := m.removePod(ctx, &p, direct, timeout); err != nil {
+				return fmt.Errorf("rolloutDelete %s/%s: %w", r.namespace, r.name, err)
+			}
+			deleted[p.UID] = struct{}{}
+		}
+
+		if err := m.waitPodsReplaced(ctx, r.namespace, r.selector, deleted, r.replicas, r.ready, timeout); err != nil {
+			return fmt.Errorf("rolloutDelete %s/%s: %w", r.namespace, r.name, err)
+		}
+	}
+
+	return nil
+}
+
+// waitPodsReplaced waits until none of the deleted pods remain and at least replicas
+// non-terminating pods matching selector are running and ready per ready.
+func (m *ManageRollout) waitPodsReplaced(ctx context.Context, ns string, selector labels.Selector,
+	deleted map[types.UID]struct{}, replicas int32, ready func(*corev1.Pod) bool, timeout time.Duration) error {
+	ticker := time.NewTicker(m.pollInterval())
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		if time.Now().After(deadline) {
+			return newError(ErrorKindTimeout, "timeout waiting for deleted pods to be replaced")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		var pods corev1.PodList
+		if err := m.Client.List(ctx, &pods, client.InNamespace(ns), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			if m.backoffThrottled(ctx, err) {
+				continue
+			}
+			return err
+		}
+
+		var readyPods int32
+		pending := false
+		for i := range pods.Items {
+			p := &pods.Items[i]
+			if _, ok := deleted[p.UID]; ok {
+				pending = true
+				break
+			}
+			if p.DeletionTimestamp == nil && p.Status.Phase == corev1.PodRunning && ready(p) {
+				readyPods++
+			}
+			if err := podUnschedulable(p, time.Now()); err != nil {
+				return err
+			}
+		}
+
+		if !pending && readyPods >= replicas {
+			return nil
+		}
+	}
+}
+
+// listActivePods returns the non-terminating pods matching selector in ns.
+func (m *ManageRollout) listActivePods(ctx context.Context, ns string, selector labels.Selector) ([]corev1.Pod, error) {
+	var list corev1.PodList
+	if err := m.Client.List(ctx, &list, client.InNamespace(ns), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	pods := make([]corev1.Pod, 0, len(list.Items))
+	for _, p := range list.Items {
+		if p.DeletionTimestamp == nil {
+			pods = append(pods, p)
+		}
+	}
+
+	return pods, nil
+}
@@ -0,0 +1,49 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWaitPodsReplaced(t *testing.T) {
+	deleted := map[types.UID]struct{}{"web-old": {}}
+
+	tests := []struct {
+		name    string
+		pods    []client.Object
+		wantErr bool
+	}{
+		{name: "replaced and ready", pods: []client.Object{webPod("web-a", 0, true), webPod("web-b", 0, true)}},
+		{name: "deleted pod still present", pods: []client.Object{
+			webPod("web-old", time.Hour, true), webPod("web-a", 0, true), webPod("web-b", 0, true),
+		}, wantErr: true},
+		{name: "replacement not ready", pods: []client.Object{webPod("web-a", 0, true), webPod("web-b", 0, false)},
+			wantErr: true},
+		{name: "too few replicas", pods: []client.Object{webPod("web-a", 0, true)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := testScheme(t)
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.pods...).Build()
+			m := &ManageRollout{Client: c, Scheme: scheme, Logger: logr.Discard(), PollInterval: 10 * time.Millisecond}
+
+			err := m.waitPodsReplaced(context.Background(), "apps", labels.SelectorFromSet(webLabels), deleted, 2,
+				func(p *corev1.Pod) bool { return podReadyFor(p, nil) }, 200*time.Millisecond)
+			if tt.wantErr && KindOf(err) != ErrorKindTimeout {
+				t.Fatalf("waitPodsReplaced() error = %v, want a timeout", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("waitPodsReplaced() error = %v", err)
+			}
+		})
+	}
+}
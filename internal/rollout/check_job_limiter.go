@@ -0,0 +1,99 @@
+package rollout
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+const defaultMaxConcurrentCheckJobs = 3
+
+// checkJobs holds one limiter per CR, since protection.maxConcurrentCheckJobs is set per CR. It outlives
+// reconciles because ManageRollout is built per reconcile.
+var checkJobs = &checkJobLimiters{byOwner: map[types.NamespacedName]*jobLimiter{}}
+
+// checkJobLimiters hands out the check Job limiter of a CR.
+type checkJobLimiters struct {
+	mu      sync.Mutex
+	byOwner map[types.NamespacedName]*jobLimiter
+}
+
+// forOwner returns the limiter of owner, creating it on first use. Checks without an owner share one.
+func (c *checkJobLimiters) forOwner(owner *trv1alpha1.LinkerdTrustRotation) *jobLimiter {
+	var key types.NamespacedName
+	if owner != nil {
+		key = types.NamespacedName{Namespace: owner.Namespace, Name: owner.Name}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	l, ok := c.byOwner[key]
+	if !ok {
+		l = newJobLimiter()
+		c.byOwner[key] = l
+	}
+
+	return l
+}
+
+// ForgetCheckJobLimiter drops the check Job limiter of a deleted CR; checks still running keep theirs.
+func ForgetCheckJobLimiter(key types.NamespacedName) {
+	checkJobs.mu.Lock()
+	defer checkJobs.mu.Unlock()
+
+	delete(checkJobs.byOwner, key)
+}
+
+// jobLimiter is a counting semaphore whose limit is supplied on acquire, so spec changes apply immediately.
+type jobLimiter struct {
+	mu       sync.Mutex
+	running  int
+	released chan struct{}
+}
+
+func newJobLimiter() *jobLimiter {
+	return &jobLimiter{released: make(chan struct{})}
+}
+
+// acquire blocks until fewer than limit slots are taken or ctx is done.
+func (l *jobLimiter) acquire(ctx context.Context, limit int) error {
+	for {
+		l.mu.Lock()
+		if l.running < limit {
+			l.running++
+			l.mu.Unlock()
+			return nil
+		}
+		released := l.released
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// release frees a slot and wakes up all waiters.
+func (l *jobLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.running--
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+// maxConcurrentCheckJobs returns the configured check Job cap, falling back to the default.
+func maxConcurrentCheckJobs(owner *trv1alpha1.LinkerdTrustRotation) int {
+	if owner == nil || owner.Spec.Protection.MaxConcurrentCheckJobs < 1 {
+		return defaultMaxConcurrentCheckJobs
+	}
+
+	return int(owner.Spec.Protection.MaxConcurrentCheckJobs)
+}
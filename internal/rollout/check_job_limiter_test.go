@@ -0,0 +1,68 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func TestJobLimiter(t *testing.T) {
+	l := newJobLimiter()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := l.acquire(ctx, 2); err != nil {
+			t.Fatalf("acquire %d: %v", i, err)
+		}
+	}
+
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := l.acquire(short, 2); err == nil {
+		t.Fatal("expected acquire over the limit to block until the context expires")
+	}
+
+	acquired := make(chan error, 1)
+	go func() { acquired <- l.acquire(ctx, 2) }()
+
+	l.release()
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Fatalf("acquire after release: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter was not woken up by release")
+	}
+}
+
+func TestCheckJobLimitersPerOwner(t *testing.T) {
+	limiters := &checkJobLimiters{byOwner: map[types.NamespacedName]*jobLimiter{}}
+	a := &trv1alpha1.LinkerdTrustRotation{ObjectMeta: metav1.ObjectMeta{Namespace: "linkerd", Name: "a"}}
+	b := &trv1alpha1.LinkerdTrustRotation{ObjectMeta: metav1.ObjectMeta{Namespace: "linkerd", Name: "b"}}
+
+	if limiters.forOwner(a) != limiters.forOwner(a) {
+		t.Fatal("the same CR got two limiters")
+	}
+	if limiters.forOwner(a) == limiters.forOwner(b) {
+		t.Fatal("two CRs share a limiter")
+	}
+	if limiters.forOwner(nil) != limiters.forOwner(nil) {
+		t.Fatal("checks without an owner got two limiters")
+	}
+
+	// A CR at its cap doesn't block another CR.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiters.forOwner(a).acquire(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := limiters.forOwner(b).acquire(ctx, 1); err != nil {
+		t.Fatalf("acquire for b while a is at its cap: %v", err)
+	}
+}
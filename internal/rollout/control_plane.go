@@ -17,8 +17,14 @@ import (
 
 const (
 	LabelCPNamespace = "linkerd.io/control-plane-ns"
+	// labelCPComponent names the control-plane component of a Linkerd Deployment (e.g. "identity").
+	labelCPComponent = "linkerd.io/control-plane-component"
 )
 
+// defaultControlPlaneRestartOrder restarts identity first, so the other components start against an
+// identity service that already serves certificates from the new issuer (linkerd.controlPlaneRestartOrder).
+var defaultControlPlaneRestartOrder = []string{"identity"}
+
 func (m *ManageRollout) SelectLinkerdControlPlane(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) (*v1.DeploymentList, error) {
 	reqs := labels.NewSelector()
 	cpList := &v1.DeploymentList{}
@@ -37,6 +43,25 @@ func (m *ManageRollout) SelectLinkerdControlPlane(ctx context.Context, obj *trv1
 	return cpList, nil
 }
 
+// UnsettledControlPlane returns the control-plane Deployments that have not finished rolling out, using the
+// rollout waiter's readiness check once instead of waiting.
+func (m *ManageRollout) UnsettledControlPlane(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) ([]string, error) {
+	deployments, err := m.SelectLinkerdControlPlane(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for i := range deployments.Items {
+		if dp := &deployments.Items[i]; !deploymentRolledOut(dp) {
+			pending = append(pending, fmt.Sprintf("%s/%s", dp.Namespace, dp.Name))
+		}
+	}
+	sort.Strings(pending)
+
+	return pending, nil
+}
+
 // RestartLinkerdControlPlane bumps pod-template annotation for each CP deployment
 // and waits until rollout is completed.
 func (m *ManageRollout) RestartLinkerdControlPlane(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) error {
@@ -57,13 +82,11 @@ func (m *ManageRollout) RestartLinkerdControlPlane(ctx context.Context, obj *trv
 		return err
 	}
 
-	sort.Slice(deployments.Items, func(i, j int) bool {
-		return deployments.Items[i].Name > deployments.Items[j].Name
-	})
+	sortControlPlane(deployments.Items, controlPlaneRestartOrder(obj))
 
 	for _, dp := range deployments.Items {
 		m.Logger.Info(fmt.Sprintf("Start linkerd control plane Deployment: %s/%s restarting", dp.Namespace, dp.Name))
-		if err := m.bumpRestartAnnotation(ctx, &dp); err != nil {
+		if err := m.bumpRestartAnnotation(ctx, &dp, restartTimestamp(), nil); err != nil {
 			return err
 		}
 
@@ -75,13 +98,14 @@ func (m *ManageRollout) RestartLinkerdControlPlane(ctx context.Context, obj *trv
 		m.Logger.Info(fmt.Sprintf("Restarted linkerd control plane Deployment: %s/%s", dp.Namespace, dp.Name))
 	}
 
-	if err := m.runLinkerdCheckJob(ctx, NewCheckProxyOptions(
+	if err := m.runLinkerdCheck(ctx, obj.Spec.Protection.CheckMode, NewCheckProxyOptions(
 		true,
 		obj.Spec.Protection.LinkerdCheckProxyImage,
 		obj.Spec.Linkerd.Namespace,
 		obj.Spec.Linkerd.Namespace,
 		"control-plane",
 		rolloutPerLimit,
+		obj,
 	)); err != nil {
 		return err
 	}
@@ -100,3 +124,35 @@ func (m *ManageRollout) RestartLinkerdControlPlane(ctx context.Context, obj *trv
 
 	return nil
 }
+
+// controlPlaneRestartOrder returns linkerd.controlPlaneRestartOrder, falling back to the default.
+func controlPlaneRestartOrder(obj *trv1alpha1.LinkerdTrustRotation) []string {
+	if order := obj.Spec.Linkerd.ControlPlaneRestartOrder; len(order) > 0 {
+		return order
+	}
+
+	return defaultControlPlaneRestartOrder
+}
+
+// sortControlPlane orders deployments by their first matching entry in order (by control-plane-component
+// label or name); unlisted Deployments go last. Ties are broken by name.
+func sortControlPlane(deployments []v1.Deployment, order []string) {
+	rank := func(dp *v1.Deployment) int {
+		for i, c := range order {
+			if c == dp.Labels[labelCPComponent] || c == dp.Name {
+				return i
+			}
+		}
+
+		return len(order)
+	}
+
+	sort.SliceStable(deployments, func(i, j int) bool {
+		ri, rj := rank(&deployments[i]), rank(&deployments[j])
+		if ri != rj {
+			return ri < rj
+		}
+
+		return deployments[i].Name < deployments[j].Name
+	})
+}
@@ -0,0 +1,51 @@
+package rollout
+
+import (
+	"slices"
+	"testing"
+
+	v1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSortControlPlane(t *testing.T) {
+	cp := func(name, component string) v1.Deployment {
+		return v1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{labelCPComponent: component}}}
+	}
+	deployments := func() []v1.Deployment {
+		return []v1.Deployment{
+			cp("linkerd-proxy-injector", "proxy-injector"),
+			cp("linkerd-destination", "destination"),
+			cp("linkerd-smi", ""),
+			cp("linkerd-identity", "identity"),
+			cp("linkerd-policy", ""),
+		}
+	}
+	names := func(items []v1.Deployment) []string {
+		var out []string
+		for _, dp := range items {
+			out = append(out, dp.Name)
+		}
+		return out
+	}
+
+	cases := []struct {
+		name  string
+		order []string
+		want  []string
+	}{
+		{"default", defaultControlPlaneRestartOrder,
+			[]string{"linkerd-identity", "linkerd-destination", "linkerd-policy", "linkerd-proxy-injector", "linkerd-smi"}},
+		{"by label and name", []string{"identity", "linkerd-policy", "destination"},
+			[]string{"linkerd-identity", "linkerd-policy", "linkerd-destination", "linkerd-proxy-injector", "linkerd-smi"}},
+		{"unknown entries", []string{"missing", "proxy-injector"},
+			[]string{"linkerd-proxy-injector", "linkerd-destination", "linkerd-identity", "linkerd-policy", "linkerd-smi"}},
+	}
+	for _, tc := range cases {
+		items := deployments()
+		sortControlPlane(items, tc.order)
+		if got := names(items); !slices.Equal(got, tc.want) {
+			t.Errorf("%s: order = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package rollout
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// crReadyFunc reports whether a custom resource is ready.
+type crReadyFunc func(u *unstructured.Unstructured) bool
+
+// crReadiness returns the readiness predicate for a CR scope: the compiled readyExpression,
+// or crStatusOK when none is set.
+func crReadiness(expr string) (crReadyFunc, error) {
+	if len(expr) == 0 {
+		return crStatusOK, nil
+	}
+
+	prg, err := compileReadyExpression(expr)
+	if err != nil {
+		return nil, &RolloutError{Kind: ErrorKindMisconfigured, Err: err}
+	}
+
+	return func(u *unstructured.Unstructured) bool {
+		out, _, err := prg.Eval(map[string]any{"self": u.Object})
+		if err != nil {
+			// Typically a status field that isn't populated yet: not ready.
+			return false
+		}
+
+		ready, ok := out.Value().(bool)
+		return ok && ready
+	}, nil
+}
+
+// compileReadyExpression compiles a CEL readiness expression over `self` that must return a bool.
+func compileReadyExpression(expr string) (cel.Program, error) {
+	env, err := cel.NewEnv(cel.Variable("self", cel.DynType))
+	if err != nil {
+		return nil, fmt.Errorf("create CEL environment: %w", err)
+	}
+
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("compile readyExpression %q: %w", expr, iss.Err())
+	}
+
+	if t := ast.OutputType(); t != cel.BoolType && t != cel.DynType {
+		return nil, fmt.Errorf("readyExpression %q must return a bool, got %s", expr, t)
+	}
+
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("build readyExpression %q: %w", expr, err)
+	}
+
+	return prg, nil
+}
+
+// ValidateReadyExpressions compiles the readyExpression of every target scope, so a bad
+// expression fails the reconcile up front instead of in the middle of a rotation.
+func ValidateReadyExpressions(obj *trv1alpha1.LinkerdTrustRotation) error {
+	for _, scope := range obj.Spec.Rollout.TargetAnnotationSelector.Targets {
+		if len(scope.ReadyExpression) == 0 {
+			continue
+		}
+
+		if scope.KindType != string(KindCR) {
+			return fmt.Errorf("targets[%s]: readyExpression is only supported for %s", scope.KindType, KindCR)
+		}
+
+		if _, err := compileReadyExpression(scope.ReadyExpression); err != nil {
+			return fmt.Errorf("targets[%s/%s]: %w", scope.KindType, scope.Kind, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package rollout
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func TestCRReadiness(t *testing.T) {
+	kafka := func(ready, replicas int64, populated bool) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]any{
+			"spec": map[string]any{"replicas": replicas},
+		}}
+		if populated {
+			u.Object["status"] = map[string]any{"readyReplicas": ready}
+		}
+		return u
+	}
+
+	ready, err := crReadiness("self.status.readyReplicas == self.spec.replicas")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		u    *unstructured.Unstructured
+		want bool
+	}{
+		{name: "ready", u: kafka(3, 3, true), want: true},
+		{name: "not ready", u: kafka(2, 3, true), want: false},
+		{name: "status not populated", u: kafka(0, 3, false), want: false},
+	}
+
+	for _, tt := range tests {
+		if got := ready(tt.u); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"self.status.", "self.spec.replicas + 1"} {
+		if _, err := crReadiness(expr); err == nil {
+			t.Errorf("%q: expected a compile error", expr)
+		}
+	}
+}
+
+func TestValidateReadyExpressions(t *testing.T) {
+	obj := &trv1alpha1.LinkerdTrustRotation{}
+	obj.Spec.Rollout.TargetAnnotationSelector.Targets = []trv1alpha1.TargetScope{
+		{KindType: string(KindDeployment), ReadyExpression: "true"},
+	}
+
+	if err := ValidateReadyExpressions(obj); err == nil {
+		t.Fatal("expected readyExpression on a Deployment scope to be rejected")
+	}
+
+	obj.Spec.Rollout.TargetAnnotationSelector.Targets[0].KindType = string(KindCR)
+	if err := ValidateReadyExpressions(obj); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
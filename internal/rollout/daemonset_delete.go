@@ -0,0 +1,54 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// restartDaemonSetByDelete restarts a DaemonSet by deleting its pods in waves and letting the DaemonSet
+// controller recreate them, which also works with the OnDelete update strategy. Waves are sized by the
+// DaemonSet's rollingUpdate.maxUnavailable resolved against status.desiredNumberScheduled, and each wave
+// must be replaced and ready (see podReadyFor) within perWaveTimeout before the next one starts.
+func (m *ManageRollout) restartDaemonSetByDelete(ctx context.Context, ds *v1.DaemonSet, direct bool,
+	readyConditions []string, perWaveTimeout time.Duration) error {
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("build selector for DaemonSet %s/%s: %w", ds.Namespace, ds.Name, err)
+	}
+
+	// Every deleted pod is recreated on its node, so the scheduled pods must all be back after each wave.
+	scheduled := ds.Status.DesiredNumberScheduled
+	waveSize := daemonSetMaxUnavailable(ds, scheduled)
+
+	return m.restartPodsByDelete(ctx, podBatchRestart{
+		kind:      KindDaemonSet,
+		namespace: ds.Namespace,
+		name:      ds.Name,
+		selector:  selector,
+		replicas:  scheduled,
+		batchSize: func(context.Context) (int, error) { return waveSize, nil },
+		ready:     func(p *corev1.Pod) bool { return podReadyFor(p, readyConditions) },
+	}, direct, perWaveTimeout)
+}
+
+// daemonSetMaxUnavailable resolves the DaemonSet's rollingUpdate.maxUnavailable (default 1, percentages
+// rounded up as the DaemonSet controller does) against the scheduled pods (at least 1).
+func daemonSetMaxUnavailable(ds *v1.DaemonSet, scheduled int32) int {
+	maxUnavailable := intstr.FromInt32(1)
+	if ru := ds.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.MaxUnavailable != nil {
+		maxUnavailable = *ru.MaxUnavailable
+	}
+
+	n, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, int(scheduled), true)
+	if err != nil || n < 1 {
+		return 1
+	}
+
+	return n
+}
@@ -0,0 +1,115 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestDaemonSetMaxUnavailable(t *testing.T) {
+	withMaxUnavailable := func(v intstr.IntOrString) *v1.DaemonSet {
+		return &v1.DaemonSet{Spec: v1.DaemonSetSpec{UpdateStrategy: v1.DaemonSetUpdateStrategy{
+			RollingUpdate: &v1.RollingUpdateDaemonSet{MaxUnavailable: &v},
+		}}}
+	}
+
+	tests := []struct {
+		name      string
+		ds        *v1.DaemonSet
+		scheduled int32
+		want      int
+	}{
+		{name: "default is one node at a time", ds: &v1.DaemonSet{}, scheduled: 10, want: 1},
+		{name: "absolute value", ds: withMaxUnavailable(intstr.FromInt32(3)), scheduled: 10, want: 3},
+		{name: "percent rounds up", ds: withMaxUnavailable(intstr.FromString("25%")), scheduled: 10, want: 3},
+		{name: "zero is raised to 1", ds: withMaxUnavailable(intstr.FromInt32(0)), scheduled: 10, want: 1},
+		{name: "invalid percent falls back to 1", ds: withMaxUnavailable(intstr.FromString("x")), scheduled: 10, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := daemonSetMaxUnavailable(tt.ds, tt.scheduled); got != tt.want {
+				t.Fatalf("daemonSetMaxUnavailable() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestartDaemonSetByDelete(t *testing.T) {
+	tests := []struct {
+		name        string
+		scheduled   int32
+		wantWaves   []int
+		wantTimeout bool
+	}{
+		{name: "waves sized against the scheduled pods", scheduled: 3, wantWaves: []int{2, 1}},
+		// A node whose pod is missing still has to get one back before the restart counts as done.
+		{name: "scheduled pod missing", scheduled: 4, wantTimeout: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := testScheme(t)
+			maxUnavailable := intstr.FromString("50%")
+			ds := &v1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "web"},
+				Spec: v1.DaemonSetSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: webLabels},
+					UpdateStrategy: v1.DaemonSetUpdateStrategy{
+						RollingUpdate: &v1.RollingUpdateDaemonSet{MaxUnavailable: &maxUnavailable},
+					},
+				},
+				Status: v1.DaemonSetStatus{DesiredNumberScheduled: tt.scheduled},
+			}
+
+			// Play the DaemonSet controller: every deleted pod is replaced by a ready one on its node.
+			var waves []int
+			inWave, replacements := 0, 0
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ds,
+				webPod("web-a", 3*time.Minute, true), webPod("web-b", 2*time.Minute, true), webPod("web-c", time.Minute, true),
+			).WithInterceptorFuncs(interceptor.Funcs{
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					if err := c.Delete(ctx, obj, opts...); err != nil {
+						return err
+					}
+					inWave++
+					replacements++
+					return c.Create(ctx, webPod(fmt.Sprintf("web-new-%d", replacements), 0, true))
+				},
+				List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					if _, ok := list.(*corev1.PodList); ok && inWave > 0 {
+						waves = append(waves, inWave)
+						inWave = 0
+					}
+					return c.List(ctx, list, opts...)
+				},
+			}).Build()
+			m := &ManageRollout{Client: c, Scheme: scheme, Logger: logr.Discard(), PollInterval: 10 * time.Millisecond}
+
+			err := m.restartDaemonSetByDelete(context.Background(), ds, true, nil, 200*time.Millisecond)
+			if tt.wantTimeout {
+				if KindOf(err) != ErrorKindTimeout {
+					t.Fatalf("restartDaemonSetByDelete() error = %v, want a timeout", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("restartDaemonSetByDelete() error = %v", err)
+			}
+			if !slices.Equal(waves, tt.wantWaves) {
+				t.Errorf("waves %v, want %v", waves, tt.wantWaves)
+			}
+		})
+	}
+}
@@ -3,16 +3,24 @@ package rollout
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
+	"strconv"
 	"time"
 
+	"github.com/go-logr/logr"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/managed"
+	"linkerd-trust-rotator.operators.infra/internal/metrics"
 	"linkerd-trust-rotator.operators.infra/internal/status"
 )
 
@@ -21,6 +29,9 @@ const (
 	Delete  = "rolloutDelete"  // delete pods one-by-one (STS safe way)
 )
 
+// NoWorkloadsMatchedMessage is reported when the data-plane selector matches nothing.
+const NoWorkloadsMatchedMessage = "no data-plane workloads matched selector"
+
 // Kind enumerates supported workload kinds in the work queue.
 type Kind string
 
@@ -43,6 +54,21 @@ type WorkItem struct {
 	// Optional vendor bump for CRs (e.g., Strimzi)
 	BumpAnnotationKey   string
 	BumpAnnotationValue string
+
+	// Optional per-scope override of protection.runLinkerdCheckProxy
+	RunProxyCheck *bool
+
+	// Optional gate checked before each pod delete (rolloutDelete only)
+	PreDeleteHook *trv1alpha1.PreDeleteHookSpec
+
+	// Ready replicas required before the restart (nil: all)
+	MinAvailable *intstr.IntOrString
+
+	// Optional CEL readiness expression for CRs (default: readyPods == pods)
+	ReadyExpression string
+
+	// Pod condition types that must be True after a delete-based restart (default: Ready)
+	ReadyConditions []string
 }
 
 type WorkItemDryRun struct {
@@ -52,26 +78,77 @@ type WorkItemDryRun struct {
 
 	// Strategy ties to your rollout strategy decision
 	Strategy string
+
+	// Tier is the workload's data-plane tier when rollout.tierOrder is set
+	Tier string `yaml:"tier,omitempty"`
+
+	// Priority is the priority of the target scope that selected the workload
+	Priority int `yaml:"priority,omitempty"`
 }
 
 // Result now also carries an ordered queue.
 type Result struct {
-	// Ordered work queue built exactly in the order of `targets`
+	// Ordered work queue built in the order of `targets`, by ascending scope priority
 	Queue []WorkItem
 
 	// Optional: quick stats for logs/metrics (no need to keep full grouped slices)
 	Stats struct {
 		Deployments, StatefulSets, DaemonSets, CustomResources int
+
+		// SkippedUpToDate counts workloads dropped because they already run with the overlap bundle
+		SkippedUpToDate int
+
+		// SkippedNamespaces counts namespaces whose workloads were dropped because they opted out
+		SkippedNamespaces int
+
+		// SkippedScaledToZero counts Deployments and StatefulSets dropped because they run no replicas
+		SkippedScaledToZero int
+
+		// SkippedByKind counts workloads dropped because their kind is not listed in rollout.onlyKinds
+		SkippedByKind int
+	}
+}
+
+// Plan is a data-plane rollout plan: the ordered queue with stats, plus its stable hash
+// used by the status cursor to resume.
+type Plan struct {
+	Result
+
+	// Hash identifies the queue; it changes whenever the selection or ordering changes.
+	Hash string
+}
+
+// SelectLinkerdDataPlane builds the data-plane plan for the CR after checking its CR scopes
+// against the allowlist (see CheckAllowedCRKinds), then verifies the RBAC of per-workload
+// strategy overrides (see preflightPlanRBAC).
+func (m *ManageRollout) SelectLinkerdDataPlane(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) (*Plan, error) {
+	if err := m.CheckAllowedCRKinds(obj); err != nil {
+		return nil, err
 	}
+
+	plan, err := BuildDataPlanePlan(ctx, m.Client, m.Logger, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.preflightPlanRBAC(ctx, obj, plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
 }
 
-func (m *ManageRollout) SelectLinkerdDataPlane(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) (*Result, error) {
+// BuildDataPlanePlan computes the data-plane rollout plan (ordered queue, stats and plan hash) for the CR.
+// It only reads from the cluster and never touches the CR status, so it can be used outside the controller.
+func BuildDataPlanePlan(ctx context.Context, c client.Reader, logger logr.Logger, obj *trv1alpha1.LinkerdTrustRotation) (*Plan, error) {
 	targets := obj.Spec.Rollout.TargetAnnotationSelector.Targets
 	annotationKey := obj.Spec.Rollout.TargetAnnotationSelector.Key
-	annotationValue := obj.Spec.Rollout.TargetAnnotationSelector.Value
+	annotationValues := targetAnnotationValues(&obj.Spec.Rollout.TargetAnnotationSelector)
+	matchLocation := obj.Spec.Rollout.TargetAnnotationSelector.MatchLocation
 	result := &Result{}
 
-	for _, scope := range targets {
+	for _, i := range scopeOrder(targets) {
+		scope := targets[i]
 		namespaces := scope.AllowedNamespaces
 		if len(namespaces) == 0 {
 			return nil, fmt.Errorf("targets[%s]: allowedNamespaces is required", scope.KindType)
@@ -82,27 +159,36 @@ func (m *ManageRollout) SelectLinkerdDataPlane(ctx context.Context, obj *trv1alp
 			rolloutStrategy = Restart
 		}
 
+		if rolloutStrategy == Delete && scope.KindType == string(KindCR) {
+			return nil, fmt.Errorf("targets[%s]: %s is only supported for StatefulSet, Deployment and DaemonSet", scope.KindType, Delete)
+		}
+
 		switch scope.KindType {
 		case string(KindDaemonSet):
 			var numDetections int
 			for _, ns := range namespaces {
 				var list v1.DaemonSetList
-				if err := m.Client.List(ctx, &list, client.InNamespace(ns)); err != nil {
+				if err := c.List(ctx, &list, client.InNamespace(ns)); err != nil {
 					return nil, fmt.Errorf("list Daemonsets in %q: %w", ns, err)
 				}
 
 				for i := range list.Items {
-					if hasAnnotationOnTemplate(list.Items[i].Spec.Template, annotationKey, annotationValue) {
+					if matchesAnnotation(matchLocation, hasAnnotationOnTemplate(list.Items[i].Spec.Template, annotationKey, annotationValues),
+						list.Items[i].Annotations, annotationKey, annotationValues) {
 						ds := *list.Items[i].DeepCopy()
 						workItemDryRun := &WorkItemDryRun{
 							Kind:      KindDaemonSet,
 							Namespace: ds.Namespace,
 							Name:      ds.Name,
-							Strategy:  rolloutStrategy,
+							Strategy:  strategyOverride(logger, rolloutStrategy, &ds, ds.Spec.Template),
+							Priority:  scope.Priority,
 						}
 						result.Queue = append(result.Queue, WorkItem{
-							WorkItemDryRun: workItemDryRun,
-							Ds:             &ds,
+							WorkItemDryRun:  workItemDryRun,
+							Ds:              &ds,
+							RunProxyCheck:   scope.RunLinkerdCheckProxy,
+							MinAvailable:    scope.MinAvailable,
+							ReadyConditions: scope.ReadyConditions,
 						})
 
 						numDetections++
@@ -111,29 +197,34 @@ func (m *ManageRollout) SelectLinkerdDataPlane(ctx context.Context, obj *trv1alp
 				}
 			}
 
-			m.Logger.Info(fmt.Sprintf("Found %d DaemonSets in namespaces %v",
+			logger.Info(fmt.Sprintf("Found %d DaemonSets in namespaces %v",
 				numDetections, namespaces))
 
 		case string(KindDeployment):
 			var numDetections int
 			for _, ns := range namespaces {
 				var list v1.DeploymentList
-				if err := m.Client.List(ctx, &list, client.InNamespace(ns)); err != nil {
+				if err := c.List(ctx, &list, client.InNamespace(ns)); err != nil {
 					return nil, fmt.Errorf("list Deployments in %q: %w", ns, err)
 				}
 
 				for i := range list.Items {
-					if hasAnnotationOnTemplate(list.Items[i].Spec.Template, annotationKey, annotationValue) {
+					if matchesAnnotation(matchLocation, hasAnnotationOnTemplate(list.Items[i].Spec.Template, annotationKey, annotationValues),
+						list.Items[i].Annotations, annotationKey, annotationValues) {
 						dep := *list.Items[i].DeepCopy()
 						workItemDryRun := &WorkItemDryRun{
 							Kind:      KindDeployment,
 							Namespace: dep.Namespace,
 							Name:      dep.Name,
-							Strategy:  rolloutStrategy,
+							Strategy:  strategyOverride(logger, rolloutStrategy, &dep, dep.Spec.Template),
+							Priority:  scope.Priority,
 						}
 						result.Queue = append(result.Queue, WorkItem{
-							WorkItemDryRun: workItemDryRun,
-							Dep:            &dep,
+							WorkItemDryRun:  workItemDryRun,
+							Dep:             &dep,
+							RunProxyCheck:   scope.RunLinkerdCheckProxy,
+							MinAvailable:    scope.MinAvailable,
+							ReadyConditions: scope.ReadyConditions,
 						})
 
 						numDetections++
@@ -142,7 +233,7 @@ func (m *ManageRollout) SelectLinkerdDataPlane(ctx context.Context, obj *trv1alp
 				}
 			}
 
-			m.Logger.Info(fmt.Sprintf("Found %d Deployments in namespaces %v",
+			logger.Info(fmt.Sprintf("Found %d Deployments in namespaces %v",
 				numDetections, namespaces))
 
 		case string(KindCR):
@@ -158,23 +249,27 @@ func (m *ManageRollout) SelectLinkerdDataPlane(ctx context.Context, obj *trv1alp
 				ul := &unstructured.UnstructuredList{}
 				ul.SetGroupVersionKind(gvk) // list will still work with UnstructuredList
 
-				if err := m.Client.List(ctx, ul, client.InNamespace(ns)); err != nil {
+				if err := c.List(ctx, ul, client.InNamespace(ns)); err != nil {
 					return nil, fmt.Errorf("list %s in %q: %w", gvk.String(), ns, err)
 				}
 
 				for i := range ul.Items {
-					if crHasTemplateAnnotation(&ul.Items[i], annotationKey, annotationValue) {
+					if matchesAnnotation(matchLocation, crHasTemplateAnnotation(&ul.Items[i], annotationKey, annotationValues),
+						ul.Items[i].GetAnnotations(), annotationKey, annotationValues) {
 						cr := *ul.Items[i].DeepCopy()
 						workItemDryRun := &WorkItemDryRun{
 							Kind:      KindCR,
 							Namespace: cr.GetNamespace(),
 							Name:      cr.GetName(),
 							Strategy:  rolloutStrategy,
+							Priority:  scope.Priority,
 						}
 						// If CR scope defines vendor-specific annotation bump, carry it
 						crItem := WorkItem{
-							WorkItemDryRun: workItemDryRun,
-							CR:             &cr,
+							WorkItemDryRun:  workItemDryRun,
+							CR:              &cr,
+							RunProxyCheck:   scope.RunLinkerdCheckProxy,
+							ReadyExpression: scope.ReadyExpression,
 						}
 
 						if scope.AnnotationBump != nil {
@@ -189,14 +284,14 @@ func (m *ManageRollout) SelectLinkerdDataPlane(ctx context.Context, obj *trv1alp
 				}
 			}
 
-			m.Logger.Info(fmt.Sprintf("Found %d Custom Resources in namespaces %v",
+			logger.Info(fmt.Sprintf("Found %d Custom Resources in namespaces %v",
 				numDetections, namespaces))
 
 		case string(KindStatefulSet):
 			var numDetections int
 			for _, ns := range namespaces {
 				var list v1.StatefulSetList
-				if err := m.Client.List(ctx, &list, client.InNamespace(ns)); err != nil {
+				if err := c.List(ctx, &list, client.InNamespace(ns)); err != nil {
 					return nil, fmt.Errorf("list StatefulSets in %q: %w", ns, err)
 				}
 
@@ -205,17 +300,23 @@ func (m *ManageRollout) SelectLinkerdDataPlane(ctx context.Context, obj *trv1alp
 				})
 
 				for i := range list.Items {
-					if hasAnnotationOnTemplate(list.Items[i].Spec.Template, annotationKey, annotationValue) {
+					if matchesAnnotation(matchLocation, hasAnnotationOnTemplate(list.Items[i].Spec.Template, annotationKey, annotationValues),
+						list.Items[i].Annotations, annotationKey, annotationValues) {
 						sts := *list.Items[i].DeepCopy()
 						workItemDryRun := &WorkItemDryRun{
 							Kind:      KindStatefulSet,
 							Namespace: sts.Namespace,
 							Name:      sts.Name,
-							Strategy:  rolloutStrategy,
+							Strategy:  strategyOverride(logger, rolloutStrategy, &sts, sts.Spec.Template),
+							Priority:  scope.Priority,
 						}
 						result.Queue = append(result.Queue, WorkItem{
-							WorkItemDryRun: workItemDryRun,
-							Sts:            &sts,
+							WorkItemDryRun:  workItemDryRun,
+							Sts:             &sts,
+							RunProxyCheck:   scope.RunLinkerdCheckProxy,
+							PreDeleteHook:   scope.PreDeleteHook,
+							MinAvailable:    scope.MinAvailable,
+							ReadyConditions: scope.ReadyConditions,
 						})
 
 						numDetections++
@@ -224,7 +325,7 @@ func (m *ManageRollout) SelectLinkerdDataPlane(ctx context.Context, obj *trv1alp
 				}
 			}
 
-			m.Logger.Info(fmt.Sprintf("Found %d StatefulSets in namespaces %v",
+			logger.Info(fmt.Sprintf("Found %d StatefulSets in namespaces %v",
 				numDetections, namespaces))
 
 		default:
@@ -233,28 +334,293 @@ func (m *ManageRollout) SelectLinkerdDataPlane(ctx context.Context, obj *trv1alp
 
 	}
 
-	return result, nil
+	if kinds := obj.Spec.Rollout.OnlyKinds; len(kinds) > 0 {
+		skipOtherKinds(logger, result, kinds)
+	}
+
+	skipScaledToZero(logger, result)
+
+	if obj.Spec.Rollout.SkipOwnedWorkloads {
+		skipOwnedWorkItems(logger, result, targets)
+	}
+
+	if key := obj.Spec.Rollout.NamespaceOptOutKey; len(key) > 0 {
+		if err := skipOptedOutNamespaces(ctx, c, logger, key, result); err != nil {
+			return nil, err
+		}
+	}
+
+	if obj.Spec.Rollout.SkipUpToDateWorkloads {
+		if err := skipUpToDateWorkItems(ctx, c, logger, obj, result); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(obj.Spec.Rollout.TierOrder) > 0 {
+		sortWorkItemsByTier(logger, result, obj.Spec.Rollout.TierOrder, obj.Spec.Rollout.TierLabelKey)
+	}
+
+	return &Plan{Result: *result, Hash: planHash(result.Queue)}, nil
+}
+
+// scopeOrder returns the indexes of targets sorted by ascending priority, ties keeping the declaration order.
+func scopeOrder(targets []trv1alpha1.TargetScope) []int {
+	order := make([]int, len(targets))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return targets[order[i]].Priority < targets[order[j]].Priority
+	})
+
+	return order
 }
 
-// crHasTemplateAnnotation checks common pod-template locations in CRDs for key=value.
-func crHasTemplateAnnotation(u *unstructured.Unstructured, key, val string) bool {
+// sortWorkItemsByTier orders the queue by the integer tier in each workload's key label (or annotation):
+// ascending for LeavesFirst, descending for LeavesLast. Workloads without a valid tier go last and
+// ties keep the selection order.
+func sortWorkItemsByTier(logger logr.Logger, result *Result, order trv1alpha1.TierOrder, key string) {
+	if len(key) == 0 {
+		key = tierKey
+	}
+
+	type tiered struct {
+		w    WorkItem
+		tier int
+		ok   bool
+	}
+
+	items := make([]tiered, 0, len(result.Queue))
+	for _, w := range result.Queue {
+		obj := getObject(w)
+		value, found := obj.GetLabels()[key]
+		if !found {
+			value, found = obj.GetAnnotations()[key]
+		}
+
+		item := tiered{w: w}
+		if found {
+			tier, err := strconv.Atoi(value)
+			if err != nil {
+				logger.Info(fmt.Sprintf("Ignoring invalid tier %q on %s %s/%s", value, w.Kind, getNamespace(w), getName(w)))
+			} else {
+				item.tier, item.ok = tier, true
+				w.Tier = value
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if !a.ok || !b.ok {
+			return a.ok && !b.ok
+		}
+		if order == trv1alpha1.TierOrderLeavesLast {
+			return a.tier > b.tier
+		}
+
+		return a.tier < b.tier
+	})
+
+	for i := range items {
+		result.Queue[i] = items[i].w
+	}
+}
+
+// skipOwnedWorkItems removes queue items controlled by another queued workload or by a kind listed in targets,
+// so the top-level workload is restarted and its controller propagates the change.
+func skipOwnedWorkItems(logger logr.Logger, result *Result, targets []trv1alpha1.TargetScope) {
+	selected := make(map[string]struct{}, len(result.Queue))
+	for _, w := range result.Queue {
+		selected[ownerKey(string(w.Kind), getNamespace(w), getName(w))] = struct{}{}
+	}
+
+	knownKinds := make(map[string]struct{}, len(targets))
+	for _, scope := range targets {
+		if scope.KindType == string(KindCR) {
+			knownKinds[scope.Kind] = struct{}{}
+		} else {
+			knownKinds[scope.KindType] = struct{}{}
+		}
+	}
+
+	kept := result.Queue[:0]
+	for _, w := range result.Queue {
+		owner := metav1.GetControllerOf(getObject(w))
+		if owner == nil {
+			kept = append(kept, w)
+			continue
+		}
+
+		_, ownerSelected := selected[ownerKey(ownerKind(owner.Kind), getNamespace(w), owner.Name)]
+		_, ownerKnown := knownKinds[owner.Kind]
+		if !ownerSelected && !ownerKnown {
+			kept = append(kept, w)
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("Skipping %s %s/%s: controlled by %s %s",
+			w.Kind, getNamespace(w), getName(w), owner.Kind, owner.Name))
+
+		result.uncount(w.Kind)
+	}
+
+	result.Queue = kept
+}
+
+// skipScaledToZero removes Deployments and StatefulSets explicitly scaled to zero replicas:
+// they run no proxies, so a restart would be a no-op.
+func skipScaledToZero(logger logr.Logger, result *Result) {
+	kept := result.Queue[:0]
+	for _, w := range result.Queue {
+		var replicas *int32
+		switch w.Kind {
+		case KindDeployment:
+			replicas = w.Dep.Spec.Replicas
+		case KindStatefulSet:
+			replicas = w.Sts.Spec.Replicas
+		}
+
+		if replicas == nil || *replicas != 0 {
+			kept = append(kept, w)
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("Skipping %s %s/%s: scaled to zero replicas", w.Kind, getNamespace(w), getName(w)))
+
+		result.uncount(w.Kind)
+		result.Stats.SkippedScaledToZero++
+	}
+
+	result.Queue = kept
+}
+
+// skipOtherKinds removes queue items whose kind is not listed in kinds (rollout.onlyKinds).
+func skipOtherKinds(logger logr.Logger, result *Result, kinds []string) {
+	allowed := make(map[Kind]struct{}, len(kinds))
+	for _, k := range kinds {
+		allowed[Kind(k)] = struct{}{}
+	}
+
+	kept := result.Queue[:0]
+	for _, w := range result.Queue {
+		if _, ok := allowed[w.Kind]; ok {
+			kept = append(kept, w)
+			continue
+		}
+
+		result.uncount(w.Kind)
+		result.Stats.SkippedByKind++
+	}
+
+	result.Queue = kept
+	if result.Stats.SkippedByKind > 0 {
+		logger.Info(fmt.Sprintf("Skipping %d workloads of kinds other than %v (rollout.onlyKinds)",
+			result.Stats.SkippedByKind, kinds))
+	}
+}
+
+// plannedCounts converts the plan stats into status counters.
+func (p *Plan) plannedCounts() *trv1alpha1.WorkloadCounts {
+	return &trv1alpha1.WorkloadCounts{
+		Deployments:     p.Stats.Deployments,
+		StatefulSets:    p.Stats.StatefulSets,
+		DaemonSets:      p.Stats.DaemonSets,
+		CustomResources: p.Stats.CustomResources,
+	}
+}
+
+// reportRemaining publishes the per-kind number of workloads not yet restarted.
+func reportRemaining(obj *trv1alpha1.LinkerdTrustRotation, planned, completed *trv1alpha1.WorkloadCounts) {
+	rotation := client.ObjectKeyFromObject(obj).String()
+	metrics.SetWorkloadsRemaining(rotation, string(KindDeployment), planned.Deployments-completed.Deployments)
+	metrics.SetWorkloadsRemaining(rotation, string(KindStatefulSet), planned.StatefulSets-completed.StatefulSets)
+	metrics.SetWorkloadsRemaining(rotation, string(KindDaemonSet), planned.DaemonSets-completed.DaemonSets)
+	metrics.SetWorkloadsRemaining(rotation, string(KindCR), planned.CustomResources-completed.CustomResources)
+}
+
+// ForgetRemaining drops the workloads-remaining gauges of a rotation that has no workloads left to restart.
+func ForgetRemaining(key types.NamespacedName) {
+	metrics.DeleteWorkloadsRemaining(key.String())
+}
+
+// ForgetRotationMetrics drops all per-rotation metrics of a deleted CR.
+func ForgetRotationMetrics(key types.NamespacedName) {
+	metrics.DeleteRotation(key.String())
+}
+
+// countKind increments the counter of the given kind.
+func countKind(c *trv1alpha1.WorkloadCounts, kind Kind) {
+	switch kind {
+	case KindDeployment:
+		c.Deployments++
+	case KindStatefulSet:
+		c.StatefulSets++
+	case KindDaemonSet:
+		c.DaemonSets++
+	case KindCR:
+		c.CustomResources++
+	}
+}
+
+// uncount decrements the per-kind stats for an item removed from the queue.
+func (r *Result) uncount(kind Kind) {
+	switch kind {
+	case KindDeployment:
+		r.Stats.Deployments--
+	case KindStatefulSet:
+		r.Stats.StatefulSets--
+	case KindDaemonSet:
+		r.Stats.DaemonSets--
+	case KindCR:
+		r.Stats.CustomResources--
+	}
+}
+
+// ownerKind maps an OwnerReference kind onto the queue Kind: built-ins keep their name, anything else is a CR.
+func ownerKind(kind string) string {
+	switch Kind(kind) {
+	case KindDeployment, KindStatefulSet, KindDaemonSet:
+		return kind
+	default:
+		return string(KindCR)
+	}
+}
+
+func ownerKey(kind, namespace, name string) string {
+	return kind + "|" + namespace + "/" + name
+}
+
+// targetAnnotationValues returns the accepted target annotation values: the selector's value and values.
+func targetAnnotationValues(sel *trv1alpha1.TargetAnnotationSelector) []string {
+	return append([]string{sel.Value}, sel.Values...)
+}
+
+// crHasTemplateAnnotation checks common pod-template locations in CRDs for key with one of vals;
+// a lone empty value matches any value.
+func crHasTemplateAnnotation(u *unstructured.Unstructured, key string, vals []string) bool {
+	accepts := func(v string) bool { return (len(vals) == 1 && vals[0] == "") || slices.Contains(vals, v) }
+
 	// spec.template.metadata.annotations
 	if ann, ok := getAnno(u, "spec", "template", "metadata", "annotations"); ok {
-		if v, ok := ann[key]; ok && (val == "" || v == val) {
+		if v, ok := ann[key]; ok && accepts(v) {
 			return true
 		}
 	}
 
 	// spec.jobTemplate.spec.template.metadata.annotations (CronJob-like)
 	if ann, ok := getAnno(u, "spec", "jobTemplate", "spec", "template", "metadata", "annotations"); ok {
-		if v, ok := ann[key]; ok && (val == "" || v == val) {
+		if v, ok := ann[key]; ok && accepts(v) {
 			return true
 		}
 	}
 
 	// spec.podTemplate.metadata.annotations (some operators)
 	if ann, ok := getAnno(u, "spec", "podTemplate", "metadata", "annotations"); ok {
-		if v, ok := ann[key]; ok && (val == "" || v == val) {
+		if v, ok := ann[key]; ok && accepts(v) {
 			return true
 		}
 	}
@@ -264,7 +630,7 @@ func crHasTemplateAnnotation(u *unstructured.Unstructured, key, val string) bool
 		for _, it := range arr {
 			pm, _ := it.(map[string]any)
 			if ann, ok := getAnnoFromMap(pm, "metadata", "annotations"); ok {
-				if v, ok := ann[key]; ok && (val == "" || v == val) {
+				if v, ok := ann[key]; ok && accepts(v) {
 					return true
 				}
 			}
@@ -279,24 +645,68 @@ func getAnno(u *unstructured.Unstructured, path ...string) (map[string]string, b
 	return getAnnoFromMap(u.Object, path...)
 }
 
-func hasAnnotationOnTemplate(t corev1.PodTemplateSpec, key, val string) bool {
+// matchesAnnotation applies the selector's match location to the pod-template match result
+// and the workload's own metadata annotations.
+func matchesAnnotation(loc trv1alpha1.MatchLocation, onTemplate bool, annotations map[string]string, key string,
+	vals []string) bool {
+	v, ok := annotations[key]
+	onMetadata := ok && slices.Contains(vals, v)
+
+	switch loc {
+	case trv1alpha1.MatchLocationMetadata:
+		return onMetadata
+	case trv1alpha1.MatchLocationEither:
+		return onTemplate || onMetadata
+	default:
+		return onTemplate
+	}
+}
+
+func hasAnnotationOnTemplate(t corev1.PodTemplateSpec, key string, vals []string) bool {
 	if t.Annotations == nil {
 		return false
 	}
 
 	v, ok := t.Annotations[key]
-	return ok && v == val
+	return ok && slices.Contains(vals, v)
+}
+
+// strategyOverride returns the strategy set by the strategyKey annotation on the workload (or its pod template),
+// falling back to the scope strategy when it is absent or not a known strategy.
+func strategyOverride(logger logr.Logger, scopeStrategy string, obj metav1.Object, t corev1.PodTemplateSpec) string {
+	v, ok := obj.GetAnnotations()[strategyKey]
+	if !ok {
+		v, ok = t.Annotations[strategyKey]
+	}
+
+	switch {
+	case !ok || v == scopeStrategy:
+		return scopeStrategy
+	case v == Restart || v == Delete:
+		return v
+	default:
+		logger.Info(fmt.Sprintf("Ignoring invalid %s=%q on %s/%s; using %s", strategyKey, v,
+			obj.GetNamespace(), obj.GetName(), scopeStrategy))
+		return scopeStrategy
+	}
 }
 
 // RestartLinkerdDataPlane bumps pod-template annotation for each CP deployment
 // and waits until rollout is completed.
 func (m *ManageRollout) RestartLinkerdDataPlane(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) error {
-	ltrSpec := obj.Spec
-	result, err := m.SelectLinkerdDataPlane(ctx, obj)
+	plan, err := m.SelectLinkerdDataPlane(ctx, obj)
 	if err != nil {
 		return err
 	}
 
+	return m.ExecuteDataPlanePlan(ctx, obj, plan)
+}
+
+// ExecuteDataPlanePlan restarts the workloads of a pre-built plan in order, persisting progress
+// and the resume cursor in the CR status.
+func (m *ManageRollout) ExecuteDataPlanePlan(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, plan *Plan) error {
+	ltrSpec := obj.Spec
+
 	if err := m.Status.SetPhase(ctx, obj,
 		status.PhasePtr(trv1alpha1.PhaseRollingDataPlane),
 		status.ReasonPtr(trv1alpha1.ReasonDataPlaneBatchRestarting),
@@ -305,77 +715,252 @@ func (m *ManageRollout) RestartLinkerdDataPlane(ctx context.Context, obj *trv1al
 		return err
 	}
 
-	hash := planHash(result.Queue)
-	total := len(result.Queue)
-	start := 0
-	if cur := obj.Status.Cursor; cur != nil && cur.PlanHash == hash && cur.Next > 0 && cur.Next <= total {
-		start = cur.Next // resume
-	} else {
+	hash := plan.Hash
+	total := len(plan.Queue)
+	if ltrSpec.Rollout.AnnotatePlanHash {
+		if err := m.annotatePlanHash(ctx, obj, hash); err != nil {
+			return fmt.Errorf("annotate plan hash: %w", err)
+		}
+	}
+
+	// Items completed by a previous reconcile are skipped, also when the plan has grown since.
+	done := completedItems(obj.Status.Cursor, plan.Queue, hash)
+	doneRefs := make([]trv1alpha1.WorkRef, 0, total)
+	completed := &trv1alpha1.WorkloadCounts{}
+	for _, w := range plan.Queue {
+		if _, ok := done[workRef(w)]; ok {
+			doneRefs = append(doneRefs, workRef(w))
+			countKind(completed, w.Kind)
+		}
+	}
+
+	// cursorNext is the number of leading queue items done, which completedItems treats as done by index.
+	// Done items can have gaps (a grown plan, Deployments deferred by rollout.ongoingRolloutPolicy=Skip),
+	// so it can be behind processed.
+	cursorNext := func() int {
+		set := make(map[trv1alpha1.WorkRef]struct{}, len(doneRefs))
+		for _, ref := range doneRefs {
+			set[ref] = struct{}{}
+		}
+
+		n := 0
+		for _, w := range plan.Queue {
+			if _, ok := set[workRef(w)]; !ok {
+				break
+			}
+			n++
+		}
+
+		return n
+	}
+
+	processed := len(doneRefs)
+	if cur := obj.Status.Cursor; cur == nil || cur.PlanHashVersion != planHashVersion || cur.PlanHash != hash || cur.Next != cursorNext() {
+		if cur != nil && cur.PlanHashVersion != planHashVersion && cur.Total > 0 {
+			m.Logger.Info(fmt.Sprintf("Plan hash scheme changed (version %d -> %d); resuming from the %d completed workloads",
+				cur.PlanHashVersion, planHashVersion, processed))
+		} else if processed > 0 {
+			m.Logger.Info(fmt.Sprintf("Data-plane plan changed; resuming with %d of %d workloads already restarted",
+				processed, total))
+		}
+
 		// init cursor
-		if err := m.Status.SetPlanHash(ctx, obj, nil, 0, total, hash); err != nil {
+		if err := m.Status.SetPlanHash(ctx, obj, nil, cursorNext(), total, hash, planHashVersion, doneRefs); err != nil {
 			return err
 		}
 	}
 
-	processed := start
 	if err := m.Status.SetProgress(ctx, obj, true, &processed, &total); err != nil {
 		return err
 	}
 
-	// helper to bump progress and persist
-	bumpProgress := func(done WorkItem) error {
-		processed++ // +1 per finished object
-		// update cursor: next index and last done
-		last := &trv1alpha1.WorkRef{
-			Kind:      string(done.Kind),
-			Namespace: getNamespace(done),
-			Name:      getName(done),
+	planned := plan.plannedCounts()
+	if err := m.Status.SetWorkloadCounts(ctx, obj, planned, completed); err != nil {
+		return err
+	}
+	reportRemaining(obj, planned, completed)
+
+	// last done item, kept in memory between throttled status writes
+	var last *trv1alpha1.WorkRef
+	persisted, persistedAt := processed, time.Now()
+
+	// persistProgress writes the cursor, per-kind counts and progress percent to the status.
+	persistProgress := func() error {
+		if processed == persisted {
+			return nil
 		}
-		if err := m.Status.SetPlanHash(ctx, obj, last, processed, total, hash); err != nil {
+
+		if err := m.Status.SetPlanHash(ctx, obj, last, cursorNext(), total, hash, planHashVersion, doneRefs); err != nil {
 			return err
 		}
 
+		if err := m.Status.SetWorkloadCounts(ctx, obj, nil, completed); err != nil {
+			return err
+		}
+
+		if err := m.Status.SetProgress(ctx, obj, true, &processed, &total); err != nil {
+			return err
+		}
+
+		persisted, persistedAt = processed, time.Now()
+		return nil
+	}
+
+	// helper to bump progress; status is persisted every progressPersistEvery items,
+	// every progressPersistInterval and always for the last item
+	bumpProgress := func(done WorkItem) error {
+		processed++ // +1 per finished object
+		// update cursor: completed count, completed set and last done
+		ref := workRef(done)
+		last = &ref
+		doneRefs = append(doneRefs, ref)
+		countKind(completed, done.Kind)
+		metrics.IncWorkloadsRolled(client.ObjectKeyFromObject(obj).String(), string(done.Kind))
+		reportRemaining(obj, planned, completed)
+
+		if ltrSpec.Rollout.AnnotateFailures {
+			if err := m.clearFailureAnnotation(ctx, done); err != nil {
+				m.Logger.Error(err, fmt.Sprintf("Failed to clear the failure annotation of %s %s/%s",
+					done.Kind, getNamespace(done), getName(done)))
+			}
+		}
+
 		m.Logger.Info(fmt.Sprintf("Current progress: %d/%d", processed, total))
-		return m.Status.SetProgress(ctx, obj, true, &processed, &total)
+		if processed == total || processed-persisted >= progressPersistEvery ||
+			time.Since(persistedAt) >= progressPersistInterval {
+			return persistProgress()
+		}
+
+		return nil
 	}
 
+	// recordFailure persists the failure for a retry and returns it as a *RolloutError tied to the item.
 	recordFailure := func(item WorkItem, cause error) error {
+		// flush the throttled cursor so the next reconcile resumes right after the last finished item
+		if err := persistProgress(); err != nil {
+			m.Logger.Error(err, "Failed to persist rollout progress")
+		}
+
 		// increment retry counter atomically using current status value
 		retries := 0
 		if obj.Status.Retries != nil {
 			retries = obj.Status.Retries.Count
 		}
 
-		last := &trv1alpha1.WorkRef{
-			Kind:      string(item.Kind),
-			Namespace: getNamespace(item),
-			Name:      getName(item),
+		last := workRef(item)
+		failure := workItemError(item, cause)
+		if failure.Kind == ErrorKindThrottled {
+			// API throttling says nothing about the workload; don't spend a retry on it
+			return failure
 		}
-		if err := m.Status.SetRetry(ctx, obj, last, retries+1, cause.Error()); err != nil {
+		if ltrSpec.Rollout.AnnotateFailures {
+			if err := m.annotateFailure(ctx, item, cause); err != nil {
+				m.Logger.Error(err, fmt.Sprintf("Failed to annotate the failed %s %s/%s",
+					item.Kind, getNamespace(item), getName(item)))
+			}
+		}
+
+		if err := m.Status.SetRetry(ctx, obj, &last, retries+1, failure.Error()); err != nil {
 			return err
 		}
 		// do NOT advance cursor; resume from the same item next reconcile
-		return cause
+		return failure
+	}
+
+	rotationAnnotations := rotationIDAnnotations(obj)
+	restartValue := restartAnnotationValue(obj)
+
+	// The canary prefix always gets the proxy check; the rest waits for the canary approval.
+	// With proxyCheckScope=Once the rest is checked mesh-wide after the rollout instead (RunMeshProxyCheck).
+	canary := canarySampleSize(ltrSpec.Rollout.CanarySample, total)
+	once := ltrSpec.Protection.ProxyCheckScope == trv1alpha1.ProxyCheckScopeOnce
+	q := plan.Queue
+	if canary > 0 || once {
+		q = slices.Clone(plan.Queue)
+		check, skip := true, false
+		for i := range q {
+			switch {
+			case i < canary:
+				q[i].RunProxyCheck = &check
+			case once:
+				q[i].RunProxyCheck = &skip
+			}
+		}
 	}
 
-	q := result.Queue
-	for i := start; i < len(q); i++ {
-		w := q[i]
+	budget, started, restarted := reconcileBudget(&ltrSpec.Rollout), time.Now(), 0
+	deferred := 0
+	for _, w := range q {
+		if _, ok := done[workRef(w)]; ok {
+			continue
+		}
+
+		// Yield the worker between workloads; at least one workload is restarted per reconcile.
+		if restarted > 0 && (time.Since(started) >= budget ||
+			(ltrSpec.Rollout.MaxWorkloadsPerReconcile > 0 && restarted >= ltrSpec.Rollout.MaxWorkloadsPerReconcile)) {
+			if err := persistProgress(); err != nil {
+				return err
+			}
+
+			m.Logger.Info(fmt.Sprintf("Reconcile budget used after %d workloads in %s; yielding at %d/%d",
+				restarted, time.Since(started).Round(time.Second), processed, total))
+			return ErrReconcileBudgetExhausted
+		}
+		restarted++
+
+		if canary > 0 && processed >= canary && ltrSpec.Rollout.CanaryApprovedRotation != obj.Status.RotationID {
+			if err := persistProgress(); err != nil {
+				return err
+			}
+
+			if err := m.Status.SetPhase(ctx, obj,
+				status.PhasePtr(trv1alpha1.PhaseVerifying),
+				status.ReasonPtr(trv1alpha1.ReasonCanaryAwaitingApproval),
+				status.StringPtr(fmt.Sprintf("Canary sample of %d/%d workloads restarted and checked; "+
+					"set rollout.canaryApprovedRotation to %q to continue", processed, total, obj.Status.RotationID)),
+			); err != nil {
+				return err
+			}
+
+			return ErrCanaryAwaitingApproval
+		}
+
+		timeout := workloadTimeout(&ltrSpec.Protection, desiredReplicas(w))
 
 		switch w.Kind {
 		case KindDaemonSet:
 			m.Logger.Info(fmt.Sprintf("Start linkerd data plane DaemonSet: %s/%s restarting",
 				getNamespace(w), getName(w)))
 
-			if err := m.bumpRestartAnnotation(ctx, w.Ds); err != nil {
+			if err := m.checkMinAvailable(ctx, w); err != nil {
 				return recordFailure(w, err)
 			}
 
-			if err := m.waitDaemonSetRolledOut(ctx, getNamespaced(w), rolloutPerLimit); err != nil {
+			if w.Strategy == Delete {
+				// Each wave is awaited until its pods are replaced, so there is no rollout to wait for.
+				if err := m.restartDaemonSetByDelete(ctx, w.Ds, ltrSpec.Rollout.DirectPodDelete, w.ReadyConditions,
+					workloadTimeout(&ltrSpec.Protection, 1)); err != nil {
+					return recordFailure(w, err)
+				}
+			} else {
+				if err := m.bumpRestartAnnotation(ctx, w.Ds, restartValue, rotationAnnotations); err != nil {
+					return recordFailure(w, err)
+				}
+
+				if err := m.waitDaemonSetRolledOut(ctx, getNamespaced(w), timeout); err != nil {
+					return recordFailure(w, err)
+				}
+			}
+
+			if err := m.waitStabilized(ctx, w, ltrSpec.Protection.StabilizationPeriod, timeout); err != nil {
 				return recordFailure(w, err)
 			}
 
-			if err := m.runProxyCheckIfEnabled(ctx, &ltrSpec, getNamespace(w), getName(w), rolloutPerLimit); err != nil {
+			if err := m.verifyPodAnnotation(ctx, obj, w); err != nil {
+				return recordFailure(w, err)
+			}
+
+			if err := m.runProxyCheckIfEnabled(ctx, obj, w, rolloutPerLimit); err != nil {
 				return recordFailure(w, err)
 			}
 
@@ -390,15 +975,43 @@ func (m *ManageRollout) RestartLinkerdDataPlane(ctx context.Context, obj *trv1al
 			m.Logger.Info(fmt.Sprintf("Start linkerd data plane Deployment: %s/%s restarting",
 				getNamespace(w), getName(w)))
 
-			if err := m.bumpRestartAnnotation(ctx, w.Dep); err != nil {
+			if skip, err := m.settleOngoingRollout(ctx, w, ltrSpec.Rollout.OngoingRolloutPolicy, timeout,
+				obj.Status.OngoingRolloutsDeferredSince); err != nil {
 				return recordFailure(w, err)
+			} else if skip {
+				deferred++
+				restarted-- // not restarted, so it doesn't count against the budget
+				continue
 			}
 
-			if err := m.waitDeploymentRolledOut(ctx, getNamespaced(w), rolloutPerLimit); err != nil {
+			if err := m.checkMinAvailable(ctx, w); err != nil {
 				return recordFailure(w, err)
 			}
 
-			if err := m.runProxyCheckIfEnabled(ctx, &ltrSpec, getNamespace(w), getName(w), rolloutPerLimit); err != nil {
+			if w.Strategy == Delete {
+				if err := m.restartDeploymentByDelete(ctx, w.Dep, ltrSpec.Rollout.DirectPodDelete, w.ReadyConditions,
+					workloadTimeout(&ltrSpec.Protection, 1)); err != nil {
+					return recordFailure(w, err)
+				}
+			} else {
+				if err := m.bumpRestartAnnotation(ctx, w.Dep, restartValue, rotationAnnotations); err != nil {
+					return recordFailure(w, err)
+				}
+			}
+
+			if err := m.waitDeploymentRolledOut(ctx, getNamespaced(w), timeout); err != nil {
+				return recordFailure(w, err)
+			}
+
+			if err := m.waitStabilized(ctx, w, ltrSpec.Protection.StabilizationPeriod, timeout); err != nil {
+				return recordFailure(w, err)
+			}
+
+			if err := m.verifyPodAnnotation(ctx, obj, w); err != nil {
+				return recordFailure(w, err)
+			}
+
+			if err := m.runProxyCheckIfEnabled(ctx, obj, w, rolloutPerLimit); err != nil {
 				return recordFailure(w, err)
 			}
 
@@ -414,19 +1027,29 @@ func (m *ManageRollout) RestartLinkerdDataPlane(ctx context.Context, obj *trv1al
 				getNamespace(w), getName(w)))
 
 			if len(w.BumpAnnotationKey) == 0 || len(w.BumpAnnotationValue) == 0 {
-				return recordFailure(w, fmt.Errorf("key, value is required for custom resources %s", w.CR.GetKind()))
+				return recordFailure(w, newError(ErrorKindMisconfigured, "key, value is required for custom resources %s", w.CR.GetKind()))
+			}
+
+			if err := m.bumpAnnotations(ctx, w.CR,
+				managed.MergeLabels(rotationAnnotations, map[string]string{w.BumpAnnotationKey: w.BumpAnnotationValue})); err != nil {
+				return recordFailure(w, err)
 			}
 
-			if err := m.bumpAnnotationGeneric(ctx, w.CR, w.BumpAnnotationKey, w.BumpAnnotationValue); err != nil {
+			ready, err := crReadiness(w.ReadyExpression)
+			if err != nil {
 				return recordFailure(w, err)
 			}
 
 			if err := m.waitCRByAnnotationAndStatus(ctx, getNamespaced(w), w.CR, w.BumpAnnotationKey,
-				true, rolloutPerLimit); err != nil {
+				true, ready, timeout); err != nil {
 				return recordFailure(w, err)
 			}
 
-			if err := m.runProxyCheckIfEnabled(ctx, &ltrSpec, getNamespace(w), getName(w), rolloutPerLimit); err != nil {
+			if err := m.waitStabilized(ctx, w, ltrSpec.Protection.StabilizationPeriod, timeout); err != nil {
+				return recordFailure(w, err)
+			}
+
+			if err := m.runProxyCheckIfEnabled(ctx, obj, w, rolloutPerLimit); err != nil {
 				return recordFailure(w, err)
 			}
 
@@ -441,23 +1064,36 @@ func (m *ManageRollout) RestartLinkerdDataPlane(ctx context.Context, obj *trv1al
 			m.Logger.Info(fmt.Sprintf("Start linkerd data plane StatefulSet: %s/%s restarting",
 				getNamespace(w), getName(w)))
 
+			if err := m.checkMinAvailable(ctx, w); err != nil {
+				return recordFailure(w, err)
+			}
+
 			if w.Strategy == Restart {
-				if err := m.bumpRestartAnnotation(ctx, w.Sts); err != nil {
+				if err := m.bumpRestartAnnotation(ctx, w.Sts, restartValue, rotationAnnotations); err != nil {
 					return recordFailure(w, err)
 				}
 
-				if err := m.waitStatefulSetRolledOut(ctx, getNamespaced(w), rolloutPerLimit); err != nil {
+				if err := m.waitStatefulSetRolledOut(ctx, getNamespaced(w), timeout); err != nil {
 					return recordFailure(w, err)
 				}
 			}
 
 			if w.Strategy == Delete {
-				if err := m.restartStatefulSetByDelete(ctx, w.Sts, rolloutPerLimit); err != nil {
+				if err := m.restartStatefulSetByDelete(ctx, w.Sts, w.PreDeleteHook, ltrSpec.Rollout.DirectPodDelete,
+					w.ReadyConditions, workloadTimeout(&ltrSpec.Protection, 1)); err != nil {
 					return recordFailure(w, err)
 				}
 			}
 
-			if err := m.runProxyCheckIfEnabled(ctx, &ltrSpec, getNamespace(w), getName(w), rolloutPerLimit); err != nil {
+			if err := m.waitStabilized(ctx, w, ltrSpec.Protection.StabilizationPeriod, timeout); err != nil {
+				return recordFailure(w, err)
+			}
+
+			if err := m.verifyPodAnnotation(ctx, obj, w); err != nil {
+				return recordFailure(w, err)
+			}
+
+			if err := m.runProxyCheckIfEnabled(ctx, obj, w, rolloutPerLimit); err != nil {
 				return recordFailure(w, err)
 			}
 
@@ -470,10 +1106,46 @@ func (m *ManageRollout) RestartLinkerdDataPlane(ctx context.Context, obj *trv1al
 		}
 	}
 
+	if deferred > 0 {
+		if err := persistProgress(); err != nil {
+			return err
+		}
+
+		if obj.Status.OngoingRolloutsDeferredSince == nil {
+			now := metav1.NewTime(time.Now().UTC())
+			if err := m.Status.SetOngoingRolloutsDeferredSince(ctx, obj, &now); err != nil {
+				return err
+			}
+		}
+
+		if err := m.Status.SetPhase(ctx, obj,
+			status.PhasePtr(trv1alpha1.PhaseRollingDataPlane),
+			status.ReasonPtr(trv1alpha1.ReasonOngoingRolloutsDeferred),
+			status.StringPtr(fmt.Sprintf("%d Deployments were mid-rollout and deferred; %d/%d workloads restarted",
+				deferred, processed, total)),
+		); err != nil {
+			return err
+		}
+
+		return ErrOngoingRolloutsDeferred
+	}
+
+	if obj.Status.OngoingRolloutsDeferredSince != nil {
+		if err := m.Status.SetOngoingRolloutsDeferredSince(ctx, obj, nil); err != nil {
+			return err
+		}
+	}
+
+	reason, msg := trv1alpha1.ReasonDataPlaneThresholdReached, "Finished restarted Linkerd data plane"
+	if total == 0 {
+		// An empty match during an overlap is almost always a selector misconfiguration.
+		reason, msg = trv1alpha1.ReasonNoWorkloadsMatched, NoWorkloadsMatchedMessage
+	}
+
 	if err := m.Status.SetPhase(ctx, obj,
 		status.PhasePtr(trv1alpha1.PhaseRollingDataPlane),
-		status.ReasonPtr(trv1alpha1.ReasonDataPlaneThresholdReached),
-		status.StringPtr("Finished restarted Linkerd data plane"),
+		status.ReasonPtr(reason),
+		status.StringPtr(msg),
 	); err != nil {
 		return err
 	}
@@ -482,27 +1154,83 @@ func (m *ManageRollout) RestartLinkerdDataPlane(ctx context.Context, obj *trv1al
 		return err
 	}
 
-	return m.Status.SetPlanHash(ctx, obj, nil, 0, total, hash)
+	return m.Status.SetPlanHash(ctx, obj, nil, 0, total, hash, planHashVersion, nil)
+}
+
+// reconcileBudget returns rollout.reconcileBudget, falling back to the default.
+func reconcileBudget(spec *trv1alpha1.RolloutSpec) time.Duration {
+	if spec.ReconcileBudget == nil || spec.ReconcileBudget.Duration <= 0 {
+		return defaultReconcileBudget
+	}
+
+	return spec.ReconcileBudget.Duration
+}
+
+// canarySampleSize resolves rollout.canarySample against the queue length (percentages round up);
+// 0 means no canary.
+func canarySampleSize(sample *intstr.IntOrString, total int) int {
+	if sample == nil || total == 0 {
+		return 0
+	}
+
+	n, err := intstr.GetScaledValueFromIntOrPercent(sample, total, true)
+	if err != nil || n <= 0 {
+		return 0
+	}
+
+	return min(n, total)
+}
+
+// completedItems returns the queue items already restarted in the current rotation: the recorded
+// completed set and, for an unchanged plan hashed with the current scheme, everything before the cursor index.
+func completedItems(cur *trv1alpha1.RolloutCursor, queue []WorkItem, hash string) map[trv1alpha1.WorkRef]struct{} {
+	done := map[trv1alpha1.WorkRef]struct{}{}
+	if cur == nil {
+		return done
+	}
+
+	for _, ref := range cur.Completed {
+		done[ref] = struct{}{}
+	}
+
+	if cur.PlanHashVersion == planHashVersion && cur.PlanHash == hash && cur.Next > 0 && cur.Next <= len(queue) {
+		for _, w := range queue[:cur.Next] {
+			done[workRef(w)] = struct{}{}
+		}
+	}
+
+	return done
 }
 
 // runProxyCheckIfEnabled runs `linkerd check --proxy` for the given workload
-// only if Safety.LinkerdCheckProxy is enabled.
+// only if Protection.RunLinkerdCheckProxy (or the scope override) is enabled.
 func (m *ManageRollout) runProxyCheckIfEnabled(
 	ctx context.Context,
-	spec *trv1alpha1.LinkerdTrustRotationSpec,
-	targetNS, targetName string,
+	obj *trv1alpha1.LinkerdTrustRotation,
+	w WorkItem,
 	timeout time.Duration,
 ) error {
-	if !spec.Protection.RunLinkerdCheckProxy {
+	spec := &obj.Spec
+	if !proxyCheckEnabled(spec, w) {
 		return nil
 	}
 
-	return m.runLinkerdCheckJob(ctx, NewCheckProxyOptions(
+	return m.runLinkerdCheck(ctx, spec.Protection.CheckMode, NewCheckProxyOptions(
 		false,
 		spec.Protection.LinkerdCheckProxyImage,
-		targetNS,
+		getNamespace(w),
 		spec.Linkerd.Namespace,
-		targetName,
+		getName(w),
 		timeout,
+		obj,
 	))
 }
+
+// proxyCheckEnabled resolves the effective proxy-check flag: the scope override wins over the global one.
+func proxyCheckEnabled(spec *trv1alpha1.LinkerdTrustRotationSpec, w WorkItem) bool {
+	if w.RunProxyCheck != nil {
+		return *w.RunProxyCheck
+	}
+
+	return spec.Protection.RunLinkerdCheckProxy
+}
@@ -0,0 +1,511 @@
+package rollout
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+const (
+	testInjectKey   = "linkerd.io/inject"
+	testInjectValue = "enabled"
+	testOptOutKey   = "example.com/skip-rotation"
+)
+
+func injectedTemplate(inject bool) corev1.PodTemplateSpec {
+	t := corev1.PodTemplateSpec{}
+	if inject {
+		t.Annotations = map[string]string{testInjectKey: testInjectValue}
+	}
+
+	return t
+}
+
+func testDeployment(ns, name string, inject bool, owner *metav1.OwnerReference) *v1.Deployment {
+	d := &v1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+		Spec:       v1.DeploymentSpec{Template: injectedTemplate(inject)},
+	}
+	if owner != nil {
+		d.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+
+	return d
+}
+
+func testStatefulSet(ns, name string, inject bool) *v1.StatefulSet {
+	return &v1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+		Spec:       v1.StatefulSetSpec{Template: injectedTemplate(inject)},
+	}
+}
+
+func testDaemonSet(ns, name string, inject bool) *v1.DaemonSet {
+	return &v1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+		Spec:       v1.DaemonSetSpec{Template: injectedTemplate(inject)},
+	}
+}
+
+func testLTR(skipOwned bool, targets ...trv1alpha1.TargetScope) *trv1alpha1.LinkerdTrustRotation {
+	return &trv1alpha1.LinkerdTrustRotation{
+		Spec: trv1alpha1.LinkerdTrustRotationSpec{
+			Rollout: trv1alpha1.RolloutSpec{
+				TargetAnnotationSelector: trv1alpha1.TargetAnnotationSelector{
+					Key:     testInjectKey,
+					Value:   testInjectValue,
+					Targets: targets,
+				},
+				SkipOwnedWorkloads: skipOwned,
+			},
+		},
+	}
+}
+
+func withMatchLocation(obj *trv1alpha1.LinkerdTrustRotation, loc trv1alpha1.MatchLocation) *trv1alpha1.LinkerdTrustRotation {
+	obj.Spec.Rollout.TargetAnnotationSelector.MatchLocation = loc
+	return obj
+}
+
+func withNamespaceOptOut(obj *trv1alpha1.LinkerdTrustRotation) *trv1alpha1.LinkerdTrustRotation {
+	obj.Spec.Rollout.NamespaceOptOutKey = testOptOutKey
+	return obj
+}
+
+func queueNames(q []WorkItem) []string {
+	out := make([]string, 0, len(q))
+	for _, w := range q {
+		out = append(out, string(w.Kind)+":"+getNamespace(w)+"/"+getName(w))
+	}
+
+	return out
+}
+
+func TestBuildDataPlanePlan(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	controller := true
+	parentRef := &metav1.OwnerReference{
+		APIVersion: "apps/v1", Kind: "Deployment", Name: "parent", UID: "parent-uid", Controller: &controller,
+	}
+
+	marked := testDeployment("meta", "marked", false, nil)
+	marked.Annotations = map[string]string{testInjectKey: testInjectValue}
+
+	var zero int32
+	stoppedDep := testDeployment("idle", "stopped", true, nil)
+	stoppedDep.Spec.Replicas = &zero
+	stoppedSts := testStatefulSet("idle", "paused", true)
+	stoppedSts.Spec.Replicas = &zero
+
+	objects := []client.Object{
+		marked,
+		testDeployment("meta", "tpl", true, nil),
+		testDeployment("apps", "web", true, nil),
+		testDeployment("apps", "plain", false, nil),
+		testDeployment("apps", "parent", true, nil),
+		testDeployment("apps", "child", true, parentRef),
+		testDeployment("other", "api", true, nil),
+		testStatefulSet("apps", "zk", true),
+		testStatefulSet("apps", "db", true),
+		testDaemonSet("apps", "agent", true),
+		stoppedDep,
+		stoppedSts,
+		testDeployment("idle", "running", true, nil),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "meta", Labels: map[string]string{testOptOutKey: "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other", Annotations: map[string]string{testOptOutKey: "false"}}},
+	}
+
+	tests := []struct {
+		name    string
+		obj     *trv1alpha1.LinkerdTrustRotation
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "follows targets order and filters by annotation and namespace",
+			obj: testLTR(false,
+				trv1alpha1.TargetScope{KindType: string(KindDaemonSet), AllowedNamespaces: []string{"apps"}},
+				trv1alpha1.TargetScope{KindType: string(KindStatefulSet), AllowedNamespaces: []string{"apps"}},
+			),
+			want: []string{
+				"DaemonSet:apps/agent",
+				"StatefulSet:apps/db",
+				"StatefulSet:apps/zk",
+			},
+		},
+		{
+			name: "keeps owned workloads by default",
+			obj: testLTR(false,
+				trv1alpha1.TargetScope{KindType: string(KindDeployment), AllowedNamespaces: []string{"apps"}},
+			),
+			want: []string{
+				"Deployment:apps/child",
+				"Deployment:apps/parent",
+				"Deployment:apps/web",
+			},
+		},
+		{
+			name: "skips workloads owned by a selected workload",
+			obj: testLTR(true,
+				trv1alpha1.TargetScope{KindType: string(KindDeployment), AllowedNamespaces: []string{"apps", "other"}},
+			),
+			want: []string{
+				"Deployment:apps/parent",
+				"Deployment:apps/web",
+				"Deployment:other/api",
+			},
+		},
+		{
+			name: "matches the pod template by default",
+			obj: testLTR(false,
+				trv1alpha1.TargetScope{KindType: string(KindDeployment), AllowedNamespaces: []string{"meta"}},
+			),
+			want: []string{"Deployment:meta/tpl"},
+		},
+		{
+			name: "matches workload metadata",
+			obj: withMatchLocation(testLTR(false,
+				trv1alpha1.TargetScope{KindType: string(KindDeployment), AllowedNamespaces: []string{"meta"}},
+			), trv1alpha1.MatchLocationMetadata),
+			want: []string{"Deployment:meta/marked"},
+		},
+		{
+			name: "matches either location",
+			obj: withMatchLocation(testLTR(false,
+				trv1alpha1.TargetScope{KindType: string(KindDeployment), AllowedNamespaces: []string{"meta"}},
+			), trv1alpha1.MatchLocationEither),
+			want: []string{"Deployment:meta/marked", "Deployment:meta/tpl"},
+		},
+		{
+			name: "requires allowed namespaces",
+			obj: testLTR(false,
+				trv1alpha1.TargetScope{KindType: string(KindDeployment)},
+			),
+			wantErr: true,
+		},
+		{
+			name: "skips workloads scaled to zero",
+			obj: testLTR(false,
+				trv1alpha1.TargetScope{KindType: string(KindDeployment), AllowedNamespaces: []string{"idle"}},
+				trv1alpha1.TargetScope{KindType: string(KindStatefulSet), AllowedNamespaces: []string{"idle"}},
+			),
+			want: []string{"Deployment:idle/running"},
+		},
+		{
+			name: "skips opted-out namespaces",
+			obj: withNamespaceOptOut(testLTR(false,
+				trv1alpha1.TargetScope{KindType: string(KindDeployment), AllowedNamespaces: []string{"meta", "other"}},
+			)),
+			want: []string{"Deployment:other/api"},
+		},
+		{
+			name: "rejects unsupported kinds",
+			obj: testLTR(false,
+				trv1alpha1.TargetScope{KindType: "Job", AllowedNamespaces: []string{"apps"}},
+			),
+			wantErr: true,
+		},
+		{
+			name: "rejects rolloutDelete for custom resources",
+			obj: testLTR(false,
+				trv1alpha1.TargetScope{KindType: string(KindCR), AllowedNamespaces: []string{"apps"},
+					RolloutStrategy: Delete},
+			),
+			wantErr: true,
+		},
+		{
+			name: "accepts rolloutDelete for DaemonSets",
+			obj: testLTR(false,
+				trv1alpha1.TargetScope{KindType: string(KindDaemonSet), AllowedNamespaces: []string{"apps"},
+					RolloutStrategy: Delete},
+			),
+			want: []string{"DaemonSet:apps/agent"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+			plan, err := BuildDataPlanePlan(context.Background(), c, logr.Discard(), tt.obj)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("BuildDataPlanePlan() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildDataPlanePlan() error = %v", err)
+			}
+
+			got := queueNames(plan.Queue)
+			if len(got) != len(tt.want) {
+				t.Fatalf("queue = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("queue = %v, want %v", got, tt.want)
+				}
+			}
+
+			if plan.Hash != planHash(plan.Queue) {
+				t.Errorf("Hash = %q, want %q", plan.Hash, planHash(plan.Queue))
+			}
+
+			stats := plan.Stats.Deployments + plan.Stats.StatefulSets + plan.Stats.DaemonSets + plan.Stats.CustomResources
+			if stats != len(plan.Queue) {
+				t.Errorf("stats total = %d, want %d", stats, len(plan.Queue))
+			}
+		})
+	}
+}
+
+func TestCompletedItems(t *testing.T) {
+	queue := []WorkItem{
+		{WorkItemDryRun: &WorkItemDryRun{Kind: KindDeployment}, Dep: testDeployment("a", "web", true, nil)},
+		{WorkItemDryRun: &WorkItemDryRun{Kind: KindDeployment}, Dep: testDeployment("a", "new", true, nil)},
+		{WorkItemDryRun: &WorkItemDryRun{Kind: KindStatefulSet}, Sts: testStatefulSet("b", "db", true)},
+	}
+	web, db := workRef(queue[0]), workRef(queue[2])
+	gone := trv1alpha1.WorkRef{Kind: string(KindDaemonSet), Namespace: "c", Name: "agent"}
+
+	tests := []struct {
+		name string
+		cur  *trv1alpha1.RolloutCursor
+		want []trv1alpha1.WorkRef
+	}{
+		{name: "no cursor"},
+		{
+			name: "unchanged plan resumes by index",
+			cur:  &trv1alpha1.RolloutCursor{PlanHash: "h", PlanHashVersion: planHashVersion, Next: 1, Total: 3},
+			want: []trv1alpha1.WorkRef{web},
+		},
+		{
+			name: "older hash scheme resumes by completed set only",
+			cur: &trv1alpha1.RolloutCursor{PlanHash: "h", PlanHashVersion: planHashVersion - 1, Next: 2, Total: 3,
+				Completed: []trv1alpha1.WorkRef{db}},
+			want: []trv1alpha1.WorkRef{db},
+		},
+		{
+			name: "grown plan keeps completed set",
+			cur: &trv1alpha1.RolloutCursor{PlanHash: "old", Next: 3, Total: 3,
+				Completed: []trv1alpha1.WorkRef{web, gone, db}},
+			want: []trv1alpha1.WorkRef{web, db},
+		},
+		{
+			name: "changed plan without completed set restarts",
+			cur:  &trv1alpha1.RolloutCursor{PlanHash: "old", Next: 2, Total: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := completedItems(tt.cur, queue, "h")
+
+			var got []trv1alpha1.WorkRef
+			for _, w := range queue {
+				if _, ok := done[workRef(w)]; ok {
+					got = append(got, workRef(w))
+				}
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("completed = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("completed = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSortWorkItemsByTier(t *testing.T) {
+	item := func(name, tier string) WorkItem {
+		dep := testDeployment("app", name, true, nil)
+		if len(tier) > 0 {
+			dep.Labels = map[string]string{tierKey: tier}
+		}
+
+		return WorkItem{WorkItemDryRun: &WorkItemDryRun{Kind: KindDeployment, Namespace: "app", Name: name}, Dep: dep}
+	}
+	queue := func() []WorkItem {
+		return []WorkItem{item("frontend", "2"), item("untiered", ""), item("db", "0"), item("cache", "0"),
+			item("api", "1"), item("broken", "x")}
+	}
+
+	tests := []struct {
+		order trv1alpha1.TierOrder
+		want  []string
+	}{
+		{order: trv1alpha1.TierOrderLeavesFirst, want: []string{"db", "cache", "api", "frontend", "untiered", "broken"}},
+		{order: trv1alpha1.TierOrderLeavesLast, want: []string{"frontend", "api", "db", "cache", "untiered", "broken"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			result := &Result{Queue: queue()}
+			sortWorkItemsByTier(logr.Discard(), result, tt.order, "")
+
+			var got []string
+			for _, w := range result.Queue {
+				got = append(got, w.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("queue = %v, want %v", got, tt.want)
+			}
+			if result.Queue[0].Tier == "" {
+				t.Errorf("Tier of %s not recorded for the dry run", result.Queue[0].Name)
+			}
+		})
+	}
+
+	asc, desc := &Result{Queue: queue()}, &Result{Queue: queue()}
+	sortWorkItemsByTier(logr.Discard(), asc, trv1alpha1.TierOrderLeavesFirst, "")
+	sortWorkItemsByTier(logr.Discard(), desc, trv1alpha1.TierOrderLeavesLast, "")
+	if planHash(asc.Queue) == planHash(desc.Queue) {
+		t.Error("plan hash does not reflect the tier order")
+	}
+}
+
+func TestStrategyOverride(t *testing.T) {
+	sts := &v1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "db"}}
+
+	if got := strategyOverride(logr.Discard(), Restart, sts, sts.Spec.Template); got != Restart {
+		t.Errorf("no annotation: strategy = %s, want %s", got, Restart)
+	}
+
+	sts.Spec.Template.Annotations = map[string]string{strategyKey: Delete}
+	if got := strategyOverride(logr.Discard(), Restart, sts, sts.Spec.Template); got != Delete {
+		t.Errorf("template annotation: strategy = %s, want %s", got, Delete)
+	}
+
+	sts.Annotations = map[string]string{strategyKey: Restart}
+	if got := strategyOverride(logr.Discard(), Delete, sts, sts.Spec.Template); got != Restart {
+		t.Errorf("workload annotation: strategy = %s, want it to win over the template", got)
+	}
+
+	sts.Annotations = map[string]string{strategyKey: "rolloutMagic"}
+	if got := strategyOverride(logr.Discard(), Restart, sts, sts.Spec.Template); got != Restart {
+		t.Errorf("invalid annotation: strategy = %s, want the scope strategy", got)
+	}
+
+	dep := WorkItem{WorkItemDryRun: &WorkItemDryRun{Kind: KindDeployment, Namespace: "app", Name: "web", Strategy: Restart},
+		Dep: testDeployment("app", "web", true, nil)}
+	deleted := dep
+	deleted.WorkItemDryRun = &WorkItemDryRun{Kind: KindDeployment, Namespace: "app", Name: "web", Strategy: Delete}
+	if planHash([]WorkItem{dep}) == planHash([]WorkItem{deleted}) {
+		t.Error("plan hash does not reflect the Deployment strategy")
+	}
+}
+
+func TestTargetAnnotationValues(t *testing.T) {
+	vals := targetAnnotationValues(&trv1alpha1.TargetAnnotationSelector{
+		Key: testInjectKey, Value: testInjectValue, Values: []string{"ingress"},
+	})
+	tpl := func(v string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{testInjectKey: v}}}
+	}
+	cr := func(v string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]any{}}
+		_ = unstructured.SetNestedStringMap(u.Object, map[string]string{testInjectKey: v},
+			"spec", "template", "metadata", "annotations")
+		return u
+	}
+
+	for v, want := range map[string]bool{testInjectValue: true, "ingress": true, "disabled": false} {
+		if got := hasAnnotationOnTemplate(tpl(v), testInjectKey, vals); got != want {
+			t.Errorf("hasAnnotationOnTemplate(%q) = %v, want %v", v, got, want)
+		}
+		if got := crHasTemplateAnnotation(cr(v), testInjectKey, vals); got != want {
+			t.Errorf("crHasTemplateAnnotation(%q) = %v, want %v", v, got, want)
+		}
+	}
+
+	if !crHasTemplateAnnotation(cr("anything"), testInjectKey, []string{""}) {
+		t.Error("an empty value no longer matches any value on CRs")
+	}
+}
+
+func TestSkipOtherKinds(t *testing.T) {
+	result := &Result{Queue: []WorkItem{
+		{WorkItemDryRun: &WorkItemDryRun{Kind: KindDeployment, Namespace: "app", Name: "web"}},
+		{WorkItemDryRun: &WorkItemDryRun{Kind: KindStatefulSet, Namespace: "app", Name: "db"}},
+		{WorkItemDryRun: &WorkItemDryRun{Kind: KindDaemonSet, Namespace: "app", Name: "agent"}},
+	}}
+	result.Stats.Deployments, result.Stats.StatefulSets, result.Stats.DaemonSets = 1, 1, 1
+
+	skipOtherKinds(logr.Discard(), result, []string{string(KindDeployment)})
+
+	if len(result.Queue) != 1 || result.Queue[0].Name != "web" {
+		t.Fatalf("queue = %v, want only the Deployment", result.Queue)
+	}
+	if result.Stats.SkippedByKind != 2 || result.Stats.StatefulSets != 0 || result.Stats.DaemonSets != 0 {
+		t.Errorf("stats = %+v, want 2 skipped and no StatefulSets/DaemonSets", result.Stats)
+	}
+}
+
+func TestScopeOrder(t *testing.T) {
+	targets := []trv1alpha1.TargetScope{
+		{KindType: string(KindStatefulSet), Priority: 2},
+		{KindType: string(KindDeployment)},
+		{KindType: string(KindDaemonSet), Priority: -1},
+		{KindType: string(KindCR)},
+	}
+
+	if got, want := scopeOrder(targets), []int{2, 1, 3, 0}; !slices.Equal(got, want) {
+		t.Errorf("scopeOrder = %v, want %v", got, want)
+	}
+
+	item := func(priority int) WorkItem {
+		return WorkItem{WorkItemDryRun: &WorkItemDryRun{Kind: KindDeployment, Namespace: "app", Name: "web", Priority: priority},
+			Dep: testDeployment("app", "web", true, nil)}
+	}
+	if planHash([]WorkItem{item(0)}) == planHash([]WorkItem{item(1)}) {
+		t.Error("plan hash does not reflect the scope priority")
+	}
+}
+
+func TestCanarySampleSize(t *testing.T) {
+	count, percent, zero := intstr.FromInt32(2), intstr.FromString("5%"), intstr.FromInt32(0)
+
+	tests := []struct {
+		name   string
+		sample *intstr.IntOrString
+		total  int
+		want   int
+	}{
+		{name: "unset", sample: nil, total: 10, want: 0},
+		{name: "count", sample: &count, total: 10, want: 2},
+		{name: "count above total", sample: &count, total: 1, want: 1},
+		{name: "percent rounds up", sample: &percent, total: 30, want: 2},
+		{name: "zero", sample: &zero, total: 10, want: 0},
+		{name: "empty queue", sample: &count, total: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canarySampleSize(tt.sample, tt.total); got != tt.want {
+				t.Errorf("canarySampleSize() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultMaxUnavailable matches the Kubernetes default for RollingUpdate Deployments.
+var defaultMaxUnavailable = intstr.FromString("25%")
+
+// restartDeploymentByDelete restarts a Deployment by deleting its pods in batches and letting the
+// ReplicaSet recreate them, so no new ReplicaSet revision is created. Batches are sized by the
+// Deployment's maxUnavailable and by the disruptions its PodDisruptionBudgets currently allow.
+// Pods are evicted (honoring PDBs) unless direct is set. Each batch must be fully replaced and
+// ready (see podReadyFor) within perBatchTimeout.
+func (m *ManageRollout) restartDeploymentByDelete(ctx context.Context, dep *v1.Deployment, direct bool,
+	readyConditions []string, perBatchTimeout time.Duration) error {
+	selector, err := metav1.LabelSelectorAsSelector(dep.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("build selector for Deployment %s/%s: %w", dep.Namespace, dep.Name, err)
+	}
+
+	replicas := desiredDeploymentReplicas(dep)
+	maxBatch := deploymentMaxUnavailable(dep, replicas)
+
+	return m.restartPodsByDelete(ctx, podBatchRestart{
+		kind:      KindDeployment,
+		namespace: dep.Namespace,
+		name:      dep.Name,
+		selector:  selector,
+		replicas:  replicas,
+		batchSize: func(ctx context.Context) (int, error) {
+			return m.waitDisruptionBudget(ctx, dep, maxBatch, perBatchTimeout)
+		},
+		ready: func(p *corev1.Pod) bool { return podReadyFor(p, readyConditions) },
+	}, direct, perBatchTimeout)
+}
+
+// deploymentMaxUnavailable resolves the Deployment's maxUnavailable against its replicas (at least 1).
+func deploymentMaxUnavailable(dep *v1.Deployment, replicas int32) int {
+	maxUnavailable := defaultMaxUnavailable
+	if ru := dep.Spec.Strategy.RollingUpdate; ru != nil && ru.MaxUnavailable != nil {
+		maxUnavailable = *ru.MaxUnavailable
+	}
+
+	n, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, int(replicas), false)
+	if err != nil || n < 1 {
+		return 1
+	}
+
+	return n
+}
+
+// waitDisruptionBudget returns how many pods may be deleted now: maxBatch capped by the lowest
+// disruptionsAllowed of the PodDisruptionBudgets selecting the Deployment's pods.
+// It waits while a PDB allows no disruptions at all.
+func (m *ManageRollout) waitDisruptionBudget(ctx context.Context, dep *v1.Deployment, maxBatch int,
+	timeout time.Duration) (int, error) {
+	ticker := time.NewTicker(m.pollInterval())
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(timeout)
+	podLabels := labels.Set(dep.Spec.Template.Labels)
+
+	for {
+		var pdbs policyv1.PodDisruptionBudgetList
+		if err := m.Client.List(ctx, &pdbs, client.InNamespace(dep.Namespace)); err != nil {
+			return 0, fmt.Errorf("list PodDisruptionBudgets: %w", err)
+		}
+
+		allowed := maxBatch
+		for _, pdb := range pdbs.Items {
+			selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil || selector.Empty() || !selector.Matches(podLabels) {
+				continue
+			}
+			allowed = min(allowed, int(pdb.Status.DisruptionsAllowed))
+		}
+
+		if allowed > 0 {
+			return allowed, nil
+		}
+
+		if time.Now().After(deadline) {
+			return 0, newError(ErrorKindTimeout, "timeout waiting for PodDisruptionBudget to allow disruptions")
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
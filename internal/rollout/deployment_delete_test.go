@@ -0,0 +1,220 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestDeploymentMaxUnavailable(t *testing.T) {
+	withMaxUnavailable := func(v intstr.IntOrString) *v1.Deployment {
+		return &v1.Deployment{Spec: v1.DeploymentSpec{Strategy: v1.DeploymentStrategy{
+			RollingUpdate: &v1.RollingUpdateDeployment{MaxUnavailable: &v},
+		}}}
+	}
+
+	tests := []struct {
+		name     string
+		dep      *v1.Deployment
+		replicas int32
+		want     int
+	}{
+		{name: "default 25% of 8", dep: &v1.Deployment{}, replicas: 8, want: 2},
+		{name: "default rounds down to at least 1", dep: &v1.Deployment{}, replicas: 2, want: 1},
+		{name: "absolute value", dep: withMaxUnavailable(intstr.FromInt32(3)), replicas: 10, want: 3},
+		{name: "percent", dep: withMaxUnavailable(intstr.FromString("50%")), replicas: 10, want: 5},
+		{name: "zero is raised to 1", dep: withMaxUnavailable(intstr.FromInt32(0)), replicas: 10, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deploymentMaxUnavailable(tt.dep, tt.replicas); got != tt.want {
+				t.Fatalf("deploymentMaxUnavailable() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// webLabels select the pods of the test Deployment "apps/web".
+var webLabels = map[string]string{"app": "web"}
+
+// webDeployment returns the Deployment "apps/web" with the given replicas and maxUnavailable.
+func webDeployment(replicas int32, maxUnavailable intstr.IntOrString) *v1.Deployment {
+	return &v1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "web"},
+		Spec: v1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: webLabels},
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: webLabels}},
+			Strategy: v1.DeploymentStrategy{
+				RollingUpdate: &v1.RollingUpdateDeployment{MaxUnavailable: &maxUnavailable},
+			},
+		},
+	}
+}
+
+// webPod returns a running pod of "apps/web" created age ago, ready if ready is set.
+func webPod(name string, age time.Duration, ready bool) *corev1.Pod {
+	cond := corev1.ConditionFalse
+	if ready {
+		cond = corev1.ConditionTrue
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "apps", Name: name, Labels: webLabels, UID: types.UID(name),
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: cond}},
+		},
+	}
+}
+
+// webPDB returns a PodDisruptionBudget selecting sel that currently allows the given disruptions.
+func webPDB(name string, sel map[string]string, allowed int32) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: name},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: sel}},
+		Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: allowed},
+	}
+}
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	return scheme
+}
+
+func TestWaitDisruptionBudget(t *testing.T) {
+	tests := []struct {
+		name    string
+		pdbs    []client.Object
+		raiseTo int32 // if set, the blocking PDB is raised to this many disruptions while waiting
+		want    int
+		wantErr ErrorKind
+	}{
+		{name: "no PDB", want: 3},
+		{name: "matching PDB caps the batch", pdbs: []client.Object{webPDB("web", webLabels, 1)}, want: 1},
+		{name: "PDB above maxBatch", pdbs: []client.Object{webPDB("web", webLabels, 5)}, want: 3},
+		{name: "lowest matching PDB wins", pdbs: []client.Object{
+			webPDB("a", webLabels, 2), webPDB("b", webLabels, 1),
+		}, want: 1},
+		{name: "other PDBs are ignored", pdbs: []client.Object{
+			webPDB("db", map[string]string{"app": "db"}, 0), webPDB("all", nil, 0),
+		}, want: 3},
+		{name: "waits while no disruptions are allowed", pdbs: []client.Object{webPDB("web", webLabels, 0)}, raiseTo: 2, want: 2},
+		{name: "times out while no disruptions are allowed", pdbs: []client.Object{webPDB("web", webLabels, 0)},
+			wantErr: ErrorKindTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := testScheme(t)
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.pdbs...).Build()
+			m := &ManageRollout{Client: c, Scheme: scheme, Logger: logr.Discard(), PollInterval: 10 * time.Millisecond}
+
+			if tt.raiseTo > 0 {
+				go func() {
+					time.Sleep(50 * time.Millisecond)
+					pdb := &policyv1.PodDisruptionBudget{}
+					_ = c.Get(context.Background(), client.ObjectKey{Namespace: "apps", Name: "web"}, pdb)
+					pdb.Status.DisruptionsAllowed = tt.raiseTo
+					_ = c.Status().Update(context.Background(), pdb)
+				}()
+			}
+
+			got, err := m.waitDisruptionBudget(context.Background(), webDeployment(6, intstr.FromInt32(3)), 3, 300*time.Millisecond)
+			if tt.wantErr != "" {
+				if KindOf(err) != tt.wantErr {
+					t.Fatalf("waitDisruptionBudget() error = %v, want kind %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("waitDisruptionBudget() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("waitDisruptionBudget() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestartDeploymentByDelete(t *testing.T) {
+	tests := []struct {
+		name        string
+		pdbs        []client.Object
+		wantBatches []int
+	}{
+		{name: "batched by maxUnavailable", wantBatches: []int{2, 1}},
+		{name: "batched by PodDisruptionBudget", pdbs: []client.Object{webPDB("web", webLabels, 1)}, wantBatches: []int{1, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := testScheme(t)
+			dep := webDeployment(3, intstr.FromInt32(2))
+			objs := append([]client.Object{
+				dep, webPod("web-2", time.Minute, true), webPod("web-0", 3*time.Minute, true), webPod("web-1", 2*time.Minute, true),
+			}, tt.pdbs...)
+
+			// Play the ReplicaSet controller: every deleted pod is replaced by a ready one. A batch is the
+			// number of deletes between two pod lists of waitPodsReplaced.
+			var deletedOrder []string
+			var batches []int
+			inBatch, replacements := 0, 0
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					if err := c.Delete(ctx, obj, opts...); err != nil {
+						return err
+					}
+					deletedOrder = append(deletedOrder, obj.GetName())
+					inBatch++
+					replacements++
+					return c.Create(ctx, webPod(fmt.Sprintf("web-new-%d", replacements), 0, true))
+				},
+				List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					if _, ok := list.(*corev1.PodList); ok && inBatch > 0 {
+						batches = append(batches, inBatch)
+						inBatch = 0
+					}
+					return c.List(ctx, list, opts...)
+				},
+			}).Build()
+			m := &ManageRollout{Client: c, Scheme: scheme, Logger: logr.Discard(), PollInterval: 10 * time.Millisecond}
+
+			if err := m.restartDeploymentByDelete(context.Background(), dep, true, nil, time.Second); err != nil {
+				t.Fatalf("restartDeploymentByDelete() error = %v", err)
+			}
+
+			if want := []string{"web-0", "web-1", "web-2"}; !slices.Equal(deletedOrder, want) {
+				t.Errorf("deleted %v, want oldest first %v", deletedOrder, want)
+			}
+			if !slices.Equal(batches, tt.wantBatches) {
+				t.Errorf("batches %v, want %v", batches, tt.wantBatches)
+			}
+		})
+	}
+}
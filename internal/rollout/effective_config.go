@@ -0,0 +1,35 @@
+package rollout
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// EffectiveConfig resolves the settings the rollout applies for obj, filling in the same defaults
+// the rollout code uses when a field is unset.
+func EffectiveConfig(obj *trv1alpha1.LinkerdTrustRotation) *trv1alpha1.EffectiveConfig {
+	protection := &obj.Spec.Protection
+
+	checkMode := protection.CheckMode
+	if len(checkMode) == 0 {
+		checkMode = trv1alpha1.CheckModeJob
+	}
+
+	base, perReplica, maxTimeout := workloadTimeoutCoefficients(protection)
+
+	return &trv1alpha1.EffectiveConfig{
+		RestartAnnotationKey:      restartedAtKey,
+		CheckImage:                (&CheckProxyOptions{CLIImage: protection.LinkerdCheckProxyImage}).image(),
+		CheckJobNamespace:         checkJobNamespace(obj, obj.Spec.Linkerd.Namespace),
+		CheckMode:                 checkMode,
+		MaxConcurrentCheckJobs:    int32(maxConcurrentCheckJobs(obj)),
+		DefaultRolloutStrategy:    Restart,
+		WorkloadTimeoutBase:       metav1.Duration{Duration: base},
+		WorkloadTimeoutPerReplica: metav1.Duration{Duration: perReplica},
+		WorkloadTimeoutMax:        metav1.Duration{Duration: maxTimeout},
+		PreDeleteHookTimeout:      metav1.Duration{Duration: defaultPreDeleteHookTimeout},
+		ReconcileBudget:           metav1.Duration{Duration: reconcileBudget(&obj.Spec.Rollout)},
+		ControlPlaneRestartOrder:  controlPlaneRestartOrder(obj),
+	}
+}
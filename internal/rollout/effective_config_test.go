@@ -0,0 +1,38 @@
+package rollout
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func TestEffectiveConfig(t *testing.T) {
+	obj := &trv1alpha1.LinkerdTrustRotation{}
+	obj.Spec.Linkerd.Namespace = "linkerd"
+
+	got := EffectiveConfig(obj)
+	if got.CheckImage != defaultLinkerdCLIImage || got.CheckMode != trv1alpha1.CheckModeJob ||
+		got.MaxConcurrentCheckJobs != defaultMaxConcurrentCheckJobs || got.DefaultRolloutStrategy != Restart ||
+		got.RestartAnnotationKey != restartedAtKey || got.WorkloadTimeoutMax.Duration != defaultTimeoutMax ||
+		got.CheckJobNamespace != "linkerd" {
+		t.Errorf("EffectiveConfig() defaults = %+v", got)
+	}
+
+	obj.Spec.Protection = trv1alpha1.ProtectionSpec{
+		LinkerdCheckProxyImage: "registry.local/linkerd-cli:test",
+		CheckMode:              trv1alpha1.CheckModeEphemeralContainer,
+		MaxConcurrentCheckJobs: 5,
+		CheckJobNamespace:      "linkerd-checks",
+		WorkloadTimeout:        &trv1alpha1.WorkloadTimeoutSpec{Base: &metav1.Duration{Duration: time.Minute}},
+	}
+
+	got = EffectiveConfig(obj)
+	if got.CheckImage != "registry.local/linkerd-cli:test" || got.CheckMode != trv1alpha1.CheckModeEphemeralContainer ||
+		got.MaxConcurrentCheckJobs != 5 || got.WorkloadTimeoutBase.Duration != time.Minute ||
+		got.WorkloadTimeoutPerReplica.Duration != defaultTimeoutPerReplica || got.CheckJobNamespace != "linkerd-checks" {
+		t.Errorf("EffectiveConfig() overrides = %+v", got)
+	}
+}
@@ -0,0 +1,94 @@
+package rollout
+
+import (
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrorKind classifies rollout failures so callers can pick a status reason and a retry strategy.
+type ErrorKind string
+
+const (
+	// ErrorKindTimeout — a waiter ran out of time (rollout, readiness, pod replacement, check Job).
+	ErrorKindTimeout ErrorKind = "Timeout"
+	// ErrorKindMisconfigured — the spec or the workload setup can't work as is (e.g. OnDelete DaemonSet).
+	ErrorKindMisconfigured ErrorKind = "Misconfigured"
+	// ErrorKindCheckFailed — `linkerd check` or a post-rollout verification failed.
+	ErrorKindCheckFailed ErrorKind = "CheckFailed"
+	// ErrorKindDegraded — the workload was too unhealthy to be restarted.
+	ErrorKindDegraded ErrorKind = "Degraded"
+	// ErrorKindDisappeared — the workload was deleted while being rolled out.
+	ErrorKindDisappeared ErrorKind = "Disappeared"
+	// ErrorKindUnschedulable — a new pod can't be scheduled (e.g. insufficient CPU/memory).
+	ErrorKindUnschedulable ErrorKind = "Unschedulable"
+	// ErrorKindThrottled — the API server kept throttling requests (HTTP 429); not a workload failure.
+	ErrorKindThrottled ErrorKind = "Throttled"
+	// ErrorKindUnknown — anything else, typically API errors.
+	ErrorKindUnknown ErrorKind = "Unknown"
+)
+
+// ErrCRDisappeared is returned when a custom resource is deleted while its rollout is awaited.
+var ErrCRDisappeared = errors.New("custom resource disappeared during rollout")
+
+// ErrCanaryAwaitingApproval is returned when the canary sample is done and the rest of the
+// data plane waits for rollout.canaryApprovedRotation.
+var ErrCanaryAwaitingApproval = errors.New("canary sample awaiting approval")
+
+// ErrReconcileBudgetExhausted is returned when the data-plane rollout used up rollout.reconcileBudget or
+// rollout.maxWorkloadsPerReconcile; progress is persisted and the next reconcile resumes from the cursor.
+var ErrReconcileBudgetExhausted = errors.New("reconcile budget exhausted")
+
+// ErrOngoingRolloutsDeferred is returned when Deployments found mid-rollout were skipped
+// (rollout.ongoingRolloutPolicy=Skip); the rest is persisted and the next reconcile retries them.
+var ErrOngoingRolloutsDeferred = errors.New("deployments with ongoing rollouts deferred")
+
+// RolloutError is a classified rollout failure, optionally tied to the workload it happened on.
+type RolloutError struct {
+	Kind ErrorKind
+
+	// Workload is "<Kind> <namespace>/<name>" of the failed work item, if known.
+	Workload string
+
+	Err error
+}
+
+func (e *RolloutError) Error() string {
+	if len(e.Workload) > 0 {
+		return fmt.Sprintf("%s: %v", e.Workload, e.Err)
+	}
+
+	return e.Err.Error()
+}
+
+func (e *RolloutError) Unwrap() error { return e.Err }
+
+// newError returns a RolloutError of the given kind with a formatted message.
+func newError(kind ErrorKind, format string, args ...any) *RolloutError {
+	return &RolloutError{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// KindOf returns the kind of the first RolloutError in err's chain, ErrorKindThrottled for an
+// unclassified API throttling error, or ErrorKindUnknown.
+func KindOf(err error) ErrorKind {
+	var re *RolloutError
+	if errors.As(err, &re) && re.Kind != ErrorKindUnknown {
+		return re.Kind
+	}
+
+	if apierrors.IsTooManyRequests(err) {
+		return ErrorKindThrottled
+	}
+
+	return ErrorKindUnknown
+}
+
+// workItemError ties err to the work item, keeping the kind of an already classified error.
+func workItemError(w WorkItem, err error) *RolloutError {
+	return &RolloutError{
+		Kind:     KindOf(err),
+		Workload: fmt.Sprintf("%s %s/%s", w.Kind, getNamespace(w), getName(w)),
+		Err:      err,
+	}
+}
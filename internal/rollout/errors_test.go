@@ -0,0 +1,33 @@
+package rollout
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRolloutErrorKinds(t *testing.T) {
+	cr := &unstructured.Unstructured{}
+	cr.SetNamespace("kafka")
+	cr.SetName("main")
+	w := WorkItem{WorkItemDryRun: &WorkItemDryRun{Kind: KindCR, Namespace: "kafka", Name: "main"}, CR: cr}
+
+	disappeared := newError(ErrorKindDisappeared, "Kafka kafka/main: %w", ErrCRDisappeared)
+	failure := workItemError(w, fmt.Errorf("wait: %w", disappeared))
+
+	if got := KindOf(failure); got != ErrorKindDisappeared {
+		t.Fatalf("kind = %s, want %s", got, ErrorKindDisappeared)
+	}
+	if !errors.Is(failure, ErrCRDisappeared) {
+		t.Fatal("expected the sentinel to stay reachable through the wrapping")
+	}
+	if want := "CustomResource kafka/main: wait: Kafka kafka/main: " + ErrCRDisappeared.Error(); failure.Error() != want {
+		t.Fatalf("message = %q, want %q", failure.Error(), want)
+	}
+
+	if got := KindOf(workItemError(w, errors.New("conflict"))); got != ErrorKindUnknown {
+		t.Fatalf("unclassified error kind = %s, want %s", got, ErrorKindUnknown)
+	}
+}
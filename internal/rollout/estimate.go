@@ -0,0 +1,99 @@
+package rollout
+
+import (
+	"fmt"
+	"time"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+const (
+	// defaultWorkloadEstimate is the assumed time to restart one data-plane workload without history.
+	defaultWorkloadEstimate = time.Minute
+	// defaultControlPlaneEstimate is the assumed time to restart the control plane without history.
+	defaultControlPlaneEstimate = 2 * time.Minute
+)
+
+// Estimate is a rough duration of a rotation, as shown by a dry run.
+type Estimate struct {
+	// Total is the whole estimate.
+	Total time.Duration
+	// ControlPlane is the control-plane restart.
+	ControlPlane time.Duration
+	// Workloads is the number of data-plane restarts, counting the re-triggered rollout after cleanup.
+	Workloads int
+	// PerWorkload is the time assumed for one data-plane restart.
+	PerWorkload time.Duration
+	// Delays is the sum of the configured protection delays.
+	Delays time.Duration
+	// FromHistory is true if the timings were taken from the last rotation.
+	FromHistory bool
+}
+
+// String renders the estimate with its breakdown.
+func (e Estimate) String() string {
+	source := "default timings"
+	if e.FromHistory {
+		source = "timings of the last rotation"
+	}
+
+	return fmt.Sprintf("estimated duration %s: control plane %s, %d workload restarts at ~%s each, delays %s (%s)",
+		e.Total, e.ControlPlane, e.Workloads, e.PerWorkload, e.Delays, source)
+}
+
+// EstimateDuration estimates how long a rotation restarting queueLen data-plane workloads takes. Workloads
+// are restarted one at a time, so the data plane takes queueLen times the per-workload estimate, doubled
+// when the rollout is re-triggered after cleanup. The control-plane and per-workload timings come from
+// status.phaseDurations of the last rotation when it rolled any workloads, otherwise from defaults (plus
+// protection.stabilizationPeriod per workload). The protection delays are added as configured.
+func EstimateDuration(obj *trv1alpha1.LinkerdTrustRotation, queueLen int) Estimate {
+	protection := &obj.Spec.Protection
+	rounds := 1
+	if protection.RetriggerRolloutAfterCleanup {
+		rounds = 2
+	}
+
+	e := Estimate{
+		ControlPlane: defaultControlPlaneEstimate,
+		Workloads:    queueLen * rounds,
+		PerWorkload:  defaultWorkloadEstimate,
+	}
+	if d := protection.StabilizationPeriod; d != nil {
+		e.PerWorkload += d.Duration
+	}
+
+	st := &obj.Status
+	if planned := plannedTotal(st.PlannedCounts); planned > 0 {
+		if dp := st.PhaseDurations[string(trv1alpha1.PhaseRollingDataPlane)].Duration; dp > 0 {
+			e.PerWorkload = dp / time.Duration(planned*rounds)
+			e.FromHistory = true
+			if cp := st.PhaseDurations[string(trv1alpha1.PhaseRollingControlPlane)].Duration; cp > 0 {
+				e.ControlPlane = cp
+			}
+		}
+	}
+
+	if d := protection.BeforeRolloutDelay; d != nil {
+		e.Delays += d.Duration
+	}
+	if d := protection.BetweenPhasesDelay; d != nil {
+		e.Delays += d.Duration
+	}
+	if d := protection.HoldAfterCleanup; d != nil && protection.RetriggerRolloutAfterCleanup {
+		e.Delays += d.Duration
+	}
+
+	e.PerWorkload = e.PerWorkload.Round(time.Second)
+	e.Total = e.ControlPlane + time.Duration(e.Workloads)*e.PerWorkload + e.Delays
+
+	return e
+}
+
+// plannedTotal returns the number of workloads in the planned counts.
+func plannedTotal(c *trv1alpha1.WorkloadCounts) int {
+	if c == nil {
+		return 0
+	}
+
+	return c.Deployments + c.StatefulSets + c.DaemonSets + c.CustomResources
+}
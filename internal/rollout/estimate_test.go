@@ -0,0 +1,41 @@
+package rollout
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func TestEstimateDuration(t *testing.T) {
+	obj := &trv1alpha1.LinkerdTrustRotation{}
+	obj.Spec.Protection.BeforeRolloutDelay = &metav1.Duration{Duration: 30 * time.Second}
+	obj.Spec.Protection.StabilizationPeriod = &metav1.Duration{Duration: 30 * time.Second}
+	obj.Spec.Protection.HoldAfterCleanup = &metav1.Duration{Duration: time.Hour}
+
+	e := EstimateDuration(obj, 10)
+	if e.FromHistory || e.PerWorkload != 90*time.Second || e.Workloads != 10 {
+		t.Errorf("default estimate = %+v, want 10 workloads at 1m30s", e)
+	}
+	// holdAfterCleanup only applies to a re-triggered rollout
+	if want := defaultControlPlaneEstimate + 15*time.Minute + 30*time.Second; e.Total != want {
+		t.Errorf("Total = %s, want %s", e.Total, want)
+	}
+
+	obj.Spec.Protection.RetriggerRolloutAfterCleanup = true
+	obj.Status.PlannedCounts = &trv1alpha1.WorkloadCounts{Deployments: 3, StatefulSets: 1}
+	obj.Status.PhaseDurations = map[string]metav1.Duration{
+		string(trv1alpha1.PhaseRollingControlPlane): {Duration: time.Minute},
+		string(trv1alpha1.PhaseRollingDataPlane):    {Duration: 16 * time.Minute},
+	}
+
+	e = EstimateDuration(obj, 10)
+	if !e.FromHistory || e.PerWorkload != 2*time.Minute || e.Workloads != 20 || e.ControlPlane != time.Minute {
+		t.Errorf("estimate from history = %+v, want 20 restarts at 2m after a 1m control plane", e)
+	}
+	if want := time.Minute + 40*time.Minute + time.Hour + 30*time.Second; e.Total != want {
+		t.Errorf("Total = %s, want %s", e.Total, want)
+	}
+}
@@ -0,0 +1,86 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// removePod evicts the pod so PodDisruptionBudgets are honored, retrying after the Retry-After of each
+// 429 (a PDB blocking the eviction, or API throttling) until timeout. With direct set, or when the cluster
+// does not serve the Eviction API, the pod is deleted without consulting PDBs. A pod that is already gone
+// counts as removed.
+func (m *ManageRollout) removePod(ctx context.Context, p *corev1.Pod, direct bool, timeout time.Duration) error {
+	if direct {
+		return m.deletePod(ctx, p)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Namespace: p.Namespace, Name: p.Name}}
+		err := m.Client.SubResource("eviction").Create(ctx, p, eviction)
+		if err == nil {
+			return nil
+		}
+
+		if apierrors.IsNotFound(err) || apierrors.IsMethodNotSupported(err) {
+			unavailable, getErr := m.evictionUnavailable(ctx, p, err)
+			if getErr != nil || !unavailable {
+				return getErr
+			}
+
+			m.Logger.Info(fmt.Sprintf("Eviction API not available, deleting pod %s/%s: %v", p.Namespace, p.Name, err))
+			return m.deletePod(ctx, p)
+		}
+
+		d := RetryAfter(err)
+		if d == 0 {
+			return fmt.Errorf("evict pod %s/%s: %w", p.Namespace, p.Name, err)
+		}
+
+		if time.Now().Add(d).After(deadline) {
+			return fmt.Errorf("evict pod %s/%s: blocked by PodDisruptionBudget: %w", p.Namespace, p.Name, err)
+		}
+
+		m.backoffThrottled(ctx, err)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// evictionUnavailable tells a NotFound or MethodNotSupported eviction error caused by the cluster not
+// serving the Eviction API from a pod that is already gone.
+func (m *ManageRollout) evictionUnavailable(ctx context.Context, p *corev1.Pod, err error) (bool, error) {
+	if apierrors.IsMethodNotSupported(err) {
+		return true, nil
+	}
+
+	if err := m.Client.Get(ctx, client.ObjectKeyFromObject(p), &corev1.Pod{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("get pod %s/%s: %w", p.Namespace, p.Name, err)
+	}
+
+	return true, nil
+}
+
+// deletePod deletes the pod with its own termination grace period; a pod that is already gone is not an error.
+func (m *ManageRollout) deletePod(ctx context.Context, p *corev1.Pod) error {
+	if err := m.Client.Delete(ctx, p, &client.DeleteOptions{
+		PropagationPolicy:  func() *metav1.DeletionPropagation { bg := metav1.DeletePropagationBackground; return &bg }(),
+		GracePeriodSeconds: nil, // use Pod's own terminationGracePeriodSeconds
+	}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete pod %s/%s: %w", p.Namespace, p.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package rollout
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestRemovePod(t *testing.T) {
+	scheme := testScheme(t)
+
+	for _, direct := range []bool{false, true} {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "web-0"}}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod).Build()
+		m := &ManageRollout{Client: c, Scheme: scheme, Logger: logr.Discard()}
+
+		if err := m.removePod(context.Background(), pod, direct, time.Second); err != nil {
+			t.Fatalf("direct=%v: removePod: %v", direct, err)
+		}
+
+		err := c.Get(context.Background(), client.ObjectKeyFromObject(pod), &corev1.Pod{})
+		if !apierrors.IsNotFound(err) {
+			t.Fatalf("direct=%v: expected pod to be gone, got %v", direct, err)
+		}
+
+		// Removing an already-gone pod is not an error.
+		if err := m.removePod(context.Background(), pod, direct, time.Second); err != nil {
+			t.Fatalf("direct=%v: removePod of missing pod: %v", direct, err)
+		}
+	}
+}
+
+// evictingClient returns a client over pod whose eviction requests fail with the errors from evict.
+func evictingClient(t *testing.T, pod *corev1.Pod, evict func() error) client.Client {
+	t.Helper()
+
+	return fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(pod).WithInterceptorFuncs(interceptor.Funcs{
+		SubResourceCreate: func(ctx context.Context, c client.Client, sub string, obj client.Object,
+			subResource client.Object, opts ...client.SubResourceCreateOption) error {
+			if err := evict(); err != nil {
+				return err
+			}
+
+			return c.SubResource(sub).Create(ctx, obj, subResource, opts...)
+		},
+	}).Build()
+}
+
+func TestRemovePodRetriesThrottledEviction(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "web-0"}}
+	attempts := 0
+	c := evictingClient(t, pod, func() error {
+		if attempts++; attempts == 1 {
+			return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 1)
+		}
+
+		return nil
+	})
+	m := &ManageRollout{Client: c, Logger: logr.Discard()}
+
+	start := time.Now()
+	if err := m.removePod(context.Background(), pod, false, time.Minute); err != nil {
+		t.Fatalf("removePod: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("evictions = %d, want 2", attempts)
+	}
+	if waited := time.Since(start); waited < time.Second {
+		t.Fatalf("retried after %s, want the Retry-After of 1s", waited)
+	}
+
+	err := c.Get(context.Background(), client.ObjectKeyFromObject(pod), &corev1.Pod{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("expected pod to be evicted, got %v", err)
+	}
+}
+
+func TestRemovePodBlockedUntilDeadline(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "web-0"}}
+	attempts := 0
+	c := evictingClient(t, pod, func() error {
+		attempts++
+		return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 1)
+	})
+	m := &ManageRollout{Client: c, Logger: logr.Discard()}
+
+	err := m.removePod(context.Background(), pod, false, 1500*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "blocked by PodDisruptionBudget") {
+		t.Fatalf("want a PodDisruptionBudget error at the deadline, got %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("evictions = %d, want 2 within the deadline", attempts)
+	}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(pod), &corev1.Pod{}); err != nil {
+		t.Fatalf("blocked pod removed: %v", err)
+	}
+}
+
+func TestRemovePodWithoutEvictionAPI(t *testing.T) {
+	evictionGR := schema.GroupResource{Resource: "pods/eviction"}
+	for _, tc := range []struct {
+		name string
+		err  error
+	}{
+		{name: "not found", err: apierrors.NewNotFound(evictionGR, "web-0")},
+		{name: "method not supported", err: apierrors.NewMethodNotSupported(evictionGR, "create")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "web-0"}}
+			c := evictingClient(t, pod, func() error { return tc.err })
+			m := &ManageRollout{Client: c, Logger: logr.Discard()}
+
+			if err := m.removePod(context.Background(), pod, false, time.Second); err != nil {
+				t.Fatalf("removePod: %v", err)
+			}
+
+			err := c.Get(context.Background(), client.ObjectKeyFromObject(pod), &corev1.Pod{})
+			if !apierrors.IsNotFound(err) {
+				t.Fatalf("expected pod to be deleted, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxLastErrorLen caps the last-error annotation value; the full error stays in the status.
+const maxLastErrorLen = 256
+
+// annotateFailure stamps the workload's metadata with the failure reason and time.
+func (m *ManageRollout) annotateFailure(ctx context.Context, w WorkItem, cause error) error {
+	reason := fmt.Sprintf("%s: %v", KindOf(cause), cause)
+	if len(reason) > maxLastErrorLen {
+		reason = reason[:maxLastErrorLen]
+	}
+
+	return m.patchWorkloadAnnotations(ctx, w, func(ann map[string]string) {
+		ann[lastErrorKey] = reason
+		ann[lastErrorAtKey] = time.Now().UTC().Format(time.RFC3339)
+	})
+}
+
+// clearFailureAnnotation removes the failure annotations left by an earlier failed roll, if any.
+func (m *ManageRollout) clearFailureAnnotation(ctx context.Context, w WorkItem) error {
+	ann := getObject(w).GetAnnotations()
+	_, hasErr := ann[lastErrorKey]
+	_, hasAt := ann[lastErrorAtKey]
+	if !hasErr && !hasAt {
+		return nil
+	}
+
+	return m.patchWorkloadAnnotations(ctx, w, func(ann map[string]string) {
+		delete(ann, lastErrorKey)
+		delete(ann, lastErrorAtKey)
+	})
+}
+
+// patchWorkloadAnnotations merge-patches the metadata annotations of the work item's object.
+func (m *ManageRollout) patchWorkloadAnnotations(ctx context.Context, w WorkItem, mutate func(map[string]string)) error {
+	obj, ok := getObject(w).(client.Object)
+	if !ok {
+		return fmt.Errorf("unsupported work item kind %q", w.Kind)
+	}
+
+	orig := obj.DeepCopyObject().(client.Object)
+	ann := obj.GetAnnotations()
+	if ann == nil {
+		ann = map[string]string{}
+	}
+
+	mutate(ann)
+	obj.SetAnnotations(ann)
+	return m.patchThrottled(ctx, obj, client.MergeFrom(orig))
+}
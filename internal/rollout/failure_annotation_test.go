@@ -0,0 +1,52 @@
+package rollout
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestFailureAnnotation(t *testing.T) {
+	scheme := testScheme(t)
+
+	dep := testDeployment("apps", "web", true, nil)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dep).Build()
+	m := New(c, scheme, logr.Discard(), nil)
+	w := WorkItem{WorkItemDryRun: &WorkItemDryRun{Kind: KindDeployment}, Dep: dep}
+	ctx := context.Background()
+
+	cause := newError(ErrorKindTimeout, "rollout timed out: %s", strings.Repeat("x", 2*maxLastErrorLen))
+	if err := m.annotateFailure(ctx, w, cause); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &v1.Deployment{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "apps", Name: "web"}, got); err != nil {
+		t.Fatal(err)
+	}
+	reason := got.Annotations[lastErrorKey]
+	if !strings.HasPrefix(reason, "Timeout: rollout timed out") || len(reason) != maxLastErrorLen {
+		t.Errorf("last-error = %q (%d bytes), want a truncated Timeout reason", reason, len(reason))
+	}
+	if got.Annotations[lastErrorAtKey] == "" {
+		t.Error("last-error-at is not set")
+	}
+
+	if err := m.clearFailureAnnotation(ctx, w); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "apps", Name: "web"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.Annotations[lastErrorKey]; ok {
+		t.Errorf("last-error still set after a successful roll: %v", got.Annotations)
+	}
+	if _, ok := got.Annotations[lastErrorAtKey]; ok {
+		t.Errorf("last-error-at still set after a successful roll: %v", got.Annotations)
+	}
+}
@@ -4,9 +4,20 @@ import (
 	"crypto/sha1"
 	"encoding/hex"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+const (
+	defaultTimeoutBase       = 3 * time.Minute
+	defaultTimeoutPerReplica = 30 * time.Second
+	defaultTimeoutMax        = 30 * time.Minute
 )
 
 func ptrInt32(v int32) *int32 { return &v }
@@ -48,6 +59,30 @@ func getName(w WorkItem) string {
 	}
 }
 
+// workRef identifies a work item in the rollout cursor.
+func workRef(w WorkItem) trv1alpha1.WorkRef {
+	return trv1alpha1.WorkRef{
+		Kind:      string(w.Kind),
+		Namespace: getNamespace(w),
+		Name:      getName(w),
+	}
+}
+
+func getObject(w WorkItem) metav1.Object {
+	switch w.Kind {
+	case KindDeployment:
+		return w.Dep
+	case KindStatefulSet:
+		return w.Sts
+	case KindDaemonSet:
+		return w.Ds
+	case KindCR:
+		return w.CR
+	default:
+		return &metav1.ObjectMeta{}
+	}
+}
+
 // getAnnoFromMap is the same but starts from a generic map.
 func getAnnoFromMap(m map[string]any, path ...string) (map[string]string, bool) {
 	cur := m
@@ -75,6 +110,58 @@ func getAnnoFromMap(m map[string]any, path ...string) (map[string]string, bool)
 	return out, true
 }
 
+// workloadTimeout returns base + perReplica*replicas capped at max, using defaults for unset coefficients.
+// With the defaults, a 4-replica workload gets the former fixed 5 minutes.
+func workloadTimeout(spec *trv1alpha1.ProtectionSpec, replicas int32) time.Duration {
+	base, perReplica, maxTimeout := workloadTimeoutCoefficients(spec)
+	if replicas < 1 {
+		replicas = 1
+	}
+
+	return min(base+perReplica*time.Duration(replicas), maxTimeout)
+}
+
+// workloadTimeoutCoefficients returns the configured base, per-replica and max timeouts, using defaults for unset ones.
+func workloadTimeoutCoefficients(spec *trv1alpha1.ProtectionSpec) (time.Duration, time.Duration, time.Duration) {
+	base, perReplica, maxTimeout := defaultTimeoutBase, defaultTimeoutPerReplica, defaultTimeoutMax
+	if wt := spec.WorkloadTimeout; wt != nil {
+		if wt.Base != nil {
+			base = wt.Base.Duration
+		}
+		if wt.PerReplica != nil {
+			perReplica = wt.PerReplica.Duration
+		}
+		if wt.Max != nil && wt.Max.Duration > 0 {
+			maxTimeout = wt.Max.Duration
+		}
+	}
+
+	return base, perReplica, maxTimeout
+}
+
+// desiredReplicas returns the number of pods the workload is expected to run (at least 1).
+func desiredReplicas(w WorkItem) int32 {
+	var replicas int32 = 1
+	switch w.Kind {
+	case KindDeployment:
+		if w.Dep.Spec.Replicas != nil {
+			replicas = *w.Dep.Spec.Replicas
+		}
+	case KindStatefulSet:
+		if w.Sts.Spec.Replicas != nil {
+			replicas = *w.Sts.Spec.Replicas
+		}
+	case KindDaemonSet:
+		replicas = w.Ds.Status.DesiredNumberScheduled
+	case KindCR:
+		if v, ok, _ := unstructured.NestedInt64(w.CR.Object, "spec", "replicas"); ok {
+			replicas = int32(v)
+		}
+	}
+
+	return max(replicas, 1)
+}
+
 func podOrdinal(name string) int {
 	// expects NAME-<ordinal>
 	n := -1
@@ -88,16 +175,73 @@ func podOrdinal(name string) int {
 	return n
 }
 
+// podReady reports whether the Pod is Ready. Native sidecars (init containers with
+// restartPolicy: Always, e.g. linkerd-proxy on newer Linkerd) must be ready as well,
+// since they keep running next to the main containers.
 func podReady(p *corev1.Pod) bool {
+	ready := false
 	for _, c := range p.Status.Conditions {
 		if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
-			return true
+			ready = true
+			break
 		}
 	}
+	if !ready {
+		return false
+	}
 
-	return false
+	return nativeSidecarsReady(p)
 }
 
+// podReadyFor reports whether every condition type in conditions is True on the Pod and its
+// native sidecars are ready. Without conditions it is podReady.
+func podReadyFor(p *corev1.Pod, conditions []string) bool {
+	if len(conditions) == 0 {
+		return podReady(p)
+	}
+
+	for _, want := range conditions {
+		found := false
+		for _, c := range p.Status.Conditions {
+			if string(c.Type) == want && c.Status == corev1.ConditionTrue {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return nativeSidecarsReady(p)
+}
+
+// nativeSidecarsReady reports whether every native sidecar of the Pod is started and ready.
+// Regular init containers are ignored: they must have completed for the Pod to be Ready anyway.
+func nativeSidecarsReady(p *corev1.Pod) bool {
+	statuses := make(map[string]corev1.ContainerStatus, len(p.Status.InitContainerStatuses))
+	for _, s := range p.Status.InitContainerStatuses {
+		statuses[s.Name] = s
+	}
+
+	for _, c := range p.Spec.InitContainers {
+		if c.RestartPolicy == nil || *c.RestartPolicy != corev1.ContainerRestartPolicyAlways {
+			continue
+		}
+
+		s, ok := statuses[c.Name]
+		if !ok || !s.Ready || s.State.Running == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// planHashVersion identifies the planHash scheme; bump it whenever planHash changes what it hashes,
+// so cursors written by an older operator resume from their completed set instead of the index.
+const planHashVersion = 3
+
 // planHash returns a stable hash of the rollout queue.
 func planHash(queue []WorkItem) string {
 	h := sha1.New()
@@ -107,20 +251,83 @@ func planHash(queue []WorkItem) string {
 
 		switch w.Kind {
 		case KindDeployment:
-			_, _ = fmt.Fprintf(h, "%s/%s", w.Dep.Namespace, w.Dep.Name)
+			_, _ = fmt.Fprintf(h, "%s/%s|%s", w.Dep.Namespace, w.Dep.Name, w.Strategy)
 		case KindStatefulSet:
 			_, _ = fmt.Fprintf(h, "%s/%s|%s", w.Sts.Namespace, w.Sts.Name, w.Strategy)
 		case KindDaemonSet:
-			_, _ = fmt.Fprintf(h, "%s/%s", w.Ds.Namespace, w.Ds.Name)
+			_, _ = fmt.Fprintf(h, "%s/%s|%s", w.Ds.Namespace, w.Ds.Name, w.Strategy)
 		case KindCR:
 			_, _ = fmt.Fprintf(h, "%s/%s|%s", w.CR.GetNamespace(), w.CR.GetName(), w.Strategy)
 			if w.BumpAnnotationKey != "" {
 				_, _ = fmt.Fprintf(h, "|%s=%s", w.BumpAnnotationKey, w.BumpAnnotationValue)
 			}
 		}
+		if w.Priority != 0 {
+			_, _ = fmt.Fprintf(h, "|p=%d", w.Priority)
+		}
 
 		_, _ = fmt.Fprintf(h, "\n")
 	}
 
 	return hex.EncodeToString(h.Sum(nil))[:12] // short, but stable
 }
+
+// podSelector returns the pod selector of a typed workload, or nil for custom resources.
+func podSelector(w WorkItem) *metav1.LabelSelector {
+	switch w.Kind {
+	case KindDeployment:
+		return w.Dep.Spec.Selector
+	case KindStatefulSet:
+		return w.Sts.Spec.Selector
+	case KindDaemonSet:
+		return w.Ds.Spec.Selector
+	default:
+		return nil
+	}
+}
+
+// rotationIDAnnotations returns the rotation ID annotation to stamp on bumped workloads,
+// or nil if the feature is disabled or no rotation ID has been recorded yet.
+func rotationIDAnnotations(obj *trv1alpha1.LinkerdTrustRotation) map[string]string {
+	if !obj.Spec.Rollout.AnnotateRotationID || len(obj.Status.RotationID) == 0 {
+		return nil
+	}
+
+	key := obj.Spec.Rollout.RotationIDAnnotationKey
+	if len(key) == 0 {
+		key = rotationIDKey
+	}
+
+	return map[string]string{key: obj.Status.RotationID}
+}
+
+// restartAnnotationValue returns the restart annotation value for data-plane workloads: with
+// rollout.restartAnnotationValue=Fingerprint the current trust anchor fingerprint, suffixed during the
+// re-triggered rollout after cleanup so it restarts the workloads again; otherwise (or before a fingerprint
+// is known) the current time.
+func restartAnnotationValue(obj *trv1alpha1.LinkerdTrustRotation) string {
+	fp := CurrentFP(obj)
+	if obj.Spec.Rollout.RestartAnnotationValue != trv1alpha1.RestartAnnotationValueFingerprint || len(fp) == 0 {
+		return restartTimestamp()
+	}
+
+	if obj.Status.CleanedUpAt != nil {
+		return fp + "-cleanup"
+	}
+
+	return fp
+}
+
+// CurrentFP returns the full current trust anchor fingerprint recorded in status. Status written before
+// status.trust.currentFullFP existed only has the (then unshortened) currentFP.
+func CurrentFP(obj *trv1alpha1.LinkerdTrustRotation) string {
+	if obj.Status.Trust == nil {
+		return ""
+	}
+
+	if len(obj.Status.Trust.CurrentFullFP) > 0 {
+		return obj.Status.Trust.CurrentFullFP
+	}
+
+	return obj.Status.Trust.CurrentFP
+}
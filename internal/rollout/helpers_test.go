@@ -0,0 +1,157 @@
+package rollout
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func testPod(podReadyCond bool, sidecarReady *bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if podReadyCond {
+		status = corev1.ConditionTrue
+	}
+
+	p := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}},
+		},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "app", Ready: podReadyCond}},
+		},
+	}
+
+	if sidecarReady != nil {
+		always := corev1.ContainerRestartPolicyAlways
+		p.Spec.InitContainers = []corev1.Container{
+			{Name: "linkerd-init"},
+			{Name: "linkerd-proxy", RestartPolicy: &always},
+		}
+		p.Status.InitContainerStatuses = []corev1.ContainerStatus{
+			{
+				Name:  "linkerd-init",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+			},
+			{
+				Name:  "linkerd-proxy",
+				Ready: *sidecarReady,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			},
+		}
+	}
+
+	return p
+}
+
+func TestPodReady(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want bool
+	}{
+		{name: "classic sidecar ready", pod: testPod(true, nil), want: true},
+		{name: "classic sidecar not ready", pod: testPod(false, nil), want: false},
+		{name: "native sidecar ready", pod: testPod(true, &yes), want: true},
+		{name: "native sidecar not yet ready", pod: testPod(true, &no), want: false},
+		{name: "native sidecar ready but pod not ready", pod: testPod(false, &yes), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := podReady(tt.pod); got != tt.want {
+				t.Errorf("podReady() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodReadyNativeSidecarBecomesReady(t *testing.T) {
+	notReady := false
+	p := testPod(true, &notReady)
+	if podReady(p) {
+		t.Fatal("podReady() = true before the native sidecar is ready")
+	}
+
+	// The sidecar turns ready on its own, independently of the main containers.
+	p.Status.InitContainerStatuses[1].Ready = true
+	if !podReady(p) {
+		t.Fatal("podReady() = false after the native sidecar became ready")
+	}
+}
+
+func TestPodReadyFor(t *testing.T) {
+	p := testPod(false, nil)
+	p.Status.Conditions = append(p.Status.Conditions,
+		corev1.PodCondition{Type: corev1.ContainersReady, Status: corev1.ConditionTrue})
+
+	if podReadyFor(p, nil) {
+		t.Error("podReadyFor() = true without conditions for a pod that isn't Ready")
+	}
+	if !podReadyFor(p, []string{"ContainersReady"}) {
+		t.Error("podReadyFor() = false although ContainersReady is True")
+	}
+	if podReadyFor(p, []string{"ContainersReady", "Ready"}) {
+		t.Error("podReadyFor() = true although Ready is False")
+	}
+}
+
+func TestRotationIDAnnotations(t *testing.T) {
+	obj := &trv1alpha1.LinkerdTrustRotation{}
+	obj.Status.RotationID = "abc"
+
+	if got := rotationIDAnnotations(obj); got != nil {
+		t.Fatalf("expected no annotations when disabled, got %v", got)
+	}
+
+	obj.Spec.Rollout.AnnotateRotationID = true
+	if got := rotationIDAnnotations(obj); got[rotationIDKey] != "abc" || len(got) != 1 {
+		t.Fatalf("expected default key, got %v", got)
+	}
+
+	obj.Spec.Rollout.RotationIDAnnotationKey = "example.com/rotation"
+	if got := rotationIDAnnotations(obj); got["example.com/rotation"] != "abc" || len(got) != 1 {
+		t.Fatalf("expected custom key, got %v", got)
+	}
+
+	obj.Status.RotationID = ""
+	if got := rotationIDAnnotations(obj); got != nil {
+		t.Fatalf("expected no annotations without a rotation ID, got %v", got)
+	}
+}
+
+func TestRestartAnnotationValue(t *testing.T) {
+	obj := &trv1alpha1.LinkerdTrustRotation{}
+	obj.Status.Trust = &trv1alpha1.TrustStatus{CurrentFP: "sha256:abc"}
+
+	if got := restartAnnotationValue(obj); got == "sha256:abc" {
+		t.Fatalf("expected a timestamp by default, got %q", got)
+	}
+
+	obj.Spec.Rollout.RestartAnnotationValue = trv1alpha1.RestartAnnotationValueFingerprint
+	if got := restartAnnotationValue(obj); got != "sha256:abc" {
+		t.Fatalf("expected the current fingerprint, got %q", got)
+	}
+
+	obj.Status.CleanedUpAt = &metav1.Time{}
+	if got := restartAnnotationValue(obj); got != "sha256:abc-cleanup" {
+		t.Fatalf("expected the cleanup fingerprint, got %q", got)
+	}
+
+	// A shortened status fingerprint is never used for the annotation.
+	obj.Status.Trust = &trv1alpha1.TrustStatus{CurrentFP: "sha256:ab", CurrentFullFP: "sha256:abc"}
+	if got := restartAnnotationValue(obj); got != "sha256:abc-cleanup" {
+		t.Fatalf("expected the full fingerprint, got %q", got)
+	}
+
+	obj.Status.Trust = nil
+	if got := restartAnnotationValue(obj); len(got) == 0 {
+		t.Fatal("expected a timestamp without a fingerprint")
+	}
+}
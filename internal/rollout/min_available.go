@@ -0,0 +1,69 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// checkMinAvailable fails a Deployment, StatefulSet or DaemonSet that has fewer ready replicas than
+// its scope's minAvailable (default: all desired replicas), so restarting it can't push it further
+// below quorum. Custom resources and workloads scaled to zero are not checked.
+func (m *ManageRollout) checkMinAvailable(ctx context.Context, w WorkItem) error {
+	var ready, desired int32
+	switch w.Kind {
+	case KindDeployment:
+		var cur v1.Deployment
+		if err := m.Client.Get(ctx, getNamespaced(w), &cur); err != nil {
+			return err
+		}
+		ready, desired = cur.Status.ReadyReplicas, desiredDeploymentReplicas(&cur)
+	case KindStatefulSet:
+		var cur v1.StatefulSet
+		if err := m.Client.Get(ctx, getNamespaced(w), &cur); err != nil {
+			return err
+		}
+		desired = 1
+		if cur.Spec.Replicas != nil {
+			desired = *cur.Spec.Replicas
+		}
+		ready = cur.Status.ReadyReplicas
+	case KindDaemonSet:
+		var cur v1.DaemonSet
+		if err := m.Client.Get(ctx, getNamespaced(w), &cur); err != nil {
+			return err
+		}
+		ready, desired = cur.Status.NumberReady, cur.Status.DesiredNumberScheduled
+	default:
+		return nil
+	}
+
+	required, err := requiredAvailable(w.MinAvailable, desired)
+	if err != nil {
+		return fmt.Errorf("%s %s/%s: %w", w.Kind, getNamespace(w), getName(w), err)
+	}
+
+	if ready < required {
+		return newError(ErrorKindDegraded, "%s %s/%s has %d/%d ready replicas, below minAvailable %d; not restarting a degraded workload",
+			w.Kind, getNamespace(w), getName(w), ready, desired, required)
+	}
+
+	return nil
+}
+
+// requiredAvailable resolves minAvailable against the desired replicas (rounding percentages up).
+// Without minAvailable all desired replicas are required.
+func requiredAvailable(minAvailable *intstr.IntOrString, desired int32) (int32, error) {
+	if minAvailable == nil {
+		return desired, nil
+	}
+
+	n, err := intstr.GetScaledValueFromIntOrPercent(minAvailable, int(desired), true)
+	if err != nil {
+		return 0, newError(ErrorKindMisconfigured, "invalid minAvailable %q: %w", minAvailable.String(), err)
+	}
+
+	return min(int32(n), desired), nil
+}
@@ -0,0 +1,34 @@
+package rollout
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestRequiredAvailable(t *testing.T) {
+	pct := intstr.FromString("50%")
+	abs := intstr.FromInt32(5)
+
+	tests := []struct {
+		name         string
+		minAvailable *intstr.IntOrString
+		desired      int32
+		want         int32
+	}{
+		{name: "default requires all", desired: 3, want: 3},
+		{name: "percentage rounds up", minAvailable: &pct, desired: 3, want: 2},
+		{name: "absolute capped at desired", minAvailable: &abs, desired: 3, want: 3},
+		{name: "scaled to zero", minAvailable: &pct, desired: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		got, err := requiredAvailable(tt.minAvailable, tt.desired)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
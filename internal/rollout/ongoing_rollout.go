@@ -0,0 +1,67 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// newReplicaSetAvailableReason is the Progressing condition reason of a Deployment whose rollout is complete.
+const newReplicaSetAvailableReason = "NewReplicaSetAvailable"
+
+// deploymentRolloutInProgress reports whether d is mid-rollout: its latest generation is not observed yet,
+// or its Progressing condition is active without the new ReplicaSet being available.
+func deploymentRolloutInProgress(d *v1.Deployment) bool {
+	if d.Status.ObservedGeneration < d.Generation {
+		return true
+	}
+
+	for _, c := range d.Status.Conditions {
+		if c.Type == v1.DeploymentProgressing && c.Status == corev1.ConditionTrue && c.Reason != newReplicaSetAvailableReason {
+			return true
+		}
+	}
+
+	return false
+}
+
+// settleOngoingRollout applies rollout.ongoingRolloutPolicy to a Deployment about to be restarted. If it is
+// mid-rollout, Wait waits up to timeout for that rollout to finish and Skip returns skip=true so the caller
+// defers it, until timeout has elapsed since deferredSince (the first deferral, nil if none); then it fails
+// like Wait does. Proceed (default) doesn't look at the Deployment at all.
+func (m *ManageRollout) settleOngoingRollout(ctx context.Context, w WorkItem, policy trv1alpha1.OngoingRolloutPolicy,
+	timeout time.Duration, deferredSince *metav1.Time) (skip bool, err error) {
+	if policy != trv1alpha1.OngoingRolloutPolicyWait && policy != trv1alpha1.OngoingRolloutPolicySkip {
+		return false, nil
+	}
+
+	var cur v1.Deployment
+	if err := m.Client.Get(ctx, getNamespaced(w), &cur); err != nil {
+		return false, fmt.Errorf("get Deployment %s/%s: %w", getNamespace(w), getName(w), err)
+	}
+
+	if !deploymentRolloutInProgress(&cur) {
+		return false, nil
+	}
+
+	if policy == trv1alpha1.OngoingRolloutPolicySkip {
+		if deferredSince != nil && time.Since(deferredSince.Time) >= timeout {
+			return false, newError(ErrorKindTimeout, "Deployment %s/%s still mid-rollout %s after it was first deferred",
+				cur.Namespace, cur.Name, time.Since(deferredSince.Time).Round(time.Second))
+		}
+
+		m.Logger.Info(fmt.Sprintf("Deployment %s/%s is mid-rollout; deferring its restart (rollout.ongoingRolloutPolicy=Skip)",
+			cur.Namespace, cur.Name))
+		return true, nil
+	}
+
+	m.Logger.Info(fmt.Sprintf("Deployment %s/%s is mid-rollout; waiting for it to finish before the restart",
+		cur.Namespace, cur.Name))
+	return false, m.waitDeploymentRolledOut(ctx, getNamespaced(w), timeout)
+}
@@ -0,0 +1,79 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func TestDeploymentRolloutInProgress(t *testing.T) {
+	progressing := func(status corev1.ConditionStatus, reason string) *v1.Deployment {
+		d := testDeployment("apps", "web", true, nil)
+		d.Generation, d.Status.ObservedGeneration = 2, 2
+		d.Status.Conditions = []v1.DeploymentCondition{{Type: v1.DeploymentProgressing, Status: status, Reason: reason}}
+		return d
+	}
+
+	if deploymentRolloutInProgress(progressing(corev1.ConditionTrue, newReplicaSetAvailableReason)) {
+		t.Error("completed rollout reported in progress")
+	}
+	if !deploymentRolloutInProgress(progressing(corev1.ConditionTrue, "ReplicaSetUpdated")) {
+		t.Error("progressing rollout not reported")
+	}
+	if deploymentRolloutInProgress(progressing(corev1.ConditionFalse, "ProgressDeadlineExceeded")) {
+		t.Error("stalled rollout reported in progress")
+	}
+
+	unobserved := progressing(corev1.ConditionTrue, newReplicaSetAvailableReason)
+	unobserved.Generation = 3
+	if !deploymentRolloutInProgress(unobserved) {
+		t.Error("unobserved generation not reported")
+	}
+}
+
+func TestSettleOngoingRollout(t *testing.T) {
+	scheme := testScheme(t)
+
+	dep := testDeployment("apps", "web", true, nil)
+	dep.Status.Conditions = []v1.DeploymentCondition{
+		{Type: v1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "ReplicaSetUpdated"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dep).Build()
+	m := New(c, scheme, logr.Discard(), nil)
+	w := WorkItem{WorkItemDryRun: &WorkItemDryRun{Kind: KindDeployment}, Dep: dep}
+	ctx := context.Background()
+
+	for policy, wantSkip := range map[trv1alpha1.OngoingRolloutPolicy]bool{
+		"":                                     false,
+		trv1alpha1.OngoingRolloutPolicyProceed: false,
+		trv1alpha1.OngoingRolloutPolicySkip:    true,
+	} {
+		skip, err := m.settleOngoingRollout(ctx, w, policy, time.Minute, nil)
+		if err != nil || skip != wantSkip {
+			t.Errorf("%q: skip = %v, %v, want %v", policy, skip, err, wantSkip)
+		}
+	}
+
+	// Wait waits for the ongoing rollout, here until its timeout.
+	if _, err := m.settleOngoingRollout(ctx, w, trv1alpha1.OngoingRolloutPolicyWait, time.Nanosecond, nil); KindOf(err) != ErrorKindTimeout {
+		t.Errorf("Wait = %v, want a timeout", err)
+	}
+
+	// Skip defers until the timeout has elapsed since the first deferral, then fails like Wait.
+	recent, expired := metav1.NewTime(time.Now()), metav1.NewTime(time.Now().Add(-2*time.Minute))
+	if skip, err := m.settleOngoingRollout(ctx, w, trv1alpha1.OngoingRolloutPolicySkip, time.Minute, &recent); err != nil || !skip {
+		t.Errorf("Skip within the timeout: skip = %v, %v, want true", skip, err)
+	}
+	if skip, err := m.settleOngoingRollout(ctx, w, trv1alpha1.OngoingRolloutPolicySkip, time.Minute, &expired); skip ||
+		KindOf(err) != ErrorKindTimeout {
+		t.Errorf("Skip past the timeout: skip = %v, %v, want a timeout", skip, err)
+	}
+}
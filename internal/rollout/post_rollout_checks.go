@@ -1,16 +1,24 @@
 package rollout
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha1"
 	"encoding/hex"
 	"fmt"
+	"sort"
+	"text/template"
 	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/managed"
+	"linkerd-trust-rotator.operators.infra/internal/status"
 )
 
 const (
@@ -18,78 +26,253 @@ const (
 	defaultLinkerdCLIImage = "ghcr.io/linkerd/cli-bin:stable-2.14.10"
 	jobNamePrefix          = "linkerd-proxy-check"
 	jobSA                  = "linkerd-check"
+	linkerdProxyContainer  = "linkerd-proxy"
+	// meshCheckTarget names check Jobs of the mesh-wide proxy check, which has no target namespace.
+	meshCheckTarget = "mesh"
+	// verifyCheckSuffix names check Jobs of verify-only reconciles apart from the rotation's mesh check.
+	verifyCheckSuffix = "verify"
+	// checkJobTTL is how long finished check Jobs are kept before the TTL controller deletes them.
+	checkJobTTL = 60
+	// LabelPreservedCheck marks failed check Jobs kept for inspection (protection.keepFailedCheckJobs).
+	LabelPreservedCheck = "trust-anchor.linkerd.edenlab.io/preserved-check"
 )
 
 type CheckProxyOptions struct {
 	CLIImage      string
 	ControlPlane  bool
 	TargetNs      string
-	JobNs         string
+	LinkerdNs     string // control-plane namespace the check targets
+	JobNs         string // namespace the check Job runs in
 	JobNameSuffix string
 	Timeout       time.Duration
+	// Command and Args override the check container invocation; items are templates over checkTemplateData
+	Command []string
+	Args    []string
+	// Owner is the CR the check runs for; it owns the Job and provides its labels
+	Owner *trv1alpha1.LinkerdTrustRotation
+}
+
+// checkTemplateData is the data available to Command/Args templates.
+type checkTemplateData struct {
+	TargetNs     string
+	LinkerdNs    string
+	ControlPlane bool
 }
 
-func NewCheckProxyOptions(controlPlane bool, image, targetNs, jobNs, jobNameSuffix string, timeout time.Duration) *CheckProxyOptions {
-	return &CheckProxyOptions{
+// NewCheckProxyOptions returns the options of a check against the control plane in linkerdNs. The Job runs in
+// the owner's protection.checkJobNamespace, defaulting to linkerdNs.
+func NewCheckProxyOptions(controlPlane bool, image, targetNs, linkerdNs, jobNameSuffix string, timeout time.Duration,
+	owner *trv1alpha1.LinkerdTrustRotation) *CheckProxyOptions {
+	options := &CheckProxyOptions{
 		CLIImage:      image,
 		ControlPlane:  controlPlane,
 		TargetNs:      targetNs,
-		JobNs:         jobNs,
+		LinkerdNs:     linkerdNs,
+		JobNs:         checkJobNamespace(owner, linkerdNs),
 		JobNameSuffix: jobNameSuffix,
 		Timeout:       timeout,
+		Owner:         owner,
 	}
+
+	if owner != nil {
+		options.Command = owner.Spec.Protection.LinkerdCheckCommand
+		options.Args = owner.Spec.Protection.LinkerdCheckArgs
+	}
+
+	return options
 }
 
-func (m *ManageRollout) runLinkerdCheckJob(ctx context.Context, options *CheckProxyOptions) error {
-	cliImage := options.CLIImage
-	if len(cliImage) == 0 {
-		cliImage = defaultLinkerdCLIImage
+// RunMeshProxyCheck runs a single mesh-wide `linkerd check --proxy` once the whole data plane is rolled
+// (protection.proxyCheckScope=Once), reporting the Verifying phase while it runs.
+func (m *ManageRollout) RunMeshProxyCheck(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) error {
+	if err := m.Status.SetPhase(ctx, obj,
+		status.PhasePtr(trv1alpha1.PhaseVerifying),
+		status.ReasonPtr(trv1alpha1.ReasonMeshProxyCheck),
+		status.StringPtr("Running the mesh-wide linkerd proxy check"),
+	); err != nil {
+		return err
 	}
 
-	argsDataPlane := []string{
-		"check",
-		"--proxy",
-		"--namespace", options.TargetNs,
-		"--linkerd-namespace", options.JobNs,
+	if err := m.runLinkerdCheck(ctx, obj.Spec.Protection.CheckMode, NewCheckProxyOptions(
+		false,
+		obj.Spec.Protection.LinkerdCheckProxyImage,
+		"",
+		obj.Spec.Linkerd.Namespace,
+		meshCheckTarget,
+		rolloutPerLimit,
+		obj,
+	)); err != nil {
+		return err
+	}
+
+	return m.Status.SetPhase(ctx, obj,
+		status.PhasePtr(trv1alpha1.PhaseVerifying),
+		status.ReasonPtr(trv1alpha1.ReasonVerificationSucceeded),
+		status.StringPtr("Mesh-wide linkerd proxy check passed"),
+	)
+}
+
+// RunVerifyCheck runs the mesh-wide `linkerd check --proxy` of a verify-only reconcile. It only reads the
+// mesh; the check Job itself is the one object it creates.
+func (m *ManageRollout) RunVerifyCheck(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) error {
+	return m.runLinkerdCheck(ctx, obj.Spec.Protection.CheckMode, NewCheckProxyOptions(
+		false,
+		obj.Spec.Protection.LinkerdCheckProxyImage,
+		"",
+		obj.Spec.Linkerd.Namespace,
+		verifyCheckSuffix,
+		rolloutPerLimit,
+		obj,
+	))
+}
+
+// MeshProxyCheckEnabled reports whether the proxy check runs once for the whole mesh instead of per workload.
+func MeshProxyCheckEnabled(obj *trv1alpha1.LinkerdTrustRotation) bool {
+	return obj.Spec.Protection.RunLinkerdCheckProxy && obj.Spec.Protection.ProxyCheckScope == trv1alpha1.ProxyCheckScopeOnce
+}
+
+// checkJobNamespace returns protection.checkJobNamespace, or linkerdNs when unset.
+func checkJobNamespace(owner *trv1alpha1.LinkerdTrustRotation, linkerdNs string) string {
+	if owner != nil && len(owner.Spec.Protection.CheckJobNamespace) > 0 {
+		return owner.Spec.Protection.CheckJobNamespace
+	}
+
+	return linkerdNs
+}
+
+// runLinkerdCheck runs `linkerd check` using the configured mode (Job by default).
+func (m *ManageRollout) runLinkerdCheck(ctx context.Context, mode trv1alpha1.CheckMode, options *CheckProxyOptions) error {
+	if mode == trv1alpha1.CheckModeEphemeralContainer {
+		return m.runLinkerdCheckEphemeral(ctx, options)
+	}
+
+	return m.runLinkerdCheckJob(ctx, options)
+}
+
+// image returns the linkerd CLI image, falling back to the default.
+func (o *CheckProxyOptions) image() string {
+	if len(o.CLIImage) == 0 {
+		return defaultLinkerdCLIImage
+	}
+
+	return o.CLIImage
+}
+
+// invocation returns the check container command and args: the rendered overrides if set,
+// otherwise the image entrypoint with the default `linkerd check` arguments.
+func (o *CheckProxyOptions) invocation() ([]string, []string, error) {
+	data := checkTemplateData{TargetNs: o.TargetNs, LinkerdNs: o.LinkerdNs, ControlPlane: o.ControlPlane}
+
+	command, err := renderCheckTemplates(o.Command, data)
+	if err != nil {
+		return nil, nil, newError(ErrorKindMisconfigured, "render check command: %w", err)
+	}
+
+	if len(o.Args) == 0 {
+		return command, o.defaultArgs(), nil
+	}
+
+	args, err := renderCheckTemplates(o.Args, data)
+	if err != nil {
+		return nil, nil, newError(ErrorKindMisconfigured, "render check args: %w", err)
+	}
+
+	return command, args, nil
+}
+
+// renderCheckTemplates executes every item as a Go template over data.
+func renderCheckTemplates(items []string, data checkTemplateData) ([]string, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		tmpl, err := template.New("check").Option("missingkey=error").Parse(item)
+		if err != nil {
+			return nil, fmt.Errorf("parse %q: %w", item, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("execute %q: %w", item, err)
+		}
+		out = append(out, buf.String())
+	}
+
+	return out, nil
+}
+
+// defaultArgs returns the `linkerd check` arguments for the data plane or the control plane.
+func (o *CheckProxyOptions) defaultArgs() []string {
+	argsDataPlane := []string{"check", "--proxy"}
+	if len(o.TargetNs) > 0 {
+		// Without a namespace the check covers the data plane of the whole mesh.
+		argsDataPlane = append(argsDataPlane, "--namespace", o.TargetNs)
+	}
+	argsDataPlane = append(argsDataPlane,
+		"--linkerd-namespace", o.LinkerdNs,
 		"--wait=5m",
 		"--verbose",
-	}
+	)
 
 	argsControlPlane := []string{
 		"check",
-		"--linkerd-namespace", options.JobNs,
+		"--linkerd-namespace", o.LinkerdNs,
 		"--wait=5m",
 		"--verbose",
 	}
 
-	args := argsDataPlane
-	if options.ControlPlane {
-		args = argsControlPlane
+	if o.ControlPlane {
+		return argsControlPlane
+	}
+
+	return argsDataPlane
+}
+
+func (m *ManageRollout) runLinkerdCheckJob(ctx context.Context, options *CheckProxyOptions) error {
+	cliImage := options.image()
+	command, args, err := options.invocation()
+	if err != nil {
+		return err
+	}
+
+	targetNs := options.TargetNs
+	if len(targetNs) == 0 {
+		targetNs = meshCheckTarget
 	}
 
-	sum := sha1.Sum([]byte(options.JobNameSuffix))
-	jobName := fmt.Sprintf("%s-%s-%s", jobNamePrefix, options.TargetNs, hex.EncodeToString(sum[:])[:7])
+	keep := keepFailedCheckJobs(options.Owner)
+	seed := options.JobNameSuffix
+	if keep > 0 {
+		// A preserved Job must not be replaced by the next check, so every check gets its own name.
+		seed = fmt.Sprintf("%s/%d", options.JobNameSuffix, time.Now().UnixNano())
+	}
+
+	sum := sha1.Sum([]byte(seed))
+	jobName := fmt.Sprintf("%s-%s-%s", jobNamePrefix, targetNs, hex.EncodeToString(sum[:])[:7])
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      jobName,
 			Namespace: options.JobNs,
-			Labels:    map[string]string{"app": jobNamePrefix},
+			Labels:    managed.MergeLabels(managed.Labels(options.Owner), map[string]string{"app": jobNamePrefix}),
 		},
 		Spec: batchv1.JobSpec{
 			BackoffLimit:            ptrInt32(0),
-			TTLSecondsAfterFinished: ptrInt32(60),
+			TTLSecondsAfterFinished: ptrInt32(checkJobTTL),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: fmt.Sprintf("%s-%s", jobNamePrefix, options.TargetNs),
+					Name: fmt.Sprintf("%s-%s", jobNamePrefix, targetNs),
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: jobSA,
 					RestartPolicy:      corev1.RestartPolicyNever,
 					Containers: []corev1.Container{
 						{
-							Name:  jobNamePrefix,
-							Image: cliImage,
-							Args:  args,
+							Name:    jobNamePrefix,
+							Image:   cliImage,
+							Command: command,
+							Args:    args,
 							// If cluster needs RBAC or KUBECONFIG, you may mount ServiceAccount token automatically.
 						},
 					},
@@ -98,12 +281,193 @@ func (m *ManageRollout) runLinkerdCheckJob(ctx context.Context, options *CheckPr
 		},
 	}
 
+	if _, err := managed.SetOwner(options.Owner, job, m.Scheme, true); err != nil {
+		return fmt.Errorf("set owner of job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+
+	limiter := checkJobs.forOwner(options.Owner)
+	if err := limiter.acquire(ctx, maxConcurrentCheckJobs(options.Owner)); err != nil {
+		return fmt.Errorf("wait for check job slot: %w", err)
+	}
+	defer limiter.release()
+
 	// Create or replace the job
-	pp := metav1.DeletePropagationForeground
-	_ = m.Client.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &pp}) // best-effort cleanup previous
+	if keep == 0 {
+		pp := metav1.DeletePropagationForeground
+		_ = m.Client.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &pp}) // best-effort cleanup previous
+	}
 	if err := m.Client.Create(ctx, job); err != nil {
 		return err
 	}
 
-	return m.waitJobSucceeded(ctx, job.Namespace, job.Name, options.Timeout)
+	err = m.waitJobSucceeded(ctx, job.Namespace, job.Name, options.Timeout)
+	if err != nil && keep > 0 && (KindOf(err) == ErrorKindCheckFailed || KindOf(err) == ErrorKindTimeout) {
+		if perr := m.preserveCheckJob(ctx, job, options.Owner, keep); perr != nil {
+			m.Logger.Error(perr, fmt.Sprintf("Failed to preserve check job %s/%s", job.Namespace, job.Name))
+		}
+	}
+
+	return err
+}
+
+// keepFailedCheckJobs returns protection.keepFailedCheckJobs; 0 keeps no failed check Jobs.
+func keepFailedCheckJobs(owner *trv1alpha1.LinkerdTrustRotation) int {
+	if owner == nil || owner.Spec.Protection.KeepFailedCheckJobs < 1 {
+		return 0
+	}
+
+	return int(owner.Spec.Protection.KeepFailedCheckJobs)
+}
+
+// preserveCheckJob keeps a failed check Job for inspection: it drops the Job's TTL, labels it as preserved
+// and deletes the owner's oldest preserved Jobs beyond keep.
+func (m *ManageRollout) preserveCheckJob(ctx context.Context, job *batchv1.Job, owner *trv1alpha1.LinkerdTrustRotation,
+	keep int) error {
+	orig := job.DeepCopy()
+	job.Spec.TTLSecondsAfterFinished = nil
+	job.Labels = managed.MergeLabels(job.Labels, map[string]string{LabelPreservedCheck: "true"})
+	if err := m.patchThrottled(ctx, job, client.MergeFrom(orig)); err != nil {
+		return fmt.Errorf("preserve job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+
+	var jobs batchv1.JobList
+	if err := m.Client.List(ctx, &jobs, client.InNamespace(job.Namespace), client.MatchingLabels{
+		managed.OwnerNameKey: owner.Name,
+		managed.OwnerNsKey:   owner.Namespace,
+		LabelPreservedCheck:  "true",
+	}); err != nil {
+		return fmt.Errorf("list preserved check jobs in %q: %w", job.Namespace, err)
+	}
+
+	// newest first
+	sort.Slice(jobs.Items, func(i, j int) bool {
+		ti, tj := jobs.Items[i].CreationTimestamp, jobs.Items[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return tj.Before(&ti)
+		}
+		return jobs.Items[i].Name > jobs.Items[j].Name
+	})
+
+	pp := metav1.DeletePropagationBackground
+	for i := keep; i < len(jobs.Items); i++ {
+		old := &jobs.Items[i]
+		if err := m.Client.Delete(ctx, old, &client.DeleteOptions{PropagationPolicy: &pp}); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("delete preserved check job %s/%s: %w", old.Namespace, old.Name, err)
+		}
+		m.Logger.Info(fmt.Sprintf("Deleted preserved check job %s/%s beyond protection.keepFailedCheckJobs=%d",
+			old.Namespace, old.Name, keep))
+	}
+
+	return nil
+}
+
+// runLinkerdCheckEphemeral attaches the linkerd CLI as an ephemeral container to a ready meshed pod
+// in the target namespace and waits for it to exit successfully.
+func (m *ManageRollout) runLinkerdCheckEphemeral(ctx context.Context, options *CheckProxyOptions) error {
+	command, args, err := options.invocation()
+	if err != nil {
+		return err
+	}
+
+	pod, err := m.findMeshedPod(ctx, options.TargetNs)
+	if err != nil {
+		return err
+	}
+
+	// Ephemeral containers can't be removed, so every check needs a unique name.
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s/%d", options.JobNameSuffix, time.Now().UnixNano())))
+	name := fmt.Sprintf("%s-%s", jobNamePrefix, hex.EncodeToString(sum[:])[:7])
+
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:            name,
+			Image:           options.image(),
+			Command:         command,
+			Args:            args,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+		},
+	})
+	if err := m.Client.SubResource("ephemeralcontainers").Update(ctx, pod); err != nil {
+		return fmt.Errorf("add ephemeral container to pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+
+	return m.waitEphemeralContainerSucceeded(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name},
+		name, options.Timeout)
+}
+
+// findMeshedPod returns the first (by name) running, ready pod with a linkerd-proxy container in ns.
+func (m *ManageRollout) findMeshedPod(ctx context.Context, ns string) (*corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := m.Client.List(ctx, &pods, client.InNamespace(ns)); err != nil {
+		return nil, fmt.Errorf("list pods in %q: %w", ns, err)
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if p.DeletionTimestamp != nil || p.Status.Phase != corev1.PodRunning || !podReady(p) {
+			continue
+		}
+
+		if hasContainer(p.Spec.Containers, linkerdProxyContainer) || hasContainer(p.Spec.InitContainers, linkerdProxyContainer) {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no ready meshed pod found in %q to run the check", ns)
+}
+
+func hasContainer(containers []corev1.Container, name string) bool {
+	for _, c := range containers {
+		if c.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// waitEphemeralContainerSucceeded polls the pod until the named ephemeral container terminates.
+func (m *ManageRollout) waitEphemeralContainerSucceeded(ctx context.Context, key types.NamespacedName, name string,
+	timeout time.Duration) error {
+	ticker := time.NewTicker(m.pollInterval())
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		if time.Now().After(deadline) {
+			return newError(ErrorKindTimeout, "timeout waiting for ephemeral container %s in pod %s", name, key.String())
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		var cur corev1.Pod
+		if err := m.Client.Get(ctx, key, &cur); err != nil {
+			if m.backoffThrottled(ctx, err) {
+				continue
+			}
+			return fmt.Errorf("get pod %s: %w", key.String(), err)
+		}
+
+		for _, st := range cur.Status.EphemeralContainerStatuses {
+			if st.Name != name || st.State.Terminated == nil {
+				continue
+			}
+
+			if st.State.Terminated.ExitCode != 0 {
+				return newError(ErrorKindCheckFailed, "linkerd check in pod %s failed: exit code %d (%s)",
+					key.String(), st.State.Terminated.ExitCode, st.State.Terminated.Reason)
+			}
+
+			return nil
+		}
+	}
 }
@@ -0,0 +1,277 @@
+package rollout
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/managed"
+)
+
+func TestCheckProxyOptionsInvocation(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     []string
+		args        []string
+		wantCommand []string
+		wantArgs    []string
+		wantErr     bool
+	}{
+		{
+			name: "defaults",
+			wantArgs: []string{"check", "--proxy", "--namespace", "apps", "--linkerd-namespace", "linkerd",
+				"--wait=5m", "--verbose"},
+		},
+		{
+			name:        "templated overrides",
+			command:     []string{"/bin/check.sh"},
+			args:        []string{"--target={{.TargetNs}}", "--linkerd={{.LinkerdNs}}", "--cp={{.ControlPlane}}"},
+			wantCommand: []string{"/bin/check.sh"},
+			wantArgs:    []string{"--target=apps", "--linkerd=linkerd", "--cp=false"},
+		},
+		{
+			name:        "command only keeps default args",
+			command:     []string{"linkerd-wrapper", "{{.LinkerdNs}}"},
+			wantCommand: []string{"linkerd-wrapper", "linkerd"},
+			wantArgs: []string{"check", "--proxy", "--namespace", "apps", "--linkerd-namespace", "linkerd",
+				"--wait=5m", "--verbose"},
+		},
+		{
+			name:    "unknown field",
+			args:    []string{"{{.Unknown}}"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewCheckProxyOptions(false, "", "apps", "linkerd", "web", time.Minute, nil)
+			o.Command, o.Args = tt.command, tt.args
+
+			command, args, err := o.invocation()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("invocation: %v", err)
+			}
+
+			if !reflect.DeepEqual(command, tt.wantCommand) {
+				t.Fatalf("command = %v, want %v", command, tt.wantCommand)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Fatalf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestMeshProxyCheckArgs(t *testing.T) {
+	o := NewCheckProxyOptions(false, "", "", "linkerd", meshCheckTarget, time.Minute, nil)
+
+	want := []string{"check", "--proxy", "--linkerd-namespace", "linkerd", "--wait=5m", "--verbose"}
+	if got := o.defaultArgs(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("args = %v, want %v", got, want)
+	}
+}
+
+func TestCheckJobNamespace(t *testing.T) {
+	owner := &trv1alpha1.LinkerdTrustRotation{}
+	owner.Spec.Protection.CheckJobNamespace = "linkerd-checks"
+
+	o := NewCheckProxyOptions(true, "", "linkerd", "linkerd", "control-plane", time.Minute, owner)
+	if o.JobNs != "linkerd-checks" {
+		t.Fatalf("JobNs = %q, want linkerd-checks", o.JobNs)
+	}
+
+	want := []string{"check", "--linkerd-namespace", "linkerd", "--wait=5m", "--verbose"}
+	if got := o.defaultArgs(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("args = %v, want %v", got, want)
+	}
+
+	if o := NewCheckProxyOptions(true, "", "linkerd", "linkerd", "control-plane", time.Minute, nil); o.JobNs != "linkerd" {
+		t.Fatalf("JobNs = %q, want the linkerd namespace by default", o.JobNs)
+	}
+}
+
+func TestPreserveCheckJob(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	owner := &trv1alpha1.LinkerdTrustRotation{ObjectMeta: metav1.ObjectMeta{Namespace: "linkerd", Name: "rotation"}}
+	owner.Spec.Protection.KeepFailedCheckJobs = 2
+	now := time.Now()
+	job := func(name string, age time.Duration, preserved bool) *batchv1.Job {
+		labels := managed.Labels(owner)
+		if preserved {
+			labels[LabelPreservedCheck] = "true"
+		}
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "linkerd", Name: name, Labels: labels,
+				CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Spec: batchv1.JobSpec{TTLSecondsAfterFinished: ptrInt32(checkJobTTL)},
+		}
+	}
+
+	failed := job("check-new", 0, false)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		failed,
+		job("check-1h", time.Hour, true),
+		job("check-2h", 2*time.Hour, true),
+		job("check-3h", 3*time.Hour, true),
+	).Build()
+	m := New(c, scheme, logr.Discard(), nil)
+
+	if err := m.preserveCheckJob(context.Background(), failed, owner, keepFailedCheckJobs(owner)); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &batchv1.Job{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(failed), got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Labels[LabelPreservedCheck] != "true" || got.Spec.TTLSecondsAfterFinished != nil {
+		t.Errorf("failed job labels = %v, ttl = %v; want preserved without TTL", got.Labels, got.Spec.TTLSecondsAfterFinished)
+	}
+
+	for name, kept := range map[string]bool{"check-1h": true, "check-2h": false, "check-3h": false} {
+		err := c.Get(context.Background(), client.ObjectKey{Namespace: "linkerd", Name: name}, &batchv1.Job{})
+		if kept && err != nil {
+			t.Errorf("job %s: %v, want it kept", name, err)
+		}
+		if !kept && !apierrors.IsNotFound(err) {
+			t.Errorf("job %s: %v, want it deleted", name, err)
+		}
+	}
+
+	if keepFailedCheckJobs(nil) != 0 || keepFailedCheckJobs(&trv1alpha1.LinkerdTrustRotation{}) != 0 {
+		t.Error("keepFailedCheckJobs defaults to non-zero")
+	}
+}
+
+func TestRunLinkerdCheckEphemeral(t *testing.T) {
+	meshedPod := func(name string, ready bool) *corev1.Pod {
+		p := webPod(name, time.Minute, ready)
+		p.Spec.Containers = []corev1.Container{{Name: "web"}, {Name: linkerdProxyContainer}}
+		return p
+	}
+
+	tests := []struct {
+		name     string
+		pods     []client.Object
+		exitCode *int32
+		wantKind ErrorKind
+		wantErr  bool
+		noPod    bool
+	}{
+		{name: "check passes", pods: []client.Object{meshedPod("web-b", true)}, exitCode: ptrInt32(0)},
+		{
+			name:     "check fails",
+			pods:     []client.Object{meshedPod("web-b", true)},
+			exitCode: ptrInt32(1),
+			wantKind: ErrorKindCheckFailed,
+			wantErr:  true,
+		},
+		{
+			name:     "container never exits",
+			pods:     []client.Object{meshedPod("web-b", true)},
+			wantKind: ErrorKindTimeout,
+			wantErr:  true,
+		},
+		{
+			name:    "no ready meshed pod",
+			pods:    []client.Object{meshedPod("web-a", false), webPod("web-b", time.Minute, true)},
+			wantErr: true,
+			noPod:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := testScheme(t)
+			// Unmeshed and unready pods sort first, so the check has to skip them.
+			objs := append([]client.Object{webPod("web-a0", time.Minute, true), meshedPod("web-a1", false)}, tt.pods...)
+
+			// Play the kubelet: the added ephemeral container terminates right away with exitCode.
+			var (
+				target string
+				added  []corev1.EphemeralContainer
+			)
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, sub string, obj client.Object,
+					opts ...client.SubResourceUpdateOption) error {
+					pod, ok := obj.(*corev1.Pod)
+					if !ok || sub != "ephemeralcontainers" {
+						return c.SubResource(sub).Update(ctx, obj, opts...)
+					}
+
+					target, added = pod.Name, pod.Spec.EphemeralContainers
+					if err := c.Update(ctx, pod); err != nil || tt.exitCode == nil {
+						return err
+					}
+
+					for _, ec := range pod.Spec.EphemeralContainers {
+						pod.Status.EphemeralContainerStatuses = append(pod.Status.EphemeralContainerStatuses,
+							corev1.ContainerStatus{Name: ec.Name, State: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{ExitCode: *tt.exitCode, Reason: "Completed"},
+							}})
+					}
+					return c.Status().Update(ctx, pod)
+				},
+			}).Build()
+			m := &ManageRollout{Client: c, Scheme: scheme, Logger: logr.Discard(), PollInterval: 10 * time.Millisecond}
+
+			o := NewCheckProxyOptions(false, "example.com/linkerd-cli:test", "apps", "linkerd", "web",
+				100*time.Millisecond, nil)
+			err := m.runLinkerdCheck(context.Background(), trv1alpha1.CheckModeEphemeralContainer, o)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if tt.wantKind != "" && KindOf(err) != tt.wantKind {
+					t.Fatalf("error kind = %q (%v), want %q", KindOf(err), err, tt.wantKind)
+				}
+			} else if err != nil {
+				t.Fatalf("runLinkerdCheck: %v", err)
+			}
+
+			if tt.noPod {
+				if added != nil {
+					t.Fatalf("ephemeral container added without a ready meshed pod: %+v", added)
+				}
+				return
+			}
+
+			if target != "web-b" || len(added) != 1 {
+				t.Fatalf("ephemeral containers of pod %q = %+v, want one in web-b", target, added)
+			}
+			ec := added[0]
+			if !strings.HasPrefix(ec.Name, jobNamePrefix+"-") || ec.Image != "example.com/linkerd-cli:test" ||
+				ec.ImagePullPolicy != corev1.PullIfNotPresent || len(ec.Command) != 0 {
+				t.Errorf("ephemeral container = %+v", ec)
+			}
+			if !reflect.DeepEqual(ec.Args, o.defaultArgs()) {
+				t.Errorf("args = %v, want %v", ec.Args, o.defaultArgs())
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// defaultPostRotationAnnotationValue changes with every rotation, so GitOps tools see a new request each time.
+const defaultPostRotationAnnotationValue = "{{.RotationID}}"
+
+// postRotationRequest is the JSON body POSTed to PostRotationTriggerSpec.WebhookURL.
+type postRotationRequest struct {
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	RotationID string `json:"rotationID"`
+	CurrentFP  string `json:"currentFP"`
+}
+
+// RunPostRotationTrigger annotates the configured target and calls the webhook of
+// protection.postRotationTrigger. It is a no-op without a trigger.
+func (m *ManageRollout) RunPostRotationTrigger(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) error {
+	trigger := obj.Spec.Protection.PostRotationTrigger
+	if trigger == nil {
+		return nil
+	}
+
+	if trigger.Target != nil {
+		if err := m.annotatePostRotationTarget(ctx, obj, trigger.Target); err != nil {
+			return err
+		}
+	}
+
+	if len(trigger.WebhookURL) > 0 {
+		req := postRotationRequest{
+			Namespace:  obj.Namespace,
+			Name:       obj.Name,
+			RotationID: obj.Status.RotationID,
+			CurrentFP:  CurrentFP(obj),
+		}
+
+		if err := callWebhook(ctx, trigger.WebhookURL, req); err != nil {
+			return fmt.Errorf("post-rotation webhook: %w", err)
+		}
+
+		m.Logger.Info("Called post-rotation webhook")
+	}
+
+	return nil
+}
+
+// annotatePostRotationTarget sets the rendered annotation on the target object.
+func (m *ManageRollout) annotatePostRotationTarget(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation,
+	t *trv1alpha1.PostRotationTarget) error {
+	value := t.AnnotationValue
+	if len(value) == 0 {
+		value = defaultPostRotationAnnotationValue
+	}
+
+	rendered, err := renderStatusTemplate(obj, "postRotationTrigger", value)
+	if err != nil {
+		return err
+	}
+
+	gvk := schema.GroupVersionKind{Group: t.APIGroup, Version: t.Version, Kind: t.Kind}
+	key := types.NamespacedName{Namespace: t.Namespace, Name: t.Name}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	if err := m.Client.Get(ctx, key, u); err != nil {
+		return fmt.Errorf("get post-rotation target %s %s: %w", gvk.Kind, key.String(), err)
+	}
+
+	orig := u.DeepCopy()
+	ann := u.GetAnnotations()
+	if ann == nil {
+		ann = map[string]string{}
+	}
+	ann[t.AnnotationKey] = rendered
+	u.SetAnnotations(ann)
+
+	if err := m.patchThrottled(ctx, u, client.MergeFrom(orig)); err != nil {
+		return fmt.Errorf("annotate post-rotation target %s %s: %w", gvk.Kind, key.String(), err)
+	}
+
+	m.Logger.Info(fmt.Sprintf("Set annotation %s=%q on post-rotation target %s %s",
+		t.AnnotationKey, rendered, gvk.Kind, key.String()))
+	return nil
+}
@@ -0,0 +1,66 @@
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func TestRunPostRotationTrigger(t *testing.T) {
+	scheme := testScheme(t)
+
+	var got postRotationRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	target := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "gitops", Name: "app"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(target).Build()
+	m := &ManageRollout{Client: c, Scheme: scheme, Logger: logr.Discard()}
+
+	obj := &trv1alpha1.LinkerdTrustRotation{ObjectMeta: metav1.ObjectMeta{Namespace: "linkerd", Name: "rotation"}}
+	obj.Status.RotationID = "r1"
+	obj.Status.Trust = &trv1alpha1.TrustStatus{CurrentFP: "sha256:abc"}
+	obj.Spec.Protection.PostRotationTrigger = &trv1alpha1.PostRotationTriggerSpec{
+		Target: &trv1alpha1.PostRotationTarget{
+			Version: "v1", Kind: "ConfigMap", Namespace: "gitops", Name: "app",
+			AnnotationKey: "example.com/rotated",
+		},
+		WebhookURL: srv.URL,
+	}
+
+	if err := m.RunPostRotationTrigger(context.Background(), obj); err != nil {
+		t.Fatalf("RunPostRotationTrigger() error = %v", err)
+	}
+
+	var cm corev1.ConfigMap
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: "gitops", Name: "app"}, &cm); err != nil {
+		t.Fatal(err)
+	}
+	if v := cm.Annotations["example.com/rotated"]; v != "r1" {
+		t.Errorf("target annotation = %q, want %q", v, "r1")
+	}
+
+	want := postRotationRequest{Namespace: "linkerd", Name: "rotation", RotationID: "r1", CurrentFP: "sha256:abc"}
+	if got != want {
+		t.Errorf("webhook request = %+v, want %+v", got, want)
+	}
+
+	obj.Spec.Protection.PostRotationTrigger.Target.Name = "missing"
+	if err := m.RunPostRotationTrigger(context.Background(), obj); err == nil {
+		t.Error("RunPostRotationTrigger() error = nil for a missing target, want error")
+	}
+}
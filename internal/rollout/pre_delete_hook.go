@@ -0,0 +1,130 @@
+package rollout
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+const (
+	defaultPreDeleteAnnotationValue = "true"
+	defaultPreDeleteHookTimeout     = 5 * time.Minute
+	webhookRequestTimeout           = 10 * time.Second
+)
+
+// preDeleteHookRequest is the JSON body POSTed to PreDeleteHookSpec.WebhookURL.
+type preDeleteHookRequest struct {
+	Namespace   string `json:"namespace"`
+	Pod         string `json:"pod"`
+	StatefulSet string `json:"statefulSet"`
+}
+
+// waitPreDeleteHook blocks until the hook green-lights deleting the pod, or fails after the hook timeout.
+// A nil hook always passes.
+func (m *ManageRollout) waitPreDeleteHook(ctx context.Context, p *corev1.Pod, stsName string, hook *trv1alpha1.PreDeleteHookSpec) error {
+	if hook == nil || (len(hook.WebhookURL) == 0 && len(hook.Annotation) == 0) {
+		return nil
+	}
+
+	timeout := defaultPreDeleteHookTimeout
+	if hook.Timeout != nil && hook.Timeout.Duration > 0 {
+		timeout = hook.Timeout.Duration
+	}
+
+	ticker := time.NewTicker(m.pollInterval())
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(timeout)
+	key := types.NamespacedName{Namespace: p.Namespace, Name: p.Name}
+
+	for {
+		ok, reason, err := m.preDeleteHookPassed(ctx, key, stsName, hook)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return newError(ErrorKindTimeout, "pre-delete hook did not green-light pod %s within %s: %s", key.String(), timeout, reason)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// preDeleteHookPassed evaluates the hook once. A non-passing check returns a short reason for diagnostics.
+func (m *ManageRollout) preDeleteHookPassed(ctx context.Context, key types.NamespacedName, stsName string,
+	hook *trv1alpha1.PreDeleteHookSpec) (bool, string, error) {
+	if len(hook.Annotation) > 0 {
+		expected := defaultPreDeleteAnnotationValue
+		if len(hook.AnnotationValue) > 0 {
+			expected = hook.AnnotationValue
+		}
+
+		var cur corev1.Pod
+		if err := m.Client.Get(ctx, key, &cur); err != nil {
+			return false, "", fmt.Errorf("get pod %s: %w", key.String(), err)
+		}
+
+		if cur.Annotations[hook.Annotation] != expected {
+			return false, fmt.Sprintf("annotation %s!=%q", hook.Annotation, expected), nil
+		}
+	}
+
+	if len(hook.WebhookURL) > 0 {
+		if err := callWebhook(ctx, hook.WebhookURL, preDeleteHookRequest{
+			Namespace:   key.Namespace,
+			Pod:         key.Name,
+			StatefulSet: stsName,
+		}); err != nil {
+			// Webhook failures are retried until the hook timeout expires.
+			return false, err.Error(), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+// callWebhook POSTs the request as JSON and treats any 2xx response as a green light.
+func callWebhook(ctx context.Context, url string, req any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookRequestTimeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("webhook request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func TestRestartStatefulSetByDeletePreDeleteHook(t *testing.T) {
+	const drained = "example.com/drained"
+	dbLabels := map[string]string{"app": "db"}
+	dbPod := func(name string, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: name, Labels: dbLabels, Annotations: annotations},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		webhookCode int
+		annotations map[string]string
+		wantEvents  []string
+		wantTimeout bool
+	}{
+		{
+			name:        "hook green-lights every pod",
+			webhookCode: http.StatusOK,
+			annotations: map[string]string{drained: "true"},
+			wantEvents:  []string{"hook db-1", "delete db-1", "hook db-0", "delete db-0"},
+		},
+		{
+			name:        "webhook failure stops the deletion",
+			webhookCode: http.StatusServiceUnavailable,
+			annotations: map[string]string{drained: "true"},
+			wantEvents:  []string{"hook db-1"},
+			wantTimeout: true,
+		},
+		{
+			name:        "missing annotation stops the deletion",
+			webhookCode: http.StatusOK,
+			wantTimeout: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu     sync.Mutex
+				events []string
+			)
+			record := func(e string) {
+				mu.Lock()
+				defer mu.Unlock()
+				if len(events) == 0 || events[len(events)-1] != e {
+					events = append(events, e)
+				}
+			}
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req preDeleteHookRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.StatefulSet != "db" {
+					t.Errorf("unexpected hook request %+v: %v", req, err)
+				}
+				record("hook " + req.Pod)
+				w.WriteHeader(tt.webhookCode)
+			}))
+			defer srv.Close()
+
+			var replicas int32 = 2
+			sts := &v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "db"},
+				Spec:       v1.StatefulSetSpec{Replicas: &replicas, Selector: &metav1.LabelSelector{MatchLabels: dbLabels}},
+				Status:     v1.StatefulSetStatus{ReadyReplicas: 2, CurrentRevision: "db-2", UpdateRevision: "db-2"},
+			}
+
+			scheme := testScheme(t)
+			// Play the StatefulSet controller: every deleted pod is recreated under its name, ready.
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(sts, dbPod("db-0", tt.annotations), dbPod("db-1", tt.annotations)).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						if err := c.Delete(ctx, obj, opts...); err != nil {
+							return err
+						}
+						record("delete " + obj.GetName())
+						return c.Create(ctx, dbPod(obj.GetName(), tt.annotations))
+					},
+				}).Build()
+			m := &ManageRollout{Client: c, Scheme: scheme, Logger: logr.Discard(), PollInterval: 10 * time.Millisecond}
+
+			hook := &trv1alpha1.PreDeleteHookSpec{
+				WebhookURL: srv.URL,
+				Annotation: drained,
+				Timeout:    &metav1.Duration{Duration: 100 * time.Millisecond},
+			}
+			err := m.restartStatefulSetByDelete(context.Background(), sts, hook, true, nil, time.Second)
+			if tt.wantTimeout {
+				if KindOf(err) != ErrorKindTimeout {
+					t.Fatalf("restartStatefulSetByDelete() error = %v, want a hook timeout", err)
+				}
+			} else if err != nil {
+				t.Fatalf("restartStatefulSetByDelete() error = %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !slices.Equal(events, tt.wantEvents) {
+				t.Errorf("events %v, want %v", events, tt.wantEvents)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// accessCheck is a single "can I <verb> <resource> in <namespace>" question.
+type accessCheck struct {
+	verb        string
+	group       string
+	resource    string
+	subresource string
+	namespace   string // empty means cluster-wide
+}
+
+func (a accessCheck) String() string {
+	resource := a.resource
+	if len(a.group) > 0 {
+		resource = fmt.Sprintf("%s.%s", a.resource, a.group)
+	}
+	if len(a.subresource) > 0 {
+		resource = fmt.Sprintf("%s/%s", resource, a.subresource)
+	}
+
+	scope := "cluster"
+	if len(a.namespace) > 0 {
+		scope = fmt.Sprintf("namespace %q", a.namespace)
+	}
+
+	return fmt.Sprintf("cannot %s %s in %s", a.verb, resource, scope)
+}
+
+// podDeleteChecks are the checks for a delete-based restart (rolloutDelete) in namespace ns: it lists
+// the workload pods and the PodDisruptionBudgets sizing its batches, then evicts the pods or, with
+// rollout.directPodDelete (or without the Eviction API), deletes them.
+func podDeleteChecks(obj *trv1alpha1.LinkerdTrustRotation, ns string) []accessCheck {
+	checks := []accessCheck{
+		{verb: "list", resource: "pods", namespace: ns},
+		{verb: "delete", resource: "pods", namespace: ns},
+		{verb: "list", group: "policy", resource: "poddisruptionbudgets", namespace: ns},
+	}
+	if !obj.Spec.Rollout.DirectPodDelete {
+		checks = append(checks, accessCheck{verb: "create", resource: "pods", subresource: "eviction", namespace: ns})
+	}
+
+	return checks
+}
+
+// PreflightRBAC verifies via SelfSubjectAccessReview that the operator can list every kind
+// it selects in every target namespace (an empty namespace means cluster-wide), and returns
+// one actionable error listing all missing permissions before any rotation work starts.
+func (m *ManageRollout) PreflightRBAC(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) error {
+	checks, err := m.rbacChecks(obj)
+	if err != nil {
+		return err
+	}
+
+	return m.reviewAccess(ctx, checks)
+}
+
+// preflightPlanRBAC verifies the pod permissions of the workloads whose strategy annotation overrides
+// their scope to rolloutDelete; only the scope strategies are known to PreflightRBAC.
+func (m *ManageRollout) preflightPlanRBAC(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, plan *Plan) error {
+	covered, err := m.rbacChecks(obj)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[accessCheck]struct{}, len(covered))
+	for _, c := range covered {
+		seen[c] = struct{}{}
+	}
+
+	var checks []accessCheck
+	for _, item := range plan.Queue {
+		if item.Strategy != Delete {
+			continue
+		}
+
+		for _, c := range podDeleteChecks(obj, item.Namespace) {
+			if _, ok := seen[c]; !ok {
+				seen[c] = struct{}{}
+				checks = append(checks, c)
+			}
+		}
+	}
+
+	return m.reviewAccess(ctx, checks)
+}
+
+// reviewAccess runs the checks and returns one error listing every missing permission.
+func (m *ManageRollout) reviewAccess(ctx context.Context, checks []accessCheck) error {
+	var missing []string
+	for _, check := range checks {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace:   check.namespace,
+					Verb:        check.verb,
+					Group:       check.group,
+					Resource:    check.resource,
+					Subresource: check.subresource,
+				},
+			},
+		}
+
+		if err := m.Client.Create(ctx, review); err != nil {
+			return fmt.Errorf("self subject access review: %w", err)
+		}
+
+		if !review.Status.Allowed {
+			missing = append(missing, check.String())
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing RBAC: %s", strings.Join(missing, "; "))
+	}
+
+	return nil
+}
+
+// rbacChecks builds the deduplicated list of access checks for the CR's targets.
+func (m *ManageRollout) rbacChecks(obj *trv1alpha1.LinkerdTrustRotation) ([]accessCheck, error) {
+	var checks []accessCheck
+	seen := map[accessCheck]struct{}{}
+	add := func(c accessCheck) {
+		if _, ok := seen[c]; !ok {
+			seen[c] = struct{}{}
+			checks = append(checks, c)
+		}
+	}
+
+	for _, scope := range obj.Spec.Rollout.TargetAnnotationSelector.Targets {
+		var group, resource string
+
+		switch scope.KindType {
+		case string(KindDeployment):
+			group, resource = "apps", "deployments"
+		case string(KindStatefulSet):
+			group, resource = "apps", "statefulsets"
+		case string(KindDaemonSet):
+			group, resource = "apps", "daemonsets"
+		case string(KindCR):
+			mapping, err := m.Client.RESTMapper().RESTMapping(
+				schema.GroupKind{Group: scope.APIGroup, Kind: scope.Kind}, scope.Version)
+			if err != nil {
+				return nil, fmt.Errorf("targets[%s]: resolve %s/%s %s: %w",
+					scope.KindType, scope.APIGroup, scope.Version, scope.Kind, err)
+			}
+			group, resource = mapping.Resource.Group, mapping.Resource.Resource
+		default:
+			// Unsupported kinds are reported by the planner.
+			continue
+		}
+
+		for _, ns := range scope.AllowedNamespaces {
+			add(accessCheck{verb: "list", group: group, resource: resource, namespace: ns})
+
+			if scope.RolloutStrategy == Delete {
+				for _, c := range podDeleteChecks(obj, ns) {
+					add(c)
+				}
+			}
+
+			// Skipping up-to-date workloads inspects pod start times.
+			if obj.Spec.Rollout.SkipUpToDateWorkloads && scope.KindType != string(KindCR) {
+				add(accessCheck{verb: "list", resource: "pods", namespace: ns})
+			}
+		}
+	}
+
+	if len(obj.Spec.Rollout.NamespaceOptOutKey) > 0 {
+		add(accessCheck{verb: "list", resource: "namespaces"})
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,127 @@
+package rollout
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func TestRBACChecks(t *testing.T) {
+	obj := testLTR(false,
+		trv1alpha1.TargetScope{KindType: string(KindDeployment), AllowedNamespaces: []string{"a"}},
+		trv1alpha1.TargetScope{KindType: string(KindStatefulSet), AllowedNamespaces: []string{"b"}, RolloutStrategy: Delete},
+	)
+	m := &ManageRollout{Logger: logr.Discard()}
+
+	checks, err := m.rbacChecks(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, c := range checks {
+		got = append(got, c.String())
+	}
+	want := []string{
+		`cannot list deployments.apps in namespace "a"`,
+		`cannot list statefulsets.apps in namespace "b"`,
+		`cannot list pods in namespace "b"`,
+		`cannot delete pods in namespace "b"`,
+		`cannot list poddisruptionbudgets.policy in namespace "b"`,
+		`cannot create pods/eviction in namespace "b"`,
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("checks:\n got %q\nwant %q", got, want)
+	}
+
+	// Direct deletes bypass the Eviction API.
+	obj.Spec.Rollout.DirectPodDelete = true
+	checks, err = m.rbacChecks(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range checks {
+		if c.subresource == "eviction" {
+			t.Errorf("unexpected check with directPodDelete: %s", c)
+		}
+	}
+}
+
+func TestPreflightPlanRBAC(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pods may be listed anywhere but deleted only in namespace "a".
+	var reviewed []string
+	c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			review := obj.(*authorizationv1.SelfSubjectAccessReview)
+			attrs := review.Spec.ResourceAttributes
+			reviewed = append(reviewed, strings.TrimSuffix(attrs.Resource+"/"+attrs.Subresource, "/")+":"+
+				attrs.Verb+":"+attrs.Namespace)
+			review.Status.Allowed = attrs.Verb != "delete" || attrs.Namespace == "a"
+			return nil
+		},
+	}).Build()
+	m := &ManageRollout{Client: c, Scheme: scheme, Logger: logr.Discard()}
+
+	obj := testLTR(false,
+		trv1alpha1.TargetScope{KindType: string(KindDeployment), AllowedNamespaces: []string{"a", "b"}},
+	)
+	item := func(ns, strategy string) WorkItem {
+		return WorkItem{WorkItemDryRun: &WorkItemDryRun{Kind: KindDeployment, Namespace: ns, Name: "web", Strategy: strategy}}
+	}
+
+	tests := []struct {
+		name     string
+		queue    []WorkItem
+		reviewed []string
+		wantErr  string
+	}{
+		{
+			name:  "no overrides",
+			queue: []WorkItem{item("a", Restart), item("b", Restart)},
+		},
+		{
+			name:     "allowed override",
+			queue:    []WorkItem{item("a", Delete), item("a", Delete), item("b", Restart)},
+			reviewed: []string{"pods:list:a", "pods:delete:a", "poddisruptionbudgets:list:a", "pods/eviction:create:a"},
+		},
+		{
+			name:     "denied override",
+			queue:    []WorkItem{item("a", Restart), item("b", Delete)},
+			reviewed: []string{"pods:list:b", "pods:delete:b", "poddisruptionbudgets:list:b", "pods/eviction:create:b"},
+			wantErr:  `cannot delete pods in namespace "b"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviewed = nil
+			err := m.preflightPlanRBAC(context.Background(), obj, &Plan{Result: Result{Queue: tt.queue}})
+
+			if !slices.Equal(reviewed, tt.reviewed) {
+				t.Errorf("reviewed %q, want %q", reviewed, tt.reviewed)
+			}
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
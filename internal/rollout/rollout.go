@@ -3,6 +3,7 @@ package rollout
 import (
 	"context"
 	"fmt"
+	"maps"
 	"sort"
 	"time"
 
@@ -14,16 +15,38 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/managed"
 	"linkerd-trust-rotator.operators.infra/internal/status"
 )
 
 const (
 	restartedAtKey      = "kubectl.kubernetes.io/restartedAt"
+	planHashKey         = "rotation.linkerd.edenlab.io/plan-hash"
+	rotationIDKey       = "rotation.linkerd.edenlab.io/rotation-id"
+	tierKey             = "rotation.linkerd.edenlab.io/tier"
+	strategyKey         = "rotation.linkerd.edenlab.io/strategy"
+	lastErrorKey        = "rotation.linkerd.edenlab.io/last-error"
+	lastErrorAtKey      = "rotation.linkerd.edenlab.io/last-error-at"
 	rolloutPollInterval = 2 * time.Second
 	rolloutPerLimit     = 5 * time.Minute
+	// rolloutConfirmWindow is how long a Deployment must stay rolled out at an unchanged
+	// replica count before the waiter accepts it (guards against HPA scaling mid-rollout).
+	rolloutConfirmWindow = 5 * time.Second
+	// progressPersistEvery and progressPersistInterval throttle cursor/progress status writes
+	// during a data-plane rollout; whichever is reached first triggers a write.
+	progressPersistEvery    = 10
+	progressPersistInterval = 30 * time.Second
+	// defaultReconcileBudget is how long one reconcile restarts data-plane workloads before yielding
+	// (rollout.reconcileBudget).
+	defaultReconcileBudget = 10 * time.Minute
+	// crNotFoundGracePolls is how many consecutive NotFound polls the CR waiter tolerates
+	// (e.g. while an operator recreates the CR) before failing with ErrCRDisappeared.
+	crNotFoundGracePolls = 3
 )
 
 type ManageRollout struct {
@@ -31,6 +54,16 @@ type ManageRollout struct {
 	Scheme *runtime.Scheme
 	Logger logr.Logger
 	Status *status.ManageStatus
+
+	// AllowedCRKinds, if set, limits CustomResource scopes to these GVKs (see CheckAllowedCRKinds).
+	AllowedCRKinds []schema.GroupVersionKind
+
+	// PollInterval is how often waiters poll the cluster (default: rolloutPollInterval).
+	PollInterval time.Duration
+
+	// ConfirmWindow is how long a Deployment must stay rolled out before the waiter accepts it
+	// (default: rolloutConfirmWindow).
+	ConfirmWindow time.Duration
 }
 
 // New returns a new secret manager.
@@ -38,49 +71,74 @@ func New(c client.Client, s *runtime.Scheme, l logr.Logger, status *status.Manag
 	return &ManageRollout{Client: c, Scheme: s, Logger: l.WithName("Rollout"), Status: status}
 }
 
+// pollInterval returns PollInterval, falling back to the default.
+func (m *ManageRollout) pollInterval() time.Duration {
+	if m.PollInterval > 0 {
+		return m.PollInterval
+	}
+
+	return rolloutPollInterval
+}
+
+// confirmWindow returns ConfirmWindow, falling back to the default.
+func (m *ManageRollout) confirmWindow() time.Duration {
+	if m.ConfirmWindow > 0 {
+		return m.ConfirmWindow
+	}
+
+	return rolloutConfirmWindow
+}
+
 // BumpRestartAnnotation bumps an annotation to trigger restart/rolling.
 // For typed workloads (Deploy/STS/DS) it updates pod template.
 // For CRDs it tries (in order): special Strimzi case -> .spec.template -> .spec.pods[] -> resource metadata.
-func (m *ManageRollout) bumpRestartAnnotation(ctx context.Context, obj client.Object) error {
-	return m.bumpAnnotationGeneric(ctx, obj, restartedAtKey, time.Now().UTC().Format(time.RFC3339))
+// The restart annotation is set to value (see restartAnnotationValue); extra annotations
+// (e.g. the rotation ID) are set in the same patch.
+func (m *ManageRollout) bumpRestartAnnotation(ctx context.Context, obj client.Object, value string, extra map[string]string) error {
+	return m.bumpAnnotations(ctx, obj, managed.MergeLabels(extra, map[string]string{restartedAtKey: value}))
+}
+
+// restartTimestamp returns the current time as a restart annotation value.
+func restartTimestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
 }
 
-// bumpRestartAnnotation patches pod template annotation with current timestamp,
+// bumpAnnotations patches pod template annotations (e.g. with current timestamp),
 // triggering a new rollout (the same as `kubectl rollout restart`).
-func (m *ManageRollout) bumpAnnotationGeneric(ctx context.Context, obj client.Object, key, value string) error {
+func (m *ManageRollout) bumpAnnotations(ctx context.Context, obj client.Object, annotations map[string]string) error {
 	switch o := obj.(type) {
 	case *v1.Deployment:
 		orig := o.DeepCopy()
 		if o.Spec.Template.Annotations == nil {
 			o.Spec.Template.Annotations = map[string]string{}
 		}
-		o.Spec.Template.Annotations[key] = value
-		return m.Client.Patch(ctx, o, client.MergeFrom(orig))
+		maps.Copy(o.Spec.Template.Annotations, annotations)
+		return m.patchThrottled(ctx, o, client.MergeFrom(orig))
 
 	case *v1.StatefulSet:
 		orig := o.DeepCopy()
 		if o.Spec.Template.Annotations == nil {
 			o.Spec.Template.Annotations = map[string]string{}
 		}
-		o.Spec.Template.Annotations[key] = value
-		return m.Client.Patch(ctx, o, client.MergeFrom(orig))
+		maps.Copy(o.Spec.Template.Annotations, annotations)
+		return m.patchThrottled(ctx, o, client.MergeFrom(orig))
 
 	case *v1.DaemonSet:
 		orig := o.DeepCopy()
 		if o.Spec.Template.Annotations == nil {
 			o.Spec.Template.Annotations = map[string]string{}
 		}
-		o.Spec.Template.Annotations[key] = value
-		return m.Client.Patch(ctx, o, client.MergeFrom(orig))
+		maps.Copy(o.Spec.Template.Annotations, annotations)
+		return m.patchThrottled(ctx, o, client.MergeFrom(orig))
 	case *unstructured.Unstructured:
-		return m.bumpAnnotationUnstructured(ctx, o, key, value)
+		return m.bumpAnnotationUnstructured(ctx, o, annotations)
 
 	default:
 		return fmt.Errorf("unsupported type for annotation bump: %T", obj)
 	}
 }
 
-func (m *ManageRollout) bumpAnnotationUnstructured(ctx context.Context, u *unstructured.Unstructured, key, value string) error {
+func (m *ManageRollout) bumpAnnotationUnstructured(ctx context.Context, u *unstructured.Unstructured, annotations map[string]string) error {
 	// Fallback: set on resource metadata (works for CRDs with operator-defined triggers)
 	orig := u.DeepCopy()
 	ann := u.GetAnnotations()
@@ -88,14 +146,37 @@ func (m *ManageRollout) bumpAnnotationUnstructured(ctx context.Context, u *unstr
 		ann = map[string]string{}
 	}
 
-	ann[key] = value
+	maps.Copy(ann, annotations)
 	u.SetAnnotations(ann)
-	return m.Client.Patch(ctx, u, client.MergeFrom(orig))
+	return m.patchThrottled(ctx, u, client.MergeFrom(orig))
+}
+
+// annotatePlanHash sets the plan hash annotation on the CR metadata.
+// Metadata-only changes don't bump generation, so GenerationChangedPredicate won't requeue on it.
+func (m *ManageRollout) annotatePlanHash(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, hash string) error {
+	if obj.GetAnnotations()[planHashKey] == hash {
+		return nil
+	}
+
+	orig := obj.DeepCopy()
+	ann := obj.GetAnnotations()
+	if ann == nil {
+		ann = map[string]string{}
+	}
+
+	ann[planHashKey] = hash
+	obj.SetAnnotations(ann)
+	return m.patchThrottled(ctx, obj, client.MergeFrom(orig))
 }
 
 // restartStatefulSetByDelete performs a manual rolling restart by deleting pods one-by-one.
-// Order: highest ordinal -> lowest (N-1 ... 0). Waits for each pod to become Ready again.
-func (m *ManageRollout) restartStatefulSetByDelete(ctx context.Context, sts *v1.StatefulSet, perPodTimeout time.Duration) error {
+// Order: highest ordinal -> lowest (N-1 ... 0). Waits for each pod to become ready again
+// (see podReadyFor),
+// then for the StatefulSet's current and update revisions to converge.
+// If hook is set, each pod must be green-lit by it before deletion.
+// Pods are evicted (honoring PDBs) unless direct is set.
+func (m *ManageRollout) restartStatefulSetByDelete(ctx context.Context, sts *v1.StatefulSet,
+	hook *trv1alpha1.PreDeleteHookSpec, direct bool, readyConditions []string, perPodTimeout time.Duration) error {
 	// List pods by StatefulSet selector
 	selector, err := metav1.LabelSelectorAsSelector(sts.Spec.Selector)
 	if err != nil {
@@ -122,27 +203,35 @@ func (m *ManageRollout) restartStatefulSetByDelete(ctx context.Context, sts *v1.
 
 	for i := range pods.Items {
 		p := pods.Items[i] // copy
-		if err := m.deletePodAndWaitSameNameReady(ctx, &p, perPodTimeout); err != nil {
+		if err := m.waitPreDeleteHook(ctx, &p, sts.Name, hook); err != nil {
+			return fmt.Errorf("rolloutDelete %s/%s pod %s: %w", p.Namespace, sts.Name, p.Name, err)
+		}
+
+		if err := m.deletePodAndWaitSameNameReady(ctx, &p, direct, readyConditions, perPodTimeout); err != nil {
 			return fmt.Errorf("rolloutDelete %s/%s pod %s: %w", p.Namespace, sts.Name, p.Name, err)
 		}
 	}
 
+	// Same completion guarantee as a template bump: the StatefulSet controller must also consider
+	// every pod to be on its update revision.
+	key := types.NamespacedName{Namespace: sts.Namespace, Name: sts.Name}
+	if err := m.waitStatefulSetRolledOut(ctx, key, perPodTimeout); err != nil {
+		return fmt.Errorf("rolloutDelete %s/%s: revisions did not converge: %w", sts.Namespace, sts.Name, err)
+	}
+
 	return nil
 }
 
-// deletePodAndWaitSameNameReady deletes the given Pod and waits until a Pod with the same name
-// appears Running and Ready again (which is how StatefulSet recreates its pods).
-func (m *ManageRollout) deletePodAndWaitSameNameReady(ctx context.Context, p *corev1.Pod, timeout time.Duration) error {
-	// Delete with default grace; adjust if you want immediate termination
-	if err := m.Client.Delete(ctx, p, &client.DeleteOptions{
-		PropagationPolicy:  func() *metav1.DeletionPropagation { bg := metav1.DeletePropagationBackground; return &bg }(),
-		GracePeriodSeconds: nil, // use Pod's own terminationGracePeriodSeconds
-	}); err != nil && !apierrors.IsNotFound(err) {
-		return fmt.Errorf("delete pod %s/%s: %w", p.Namespace, p.Name, err)
+// deletePodAndWaitSameNameReady removes the given Pod (see removePod) and waits until a Pod with the same name
+// appears Running and ready per readyConditions again (which is how StatefulSet recreates its pods).
+func (m *ManageRollout) deletePodAndWaitSameNameReady(ctx context.Context, p *corev1.Pod, direct bool,
+	readyConditions []string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	if err := m.removePod(ctx, p, direct, timeout); err != nil {
+		return err
 	}
 
-	deadline := time.Now().Add(timeout)
-	tick := time.NewTicker(2 * time.Second)
+	tick := time.NewTicker(m.pollInterval())
 	defer tick.Stop()
 
 	key := types.NamespacedName{Namespace: p.Namespace, Name: p.Name}
@@ -156,23 +245,27 @@ func (m *ManageRollout) deletePodAndWaitSameNameReady(ctx context.Context, p *co
 
 		var cur corev1.Pod
 		err := m.Client.Get(ctx, key, &cur)
-		if apierrors.IsNotFound(err) {
-			// Still recreating — keep polling
+		if apierrors.IsNotFound(err) || m.backoffThrottled(ctx, err) {
+			// Still recreating (or throttled) — keep polling
 			continue
 		}
 		if err != nil {
 			return err
 		}
 
-		// Ready when Running + PodReady=True and not terminating
+		// Ready when Running + ready conditions True and not terminating
 		if cur.DeletionTimestamp == nil &&
 			cur.Status.Phase == corev1.PodRunning &&
-			podReady(&cur) {
+			podReadyFor(&cur, readyConditions) {
 			return nil
 		}
+
+		if err := podUnschedulable(&cur, time.Now()); err != nil {
+			return err
+		}
 	}
 
-	return fmt.Errorf("timeout waiting pod %s/%s to be Ready after delete", p.Namespace, p.Name)
+	return newError(ErrorKindTimeout, "timeout waiting pod %s/%s to be Ready after delete", p.Namespace, p.Name)
 }
 
 // waitCRByAnnotationAndStatus is a generic waiter for any CRD.
@@ -189,22 +282,18 @@ func (m *ManageRollout) waitCRByAnnotationAndStatus(
 	u *unstructured.Unstructured,
 	annoKey string,
 	requireAnnoCleared bool,
+	statusOK crReadyFunc,
 	timeout time.Duration,
 ) error {
-	ticker := time.NewTicker(rolloutPollInterval)
+	ticker := time.NewTicker(m.pollInterval())
 	defer ticker.Stop()
 
 	deadline := time.Now().Add(timeout)
-	statusOK := func(u *unstructured.Unstructured) bool {
-		ready, _, _ := unstructured.NestedInt64(u.Object, "status", "readyPods")
-		total, _, _ := unstructured.NestedInt64(u.Object, "status", "pods")
-		obs, _, _ := unstructured.NestedInt64(u.Object, "status", "observedGeneration")
-		return total > 0 && ready == total && obs >= u.GetGeneration()
-	}
+	notFound := 0
 
 	for {
 		if time.Now().After(deadline) {
-			return fmt.Errorf("timeout waiting for %s", u.GroupVersionKind().String())
+			return newError(ErrorKindTimeout, "timeout waiting for %s", u.GroupVersionKind().String())
 		}
 
 		select {
@@ -217,11 +306,18 @@ func (m *ManageRollout) waitCRByAnnotationAndStatus(
 		cur.SetGroupVersionKind(u.GroupVersionKind())
 		if err := m.Client.Get(ctx, key, cur); err != nil {
 			if apierrors.IsNotFound(err) {
+				if notFound++; notFound >= crNotFoundGracePolls {
+					return newError(ErrorKindDisappeared, "%s %s: %w", u.GetKind(), key.String(), ErrCRDisappeared)
+				}
+				continue
+			}
+			if m.backoffThrottled(ctx, err) {
 				continue
 			}
 
 			return err
 		}
+		notFound = 0
 
 		ok := statusOK(cur)
 		if requireAnnoCleared {
@@ -241,15 +337,16 @@ func (m *ManageRollout) waitCRByAnnotationAndStatus(
 // waitDeploymentRolledOut waits until Deployment is fully rolled out,
 // following the same logic as `kubectl rollout status`.
 func (m *ManageRollout) waitDeploymentRolledOut(ctx context.Context, key types.NamespacedName, timeout time.Duration) error {
-	ticker := time.NewTicker(rolloutPollInterval)
+	ticker := time.NewTicker(m.pollInterval())
 	defer ticker.Stop()
 
 	deadline := time.Now().Add(timeout)
+	confirm := &rolloutConfirmer{window: m.confirmWindow()}
 
 	for {
 		// timeout check
 		if time.Now().After(deadline) {
-			return fmt.Errorf("timeout waiting for Deployment rollout")
+			return newError(ErrorKindTimeout, "timeout waiting for Deployment rollout")
 		}
 
 		select {
@@ -264,36 +361,79 @@ func (m *ManageRollout) waitDeploymentRolledOut(ctx context.Context, key types.N
 				// unlikely for Deployment, but retry
 				continue
 			}
+			if m.backoffThrottled(ctx, err) {
+				continue
+			}
 			return err
 		}
 
-		// replicas defaults to 1 if not set
-		var replicas int32 = 1
-		if cur.Spec.Replicas != nil {
-			replicas = *cur.Spec.Replicas
+		// Desired replicas are re-read on every poll, so an HPA scale restarts the confirmation window.
+		if confirm.observe(time.Now(), deploymentRolledOut(&cur), desiredDeploymentReplicas(&cur)) {
+			return nil
 		}
 
-		ready := cur.Status.UpdatedReplicas == replicas &&
-			cur.Status.ReadyReplicas == replicas &&
-			cur.Status.UnavailableReplicas == 0 &&
-			cur.Status.ObservedGeneration >= cur.Generation
-
-		if ready {
-			return nil
+		if err := m.checkUnschedulable(ctx, cur.Namespace, cur.Spec.Selector); err != nil {
+			return err
 		}
 	}
 }
 
+// rolloutConfirmer accepts a rollout only after it has been observed complete for a whole window
+// at the same desired replica count. Any regression or replica change restarts the window.
+type rolloutConfirmer struct {
+	window   time.Duration
+	since    time.Time
+	replicas int32
+	ok       bool
+}
+
+// observe records one poll and reports whether the rollout is confirmed.
+func (c *rolloutConfirmer) observe(now time.Time, rolledOut bool, replicas int32) bool {
+	if !rolledOut {
+		c.ok = false
+		return false
+	}
+
+	if !c.ok || c.replicas != replicas {
+		c.ok, c.since, c.replicas = true, now, replicas
+	}
+
+	return now.Sub(c.since) >= c.window
+}
+
+func desiredDeploymentReplicas(cur *v1.Deployment) int32 {
+	// replicas defaults to 1 if not set
+	if cur.Spec.Replicas != nil {
+		return *cur.Spec.Replicas
+	}
+
+	return 1
+}
+
+// deploymentRolledOut reports whether the Deployment is fully rolled out and ready.
+// ReadyReplicas is derived from the PodReady condition, which already accounts for
+// native sidecar (restartable init container) readiness.
+func deploymentRolledOut(cur *v1.Deployment) bool {
+	replicas := desiredDeploymentReplicas(cur)
+
+	// Replicas must match too: surplus old pods mean the rollout (or a scale-down) is still in flight.
+	return cur.Status.ObservedGeneration >= cur.Generation &&
+		cur.Status.UpdatedReplicas == replicas &&
+		cur.Status.Replicas == replicas &&
+		cur.Status.ReadyReplicas == replicas &&
+		cur.Status.UnavailableReplicas == 0
+}
+
 // waitStatefulSetRolledOut waits until StatefulSet has finished rolling update.
 func (m *ManageRollout) waitStatefulSetRolledOut(ctx context.Context, key types.NamespacedName, timeout time.Duration) error {
-	ticker := time.NewTicker(rolloutPollInterval)
+	ticker := time.NewTicker(m.pollInterval())
 	defer ticker.Stop()
 
 	deadline := time.Now().Add(timeout)
 
 	for {
 		if time.Now().After(deadline) {
-			return fmt.Errorf("timeout waiting for StatefulSet rollout")
+			return newError(ErrorKindTimeout, "timeout waiting for StatefulSet rollout")
 		}
 		select {
 		case <-ctx.Done():
@@ -307,36 +447,46 @@ func (m *ManageRollout) waitStatefulSetRolledOut(ctx context.Context, key types.
 				// Unlikely for StatefulSet; retry next tick.
 				continue
 			}
+			if m.backoffThrottled(ctx, err) {
+				continue
+			}
 			return err
 		}
 
-		// default replicas = 1 if not set
-		var replicas int32 = 1
-		if cur.Spec.Replicas != nil {
-			replicas = *cur.Spec.Replicas
+		if statefulSetRolledOut(&cur) {
+			return nil
 		}
 
-		ready := cur.Status.ReadyReplicas == replicas &&
-			cur.Status.CurrentRevision == cur.Status.UpdateRevision &&
-			cur.Status.ObservedGeneration >= cur.Generation
-
-		if ready {
-			return nil
+		if err := m.checkUnschedulable(ctx, cur.Namespace, cur.Spec.Selector); err != nil {
+			return err
 		}
 	}
 }
 
+// statefulSetRolledOut reports whether the StatefulSet has converged to its update revision and is ready.
+func statefulSetRolledOut(cur *v1.StatefulSet) bool {
+	// default replicas = 1 if not set
+	var replicas int32 = 1
+	if cur.Spec.Replicas != nil {
+		replicas = *cur.Spec.Replicas
+	}
+
+	return cur.Status.ReadyReplicas == replicas &&
+		cur.Status.CurrentRevision == cur.Status.UpdateRevision &&
+		cur.Status.ObservedGeneration >= cur.Generation
+}
+
 // waitDaemonSetRolledOut waits until DaemonSet has finished rolling update.
 // Note: with OnDelete strategy, bumping the template won't roll pods; we fail early.
 func (m *ManageRollout) waitDaemonSetRolledOut(ctx context.Context, key types.NamespacedName, timeout time.Duration) error {
-	ticker := time.NewTicker(rolloutPollInterval)
+	ticker := time.NewTicker(m.pollInterval())
 	defer ticker.Stop()
 
 	deadline := time.Now().Add(timeout)
 
 	for {
 		if time.Now().After(deadline) {
-			return fmt.Errorf("timeout waiting for Daemonset rollout")
+			return newError(ErrorKindTimeout, "timeout waiting for Daemonset rollout")
 		}
 		select {
 		case <-ctx.Done():
@@ -350,20 +500,126 @@ func (m *ManageRollout) waitDaemonSetRolledOut(ctx context.Context, key types.Na
 				// Unlikely for DaemonSet; retry next tick.
 				continue
 			}
+			if m.backoffThrottled(ctx, err) {
+				continue
+			}
 			return err
 		}
 
 		if cur.Spec.UpdateStrategy.Type == v1.OnDeleteDaemonSetStrategyType {
-			return fmt.Errorf("Daemonset %s uses OnDelete strategy: template bump won't roll pods", key.String())
+			return newError(ErrorKindMisconfigured,
+				"Daemonset %s uses OnDelete strategy: template bump won't roll pods, use rolloutStrategy=rolloutDelete", key.String())
+		}
+
+		if daemonSetRolledOut(&cur) {
+			return nil
+		}
+
+		if err := m.checkUnschedulable(ctx, cur.Namespace, cur.Spec.Selector); err != nil {
+			return err
+		}
+	}
+}
+
+// daemonSetRolledOut reports whether the DaemonSet is updated and available on every scheduled node.
+func daemonSetRolledOut(cur *v1.DaemonSet) bool {
+	desired := cur.Status.DesiredNumberScheduled
+	return cur.Status.UpdatedNumberScheduled == desired &&
+		cur.Status.NumberAvailable == desired &&
+		cur.Status.NumberMisscheduled == 0 &&
+		cur.Status.ObservedGeneration >= cur.Generation
+}
+
+// crStatusOK is the generic readiness predicate for custom resources (readyPods == pods).
+func crStatusOK(u *unstructured.Unstructured) bool {
+	ready, _, _ := unstructured.NestedInt64(u.Object, "status", "readyPods")
+	total, _, _ := unstructured.NestedInt64(u.Object, "status", "pods")
+	obs, _, _ := unstructured.NestedInt64(u.Object, "status", "observedGeneration")
+	return total > 0 && ready == total && obs >= u.GetGeneration()
+}
+
+// workItemReady fetches the workload and evaluates its readiness predicate once (non-blocking).
+func (m *ManageRollout) workItemReady(ctx context.Context, w WorkItem) (bool, error) {
+	key := getNamespaced(w)
+
+	switch w.Kind {
+	case KindDeployment:
+		var cur v1.Deployment
+		if err := m.Client.Get(ctx, key, &cur); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+
+		return deploymentRolledOut(&cur), nil
+	case KindStatefulSet:
+		var cur v1.StatefulSet
+		if err := m.Client.Get(ctx, key, &cur); err != nil {
+			return false, client.IgnoreNotFound(err)
 		}
 
-		desired := cur.Status.DesiredNumberScheduled
-		ready := cur.Status.UpdatedNumberScheduled == desired &&
-			cur.Status.NumberAvailable == desired &&
-			cur.Status.NumberMisscheduled == 0 &&
-			cur.Status.ObservedGeneration >= cur.Generation
+		return statefulSetRolledOut(&cur), nil
+	case KindDaemonSet:
+		var cur v1.DaemonSet
+		if err := m.Client.Get(ctx, key, &cur); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+
+		return daemonSetRolledOut(&cur), nil
+	case KindCR:
+		cur := &unstructured.Unstructured{}
+		cur.SetGroupVersionKind(w.CR.GroupVersionKind())
+		if err := m.Client.Get(ctx, key, cur); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
 
-		if ready {
+		ready, err := crReadiness(w.ReadyExpression)
+		if err != nil {
+			return false, err
+		}
+
+		return ready(cur), nil
+	default:
+		return false, fmt.Errorf("unsupported kind for readiness check: %s", w.Kind)
+	}
+}
+
+// waitStabilized confirms that a workload declared ready stays ready for the whole stabilization period.
+// A flap back to not-ready restarts the window; the overall timeout still applies.
+func (m *ManageRollout) waitStabilized(ctx context.Context, w WorkItem, period *metav1.Duration, timeout time.Duration) error {
+	if period == nil || period.Duration <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(m.pollInterval())
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(timeout)
+	stableSince := time.Now()
+
+	for {
+		if time.Now().After(deadline) {
+			return newError(ErrorKindTimeout, "timeout waiting for %s %s to stay ready for %s",
+				w.Kind, getNamespaced(w).String(), period.Duration.String())
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		ready, err := m.workItemReady(ctx, w)
+		if err != nil {
+			return err
+		}
+
+		if !ready {
+			m.Logger.Info(fmt.Sprintf("%s %s became unready during stabilization, waiting",
+				w.Kind, getNamespaced(w).String()))
+			stableSince = time.Now()
+			continue
+		}
+
+		if time.Since(stableSince) >= period.Duration {
 			return nil
 		}
 	}
@@ -371,7 +627,7 @@ func (m *ManageRollout) waitDaemonSetRolledOut(ctx context.Context, key types.Na
 
 func (m *ManageRollout) waitJobSucceeded(ctx context.Context, ns, name string, timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
-	tick := time.NewTicker(rolloutPollInterval)
+	tick := time.NewTicker(m.pollInterval())
 	defer tick.Stop()
 
 	key := client.ObjectKey{Namespace: ns, Name: name}
@@ -384,7 +640,7 @@ func (m *ManageRollout) waitJobSucceeded(ctx context.Context, ns, name string, t
 
 		var cur batchv1.Job
 		if err := m.Client.Get(ctx, key, &cur); err != nil {
-			if apierrors.IsNotFound(err) {
+			if apierrors.IsNotFound(err) || m.backoffThrottled(ctx, err) {
 				continue
 			}
 
@@ -393,7 +649,7 @@ func (m *ManageRollout) waitJobSucceeded(ctx context.Context, ns, name string, t
 
 		for _, c := range cur.Status.Conditions {
 			if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
-				return fmt.Errorf("linkerd check job failed: %s", c.Message)
+				return newError(ErrorKindCheckFailed, "linkerd check job failed: %s", c.Message)
 			}
 
 			if c.Type == batchv1.JobComplete && c.Status == corev1.ConditionTrue {
@@ -402,5 +658,5 @@ func (m *ManageRollout) waitJobSucceeded(ctx context.Context, ns, name string, t
 		}
 	}
 
-	return fmt.Errorf("timeout waiting for linkerd check job %s/%s", ns, name)
+	return newError(ErrorKindTimeout, "timeout waiting for linkerd check job %s/%s", ns, name)
 }
@@ -0,0 +1,171 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// deploymentAt returns a Deployment with the given desired replicas and status counters.
+func deploymentAt(desired, updated, total, ready int32) *v1.Deployment {
+	return &v1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec:       v1.DeploymentSpec{Replicas: &desired},
+		Status: v1.DeploymentStatus{
+			ObservedGeneration:  2,
+			UpdatedReplicas:     updated,
+			Replicas:            total,
+			ReadyReplicas:       ready,
+			UnavailableReplicas: max(desired-ready, 0),
+		},
+	}
+}
+
+func TestDeploymentRolledOut(t *testing.T) {
+	lagging := deploymentAt(2, 2, 2, 2)
+	lagging.Status.ObservedGeneration = 1
+
+	tests := []struct {
+		name string
+		dep  *v1.Deployment
+		want bool
+	}{
+		{name: "fully rolled out", dep: deploymentAt(2, 2, 2, 2), want: true},
+		{name: "old pods still present", dep: deploymentAt(2, 2, 3, 2), want: false},
+		{name: "observed generation lagging", dep: lagging, want: false},
+		{name: "scaled up, new pods not ready", dep: deploymentAt(4, 4, 4, 2), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deploymentRolledOut(tt.dep); got != tt.want {
+				t.Errorf("deploymentRolledOut() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRolloutConfirmerHPAScaleUp(t *testing.T) {
+	c := &rolloutConfirmer{window: 5 * time.Second}
+	now := time.Unix(0, 0)
+
+	// Each step is one poll of the Deployment as an HPA scales it from 2 to 4 replicas mid-rollout.
+	steps := []struct {
+		dep  *v1.Deployment
+		want bool
+	}{
+		{dep: deploymentAt(2, 1, 3, 2), want: false}, // rolling
+		{dep: deploymentAt(2, 2, 2, 2), want: false}, // briefly complete at the old count
+		{dep: deploymentAt(4, 2, 4, 2), want: false}, // HPA scaled up: target moved
+		{dep: deploymentAt(4, 4, 4, 3), want: false}, // new pods still starting
+		{dep: deploymentAt(4, 4, 4, 4), want: false}, // complete at 4: window starts
+		{dep: deploymentAt(4, 4, 4, 4), want: false},
+		{dep: deploymentAt(4, 4, 4, 4), want: true}, // held for the whole window
+	}
+
+	for i, st := range steps {
+		got := c.observe(now, deploymentRolledOut(st.dep), desiredDeploymentReplicas(st.dep))
+		if got != st.want {
+			t.Fatalf("poll %d: observe() = %v, want %v", i, got, st.want)
+		}
+		now = now.Add(rolloutPollInterval + 500*time.Millisecond)
+	}
+}
+
+func TestRolloutConfirmerReplicaChangeRestartsWindow(t *testing.T) {
+	c := &rolloutConfirmer{window: 5 * time.Second}
+	now := time.Unix(0, 0)
+
+	if c.observe(now, true, 2) {
+		t.Fatal("observe() confirmed before the window elapsed")
+	}
+
+	// A scale to 3 that is already complete still restarts the window.
+	now = now.Add(4 * time.Second)
+	if c.observe(now, true, 3) {
+		t.Fatal("observe() confirmed right after a replica change")
+	}
+
+	now = now.Add(4 * time.Second)
+	if c.observe(now, true, 3) {
+		t.Fatal("observe() confirmed before the restarted window elapsed")
+	}
+
+	now = now.Add(time.Second)
+	if !c.observe(now, true, 3) {
+		t.Fatal("observe() did not confirm after the window elapsed")
+	}
+}
+
+func TestRestartStatefulSetByDeleteWaitsForRevisions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, converge := range []bool{true, false} {
+		t.Run(map[bool]string{true: "converges", false: "stuck on old revision"}[converge], func(t *testing.T) {
+			t.Parallel()
+
+			var replicas int32 = 1
+			labels := map[string]string{"app": "db"}
+			sts := &v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "db"},
+				Spec:       v1.StatefulSetSpec{Replicas: &replicas, Selector: &metav1.LabelSelector{MatchLabels: labels}},
+				Status:     v1.StatefulSetStatus{ReadyReplicas: 1, CurrentRevision: "db-1", UpdateRevision: "db-2"},
+			}
+			readyPod := func() *corev1.Pod {
+				return &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "db-0", Labels: labels},
+					Status: corev1.PodStatus{
+						Phase:      corev1.PodRunning,
+						Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+					},
+				}
+			}
+
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sts, readyPod()).Build()
+			m := &ManageRollout{Client: c, Scheme: scheme, Logger: logr.Discard()}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Play the StatefulSet controller: recreate the deleted pod, then (optionally) converge revisions.
+			go func() {
+				for ctx.Err() == nil {
+					time.Sleep(100 * time.Millisecond)
+					if err := c.Get(ctx, client.ObjectKey{Namespace: "apps", Name: "db-0"}, &corev1.Pod{}); !apierrors.IsNotFound(err) {
+						continue
+					}
+
+					_ = c.Create(ctx, readyPod())
+					if converge {
+						cur := &v1.StatefulSet{}
+						_ = c.Get(ctx, client.ObjectKeyFromObject(sts), cur)
+						cur.Status.CurrentRevision = cur.Status.UpdateRevision
+						_ = c.Status().Update(ctx, cur)
+					}
+					return
+				}
+			}()
+
+			err := m.restartStatefulSetByDelete(ctx, sts, nil, true, nil, 3*time.Second)
+			if converge && err != nil {
+				t.Fatalf("restartStatefulSetByDelete() error = %v", err)
+			}
+			if !converge && KindOf(err) != ErrorKindTimeout {
+				t.Fatalf("restartStatefulSetByDelete() error = %v, want a timeout", err)
+			}
+		})
+	}
+}
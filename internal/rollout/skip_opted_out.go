@@ -0,0 +1,55 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// namespaceOptOutValue is the label/annotation value that opts a namespace out.
+const namespaceOptOutValue = "true"
+
+// skipOptedOutNamespaces removes queue items in namespaces labeled or annotated with key=true
+// and counts the skipped namespaces.
+func skipOptedOutNamespaces(ctx context.Context, c client.Reader, logger logr.Logger, key string, result *Result) error {
+	var list corev1.NamespaceList
+	if err := c.List(ctx, &list); err != nil {
+		return fmt.Errorf("list namespaces: %w", err)
+	}
+
+	optedOut := map[string]struct{}{}
+	for _, ns := range list.Items {
+		if ns.Labels[key] == namespaceOptOutValue || ns.Annotations[key] == namespaceOptOutValue {
+			optedOut[ns.Name] = struct{}{}
+		}
+	}
+
+	if len(optedOut) == 0 {
+		return nil
+	}
+
+	skipped := map[string]struct{}{}
+	kept := result.Queue[:0]
+	for _, w := range result.Queue {
+		ns := getNamespace(w)
+		if _, ok := optedOut[ns]; !ok {
+			kept = append(kept, w)
+			continue
+		}
+
+		if _, ok := skipped[ns]; !ok {
+			logger.Info(fmt.Sprintf("Skipping namespace %s: opted out via %s=%s", ns, key, namespaceOptOutValue))
+			skipped[ns] = struct{}{}
+		}
+
+		result.uncount(w.Kind)
+	}
+
+	result.Queue = kept
+	result.Stats.SkippedNamespaces = len(skipped)
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/config_map"
+)
+
+// skipUpToDateWorkItems removes typed workloads whose pods all started after the trust-roots bundle
+// was last updated: their proxies already loaded the overlap, so restarting them gains nothing.
+// Custom resources are always kept since their pods can't be resolved generically.
+func skipUpToDateWorkItems(ctx context.Context, c client.Reader, logger logr.Logger,
+	obj *trv1alpha1.LinkerdTrustRotation, result *Result) error {
+	since, err := config_map.LastModified(ctx, c, obj)
+	if err != nil {
+		return err
+	}
+
+	if since.IsZero() {
+		return nil
+	}
+
+	kept := result.Queue[:0]
+	for _, w := range result.Queue {
+		upToDate, err := workItemStartedAfter(ctx, c, w, since)
+		if err != nil {
+			return err
+		}
+
+		if !upToDate {
+			kept = append(kept, w)
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("Skipping %s %s/%s: all pods started after the trust bundle update at %s",
+			w.Kind, getNamespace(w), getName(w), since.UTC().Format(time.RFC3339)))
+
+		result.uncount(w.Kind)
+		result.Stats.SkippedUpToDate++
+	}
+
+	result.Queue = kept
+
+	return nil
+}
+
+// workItemStartedAfter reports whether the workload has at least one pod and every live pod started after since.
+func workItemStartedAfter(ctx context.Context, c client.Reader, w WorkItem, since time.Time) (bool, error) {
+	selector := podSelector(w)
+	if selector == nil {
+		return false, nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, fmt.Errorf("build selector for %s %s/%s: %w", w.Kind, getNamespace(w), getName(w), err)
+	}
+
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods, client.InNamespace(getNamespace(w)), client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return false, fmt.Errorf("list pods for %s %s/%s: %w", w.Kind, getNamespace(w), getName(w), err)
+	}
+
+	var live int
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if p.DeletionTimestamp != nil {
+			continue
+		}
+
+		if p.Status.StartTime == nil || !p.Status.StartTime.After(since) {
+			return false, nil
+		}
+		live++
+	}
+
+	return live > 0, nil
+}
@@ -0,0 +1,66 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// throttleDefaultDelay is the back-off after a throttled request without a Retry-After.
+	throttleDefaultDelay = time.Second
+	// throttleMaxDelay caps the Retry-After honored for a single back-off.
+	throttleMaxDelay = 30 * time.Second
+	// throttleMaxAttempts bounds how often a throttled patch is retried before its error is returned.
+	throttleMaxAttempts = 5
+)
+
+// RetryAfter returns how long to back off after an API throttling (HTTP 429) error: its Retry-After
+// (capped at throttleMaxDelay) or throttleDefaultDelay. It returns 0 for any other error.
+func RetryAfter(err error) time.Duration {
+	if !apierrors.IsTooManyRequests(err) {
+		return 0
+	}
+
+	if secs, ok := apierrors.SuggestsClientDelay(err); ok && secs > 0 {
+		return min(time.Duration(secs)*time.Second, throttleMaxDelay)
+	}
+
+	return throttleDefaultDelay
+}
+
+// backoffThrottled reports whether err is an API throttling error and, if so, waits for its Retry-After
+// first, so polling loops can simply poll again instead of failing the workload.
+func (m *ManageRollout) backoffThrottled(ctx context.Context, err error) bool {
+	d := RetryAfter(err)
+	if d == 0 {
+		return false
+	}
+
+	m.Logger.Info(fmt.Sprintf("API server throttled the request, retrying in %s: %v", d, err))
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+
+	return true
+}
+
+// patchThrottled patches obj, retrying up to throttleMaxAttempts times while the API server throttles it.
+func (m *ManageRollout) patchThrottled(ctx context.Context, obj client.Object, patch client.Patch) error {
+	var err error
+	for range throttleMaxAttempts {
+		if err = m.Client.Patch(ctx, obj, patch); err == nil || !m.backoffThrottled(ctx, err) {
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,75 @@
+package rollout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestRetryAfter(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want time.Duration
+	}{
+		{"retry-after", apierrors.NewTooManyRequests("slow down", 7), 7 * time.Second},
+		{"no retry-after", apierrors.NewTooManyRequests("slow down", 0), throttleDefaultDelay},
+		{"capped", apierrors.NewTooManyRequests("slow down", 600), throttleMaxDelay},
+		{"wrapped", newError(ErrorKindUnknown, "patch: %w", apierrors.NewTooManyRequests("slow down", 3)), 3 * time.Second},
+		{"other error", errors.New("boom"), 0},
+		{"nil", nil, 0},
+	}
+	for _, tc := range cases {
+		if got := RetryAfter(tc.err); got != tc.want {
+			t.Errorf("%s: RetryAfter = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+
+	if got := KindOf(workItemError(WorkItem{WorkItemDryRun: &WorkItemDryRun{Kind: KindDeployment}, Dep: testDeployment("apps", "web", true, nil)},
+		apierrors.NewTooManyRequests("slow down", 1))); got != ErrorKindThrottled {
+		t.Errorf("KindOf(429) = %s, want Throttled", got)
+	}
+	if got := KindOf(newError(ErrorKindTimeout, "wait: %w", apierrors.NewTooManyRequests("slow down", 1))); got != ErrorKindTimeout {
+		t.Errorf("KindOf(classified 429) = %s, want Timeout", got)
+	}
+}
+
+func TestPatchThrottled(t *testing.T) {
+	scheme := testScheme(t)
+
+	dep := testDeployment("apps", "web", true, nil)
+	throttled := 1
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dep).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if throttled > 0 {
+				throttled--
+				return apierrors.NewTooManyRequests("slow down", 0)
+			}
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+	}).Build()
+	m := New(c, scheme, logr.Discard(), nil)
+
+	orig := dep.DeepCopy()
+	dep.Annotations = map[string]string{"k": "v"}
+	if err := m.patchThrottled(context.Background(), dep, client.MergeFrom(orig)); err != nil {
+		t.Fatalf("throttled patch not retried: %v", err)
+	}
+	if throttled != 0 {
+		t.Errorf("patch was not throttled first")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	throttled = throttleMaxAttempts
+	if err := m.patchThrottled(ctx, dep, client.MergeFrom(orig)); !errors.Is(err, context.Canceled) {
+		t.Errorf("patch under a cancelled context = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,64 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// unschedulableGrace is how long a pod may stay Pending as Unschedulable before the rollout waiting
+// for it is aborted, leaving room for a cluster autoscaler to add capacity.
+const unschedulableGrace = 2 * time.Minute
+
+// podUnschedulable returns an ErrorKindUnschedulable error if p is Pending and the scheduler has reported
+// it Unschedulable for at least unschedulableGrace, naming the pod and the scheduler message.
+func podUnschedulable(p *corev1.Pod, now time.Time) error {
+	if p.DeletionTimestamp != nil || p.Status.Phase != corev1.PodPending {
+		return nil
+	}
+
+	for _, c := range p.Status.Conditions {
+		if c.Type != corev1.PodScheduled || c.Status != corev1.ConditionFalse || c.Reason != corev1.PodReasonUnschedulable {
+			continue
+		}
+
+		if now.Sub(c.LastTransitionTime.Time) < unschedulableGrace {
+			return nil
+		}
+
+		return newError(ErrorKindUnschedulable, "insufficient resources / unschedulable: pod %s/%s pending for %s: %s",
+			p.Namespace, p.Name, now.Sub(c.LastTransitionTime.Time).Round(time.Second), c.Message)
+	}
+
+	return nil
+}
+
+// checkUnschedulable reports the first pod of the workload selector in ns stuck as Unschedulable
+// (see podUnschedulable), so waiters fail fast instead of running into their timeout.
+func (m *ManageRollout) checkUnschedulable(ctx context.Context, ns string, selector *metav1.LabelSelector) error {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return fmt.Errorf("build selector: %w", err)
+	}
+
+	var pods corev1.PodList
+	if err := m.Client.List(ctx, &pods, client.InNamespace(ns), client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		if m.backoffThrottled(ctx, err) {
+			return nil
+		}
+		return fmt.Errorf("list pods in %q: %w", ns, err)
+	}
+
+	now := time.Now()
+	for i := range pods.Items {
+		if err := podUnschedulable(&pods.Items[i], now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package rollout
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodUnschedulable(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	pending := func(reason string, since time.Duration) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "web-1"}}
+		p.Status.Phase = corev1.PodPending
+		p.Status.Conditions = []corev1.PodCondition{{
+			Type:               corev1.PodScheduled,
+			Status:             corev1.ConditionFalse,
+			Reason:             reason,
+			Message:            "0/3 nodes are available: 3 Insufficient cpu.",
+			LastTransitionTime: metav1.NewTime(now.Add(-since)),
+		}}
+		return p
+	}
+
+	if err := podUnschedulable(pending(corev1.PodReasonUnschedulable, time.Minute), now); err != nil {
+		t.Fatalf("pod within the grace period reported: %v", err)
+	}
+
+	err := podUnschedulable(pending(corev1.PodReasonUnschedulable, 5*time.Minute), now)
+	if KindOf(err) != ErrorKindUnschedulable {
+		t.Fatalf("KindOf(%v) = %s, want Unschedulable", err, KindOf(err))
+	}
+
+	if err := podUnschedulable(pending("SchedulingGated", 5*time.Minute), now); err != nil {
+		t.Fatalf("gated pod reported: %v", err)
+	}
+
+	running := pending(corev1.PodReasonUnschedulable, 5*time.Minute)
+	running.Status.Phase = corev1.PodRunning
+	if err := podUnschedulable(running, now); err != nil {
+		t.Fatalf("running pod reported: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package rollout
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// statusTemplateData is exposed to annotation value templates (rollout.verifyPodAnnotation,
+// protection.postRotationTrigger).
+type statusTemplateData struct {
+	CurrentFP  string
+	RotationID string
+}
+
+// verifyPodAnnotation checks that every live pod of the workload carries the annotation value
+// configured in rollout.verifyPodAnnotation. Custom resources are skipped since their pods
+// can't be resolved generically.
+func (m *ManageRollout) verifyPodAnnotation(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, w WorkItem) error {
+	check := obj.Spec.Rollout.VerifyPodAnnotation
+	if check == nil {
+		return nil
+	}
+
+	selector := podSelector(w)
+	if selector == nil {
+		return nil
+	}
+
+	expected, err := expectedPodAnnotation(obj, check.Value)
+	if err != nil {
+		return err
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return fmt.Errorf("build selector for %s %s/%s: %w", w.Kind, getNamespace(w), getName(w), err)
+	}
+
+	var pods corev1.PodList
+	if err := m.Client.List(ctx, &pods, client.InNamespace(getNamespace(w)), client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return fmt.Errorf("list pods for %s %s/%s: %w", w.Kind, getNamespace(w), getName(w), err)
+	}
+
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if p.DeletionTimestamp != nil {
+			continue
+		}
+
+		if v := p.Annotations[check.Key]; v != expected {
+			return newError(ErrorKindCheckFailed, "pod %s/%s of %s %s: annotation %s=%q, expected %q",
+				p.Namespace, p.Name, w.Kind, getName(w), check.Key, v, expected)
+		}
+	}
+
+	return nil
+}
+
+// expectedPodAnnotation renders the expected annotation value against the CR status.
+func expectedPodAnnotation(obj *trv1alpha1.LinkerdTrustRotation, value string) (string, error) {
+	return renderStatusTemplate(obj, "verifyPodAnnotation", value)
+}
+
+// renderStatusTemplate renders the named field's value template against the CR status.
+func renderStatusTemplate(obj *trv1alpha1.LinkerdTrustRotation, name, value string) (string, error) {
+	data := statusTemplateData{RotationID: obj.Status.RotationID, CurrentFP: CurrentFP(obj)}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(value)
+	if err != nil {
+		return "", newError(ErrorKindMisconfigured, "parse %s value %q: %w", name, value, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", newError(ErrorKindMisconfigured, "render %s value %q: %w", name, value, err)
+	}
+
+	return buf.String(), nil
+}
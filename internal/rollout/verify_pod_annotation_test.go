@@ -0,0 +1,61 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func TestVerifyPodAnnotation(t *testing.T) {
+	scheme := testScheme(t)
+
+	dep := &v1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "web"},
+		Spec: v1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+	w := WorkItem{WorkItemDryRun: &WorkItemDryRun{Kind: KindDeployment, Namespace: "apps", Name: "web"}, Dep: dep}
+
+	pod := func(name, rotationID string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "apps",
+			Name:        name,
+			Labels:      map[string]string{"app": "web"},
+			Annotations: map[string]string{rotationIDKey: rotationID},
+		}}
+	}
+
+	obj := &trv1alpha1.LinkerdTrustRotation{}
+	obj.Spec.Rollout.VerifyPodAnnotation = &trv1alpha1.PodAnnotationCheck{Key: rotationIDKey, Value: "{{.RotationID}}"}
+	obj.Status.RotationID = "r2"
+
+	tests := []struct {
+		name    string
+		pods    []*corev1.Pod
+		wantErr bool
+	}{
+		{name: "all match", pods: []*corev1.Pod{pod("web-a", "r2"), pod("web-b", "r2")}},
+		{name: "one stale", pods: []*corev1.Pod{pod("web-a", "r2"), pod("web-b", "r1")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		b := fake.NewClientBuilder().WithScheme(scheme)
+		for _, p := range tt.pods {
+			b = b.WithObjects(p)
+		}
+		m := &ManageRollout{Client: b.Build(), Scheme: scheme, Logger: logr.Discard()}
+
+		err := m.verifyPodAnnotation(context.Background(), obj, w)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
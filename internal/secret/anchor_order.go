@@ -0,0 +1,44 @@
+package secret
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrCurrentAnchorOlder is returned (wrapped) by CheckAnchorOrder when the current trust anchor was issued
+// before the previous one, e.g. because the two secrets were swapped by mistake.
+var ErrCurrentAnchorOlder = errors.New("current trust anchor is older than the previous one")
+
+// CheckAnchorOrder verifies that the root of currentPEM is not older (by notBefore) than the root of
+// previousPEM, so diverged secrets never drive a rotation back to an older anchor.
+func CheckAnchorOrder(currentPEM, previousPEM []byte) error {
+	current, err := anchorCert(currentPEM)
+	if err != nil {
+		return fmt.Errorf("current trust anchor: %w", err)
+	}
+
+	previous, err := anchorCert(previousPEM)
+	if err != nil {
+		return fmt.Errorf("previous trust anchor: %w", err)
+	}
+
+	if current.NotBefore.Before(previous.NotBefore) {
+		return fmt.Errorf("%w: current %q is valid from %s, previous %q from %s", ErrCurrentAnchorOlder,
+			current.Subject.CommonName, current.NotBefore.UTC().Format(time.RFC3339),
+			previous.Subject.CommonName, previous.NotBefore.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// anchorCert returns the root certificate of a PEM bundle, chosen like FingerprintAnchorCert does.
+func anchorCert(pemBytes []byte) (*x509.Certificate, error) {
+	der, err := anchorDER(pemBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return x509.ParseCertificate(der)
+}
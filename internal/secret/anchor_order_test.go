@@ -0,0 +1,29 @@
+package secret
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckAnchorOrder(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	older := issueTestCA(t, "root-2024", nil, now.Add(-48*time.Hour), now.Add(-24*time.Hour)).pem
+	newer := issueTestCA(t, "root-2025", nil, now.Add(-time.Hour), now.Add(23*time.Hour)).pem
+
+	if err := CheckAnchorOrder(newer, older); err != nil {
+		t.Errorf("forward rotation rejected: %v", err)
+	}
+
+	if err := CheckAnchorOrder(older, newer); !errors.Is(err, ErrCurrentAnchorOlder) {
+		t.Errorf("swapped anchors: got %v, want ErrCurrentAnchorOlder", err)
+	}
+
+	if err := CheckAnchorOrder(newer, newer); err != nil {
+		t.Errorf("identical anchors rejected: %v", err)
+	}
+
+	if err := CheckAnchorOrder([]byte("garbage"), older); err == nil {
+		t.Error("invalid current anchor accepted")
+	}
+}
@@ -0,0 +1,56 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+// InspectTrustSecrets is the read-only counterpart of EnsureTrustSecrets: it fingerprints and compares the
+// trust anchor secrets but never bootstraps or repairs the previous secret. A missing previous secret leaves
+// PreviousFP empty and Diverged false.
+func (m *ManageSecret) InspectTrustSecrets(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) (*Result, error) {
+	var err error
+	result := &Result{}
+
+	cNamespaced := types.NamespacedName{Namespace: obj.Spec.Linkerd.Namespace, Name: obj.Spec.Linkerd.TrustAnchorSecret}
+	cSecret := &v1.Secret{}
+	if err := m.Client.Get(ctx, cNamespaced, cSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrCurrentSecretMissing, cNamespaced.String())
+		}
+
+		return nil, err
+	}
+
+	if mode := obj.Spec.Linkerd.SingleSecretMode; mode != nil {
+		return inspectSingleSecret(obj, cSecret, mode)
+	}
+
+	if err := checkCertCount(obj, cNamespaced.String(), cSecret.Data[secretDataKey]); err != nil {
+		return nil, err
+	}
+
+	result.CurrentFP, err = fingerprint(obj, cSecret.Data[secretDataKey])
+	if err != nil {
+		return nil, err
+	}
+	result.CurrentPEM = cSecret.Data[secretDataKey]
+
+	pNamespaced := types.NamespacedName{Namespace: obj.Spec.Linkerd.Namespace, Name: obj.Spec.Linkerd.PreviousTrustAnchorSecret}
+	pSecret := &v1.Secret{}
+	if err := m.Client.Get(ctx, pNamespaced, pSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return result, nil
+		}
+
+		return nil, err
+	}
+
+	return comparePrevious(obj, pNamespaced, cSecret, pSecret, result)
+}
@@ -1,11 +1,11 @@
 package secret
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
-	"crypto/x509"
 	"encoding/hex"
-	"encoding/pem"
+	"errors"
 	"fmt"
 	"time"
 
@@ -20,13 +20,28 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/certutil"
+	"linkerd-trust-rotator.operators.infra/internal/managed"
 )
 
 const (
-	secretAnnotation = "trust-anchor.linkerd.edenlab.io/created"
-	secretDataKey    = "tls.crt"
+	secretAnnotation      = "trust-anchor.linkerd.edenlab.io/created"
+	secretDataKey         = "tls.crt"
+	previousSecretDataKey = "tls-old.crt"
+	// rolledBackSecretSuffix names the secret RestorePrevious saves the replaced current anchor to.
+	rolledBackSecretSuffix = "-rolled-back"
+
+	defaultBootstrapTimeout  = 3 * time.Second
+	bootstrapInitialInterval = 200 * time.Millisecond
+	bootstrapMaxInterval     = time.Second
+
+	defaultCurrentSecretTimeout = 10 * time.Minute
 )
 
+// ErrCurrentSecretMissing is returned (wrapped) by EnsureTrustSecrets when the current trust anchor secret
+// does not exist yet, so callers can wait for it instead of failing.
+var ErrCurrentSecretMissing = errors.New("current trust anchor secret not found")
+
 type ManageSecret struct {
 	Client client.Client
 	Scheme *runtime.Scheme
@@ -42,20 +57,27 @@ func New(c client.Client, s *runtime.Scheme, l logr.Logger) *ManageSecret {
 type Result struct {
 	// CreatedPrevious indicates whether the previous secret was created (bootstrap).
 	CreatedPrevious bool
+	// Bootstrapped is true only when this call created (or recreated) the previous secret.
+	Bootstrapped bool
 	// CurrentFP is the SHA-256 fingerprint of current secret certificate bundle.
 	CurrentFP string
 	// PreviousFP is the SHA-256 fingerprint of previous secret certificate bundle (empty if not available).
 	PreviousFP string
 	// Diverged is true when both fingerprints are available and differ.
 	Diverged bool
+	// CurrentPEM is the raw PEM bundle of the current trust anchor.
+	CurrentPEM []byte
+	// PreviousPEM is the raw PEM bundle of the previous trust anchor (empty if not available).
+	PreviousPEM []byte
 }
 
 // EnsureTrustSecrets validates the current secret, optionally bootstraps the previous secret,
 // and returns fingerprints to drive rotation logic.
-// - current must exist and contain a certificate under one of certKeys (e.g., "tls.crt", "ca.crt").
+// - current must exist (else ErrCurrentSecretMissing) and contain a certificate under one of certKeys (e.g., "tls.crt").
 // - if previous is missing and bootstrapPrevious is true, it is created as a byte-for-byte copy of current.
 // - this function NEVER overwrites an existing previous secret.
 // - fingerprints are computed from all CERTIFICATE PEM blocks by concatenating DER and hashing with SHA-256.
+// - with linkerd.fingerprintMode=Anchor only the self-signed root is fingerprinted.
 func (m *ManageSecret) EnsureTrustSecrets(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) (*Result, error) {
 	var errFP error
 	result := &Result{}
@@ -63,22 +85,31 @@ func (m *ManageSecret) EnsureTrustSecrets(ctx context.Context, obj *trv1alpha1.L
 	cNamespaced := types.NamespacedName{Namespace: obj.Spec.Linkerd.Namespace, Name: obj.Spec.Linkerd.TrustAnchorSecret}
 	cSecret := &v1.Secret{}
 	if err := m.Client.Get(ctx, cNamespaced, cSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrCurrentSecretMissing, cNamespaced.String())
+		}
+
 		return nil, err
 	}
 
+	if mode := obj.Spec.Linkerd.SingleSecretMode; mode != nil {
+		return m.ensureSingleSecret(ctx, obj, cSecret, mode)
+	}
+
 	pNamespaced := types.NamespacedName{Namespace: obj.Spec.Linkerd.Namespace, Name: obj.Spec.Linkerd.PreviousTrustAnchorSecret}
 	pSecret := &v1.Secret{}
 	if err := m.Client.Get(ctx, pNamespaced, pSecret); err != nil {
 		if apierrors.IsNotFound(err) && obj.Spec.Linkerd.BootstrapPreviousSecret {
-			if err := m.bootstrapPreviousSecrets(ctx, cSecret, obj); err != nil {
-				return nil, err
-			}
-
 			var (
-				duration = 3 * time.Second
+				duration = bootstrapTimeout(obj)
 				interval = 1 * time.Second
 			)
 
+			created, err := m.bootstrapPreviousSecrets(ctx, cSecret, obj, duration)
+			if err != nil {
+				return nil, err
+			}
+
 			for start := time.Now(); ; {
 				if ctx.Err() != nil {
 					return nil, ctx.Err()
@@ -99,26 +130,66 @@ func (m *ManageSecret) EnsureTrustSecrets(ctx context.Context, obj *trv1alpha1.L
 				time.Sleep(interval)
 			}
 
-			m.Logger.Info(fmt.Sprintf("bootstrapped previous secret from %s", cSecret.Name))
+			if created {
+				m.Logger.Info(fmt.Sprintf("bootstrapped previous secret from %s", cSecret.Name))
+				result.Bootstrapped = true
+			}
 		} else {
 			return nil, err
 		}
 	}
 
-	result.CurrentFP, errFP = fingerprintPEMCerts(cSecret.Data[secretDataKey])
+	if err := checkCertCount(obj, cNamespaced.String(), cSecret.Data[secretDataKey]); err != nil {
+		return nil, err
+	}
+
+	result.CurrentFP, errFP = fingerprint(obj, cSecret.Data[secretDataKey])
 	if errFP != nil {
 		return nil, errFP
 	}
 
-	result.PreviousFP, errFP = fingerprintPEMCerts(pSecret.Data[secretDataKey])
+	pErr := checkCertCount(obj, pNamespaced.String(), pSecret.Data[secretDataKey])
+	if pErr == nil {
+		_, pErr = fingerprint(obj, pSecret.Data[secretDataKey])
+	}
+	if pErr != nil && rebootstrapInvalid(obj) {
+		m.Logger.Info(fmt.Sprintf("previous secret %s is invalid (%v), recreating from %s", pNamespaced.String(), pErr, cSecret.Name))
+
+		if err := m.rebootstrapPreviousSecret(ctx, cSecret, pSecret, managed.Labels(obj)); err != nil {
+			return nil, err
+		}
+		result.Bootstrapped = true
+	}
+
+	return comparePrevious(obj, pNamespaced, cSecret, pSecret, result)
+}
+
+// comparePrevious fingerprints the previous secret into result and compares it with the current one.
+func comparePrevious(obj *trv1alpha1.LinkerdTrustRotation, pNamespaced types.NamespacedName, cSecret, pSecret *v1.Secret,
+	result *Result) (*Result, error) {
+	var errFP error
+	if err := checkCertCount(obj, pNamespaced.String(), pSecret.Data[secretDataKey]); err != nil {
+		return nil, err
+	}
+
+	result.PreviousFP, errFP = fingerprint(obj, pSecret.Data[secretDataKey])
 	if errFP != nil {
 		return nil, errFP
 	}
 
+	result.CurrentPEM = cSecret.Data[secretDataKey]
+	result.PreviousPEM = pSecret.Data[secretDataKey]
+
 	if pSecret.Annotations[secretAnnotation] == "true" {
 		result.CreatedPrevious = true
 	}
 
+	// In anchor mode only the root matters, so a reordered chain or a changed intermediate is no divergence.
+	if obj.Spec.Linkerd.FingerprintMode == trv1alpha1.FingerprintModeAnchor {
+		result.Diverged = result.CurrentFP != result.PreviousFP
+		return result, nil
+	}
+
 	// cmp options:
 	// - EquateEmpty: treat nil and empty maps as equal.
 	// - SortMaps is not required for map[string][]byte; cmp handles map order.
@@ -127,7 +198,125 @@ func (m *ManageSecret) EnsureTrustSecrets(ctx context.Context, obj *trv1alpha1.L
 	return result, nil
 }
 
-func fingerprintPEMCerts(pemBytes []byte) (string, error) {
+// ensureSingleSecret is inspectSingleSecret for EnsureTrustSecrets: like the previous secret in two-secret mode,
+// a missing previous key is first seeded from the current one when linkerd.bootstrapPreviousSecret is set,
+// and an invalid one too with bootstrapMode=IfMissingOrInvalid.
+func (m *ManageSecret) ensureSingleSecret(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, secret *v1.Secret,
+	mode *trv1alpha1.SingleSecretModeSpec) (*Result, error) {
+	_, previousKey := singleSecretKeys(mode)
+	pName := fmt.Sprintf("%s/%s[%s]", secret.Namespace, secret.Name, previousKey)
+	pData := secret.Data[previousKey]
+
+	var pErr error
+	if len(pData) > 0 {
+		if pErr = checkCertCount(obj, pName, pData); pErr == nil {
+			_, pErr = fingerprint(obj, pData)
+		}
+	}
+
+	seed := (len(pData) == 0 && obj.Spec.Linkerd.BootstrapPreviousSecret) || (pErr != nil && rebootstrapInvalid(obj))
+	if !seed {
+		if len(pData) == 0 {
+			m.Logger.Info(fmt.Sprintf("previous key %s is missing and linkerd.bootstrapPreviousSecret is off, "+
+				"so no divergence can be detected", pName))
+		}
+
+		return inspectSingleSecret(obj, secret, mode)
+	}
+
+	// Validate the current anchor before copying it.
+	probe := secret.DeepCopy()
+	delete(probe.Data, previousKey)
+	result, err := inspectSingleSecret(obj, probe, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	if pErr != nil {
+		m.Logger.Info(fmt.Sprintf("previous key %s is invalid (%v), recreating it from the current anchor", pName, pErr))
+	}
+	if err := m.seedPreviousKey(ctx, secret, previousKey, result.CurrentPEM); err != nil {
+		return nil, err
+	}
+
+	m.Logger.Info(fmt.Sprintf("bootstrapped previous key %s from the current anchor", pName))
+	result.PreviousFP, result.PreviousPEM, result.Bootstrapped = result.CurrentFP, result.CurrentPEM, true
+
+	return result, nil
+}
+
+// inspectSingleSecret computes fingerprints of both anchors stored in one secret under separate keys.
+// A missing or empty previous key leaves PreviousFP empty and Diverged false.
+func inspectSingleSecret(obj *trv1alpha1.LinkerdTrustRotation, secret *v1.Secret, mode *trv1alpha1.SingleSecretModeSpec) (*Result, error) {
+	var err error
+	result := &Result{}
+	currentKey, previousKey := singleSecretKeys(mode)
+
+	cData, ok := secret.Data[currentKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s has no key %q", secret.Namespace, secret.Name, currentKey)
+	}
+
+	if err := checkCertCount(obj, fmt.Sprintf("%s/%s[%s]", secret.Namespace, secret.Name, currentKey), cData); err != nil {
+		return nil, err
+	}
+
+	result.CurrentFP, err = fingerprint(obj, cData)
+	if err != nil {
+		return nil, err
+	}
+	result.CurrentPEM = cData
+
+	pData := secret.Data[previousKey]
+	if len(pData) == 0 {
+		return result, nil
+	}
+
+	if err := checkCertCount(obj, fmt.Sprintf("%s/%s[%s]", secret.Namespace, secret.Name, previousKey), pData); err != nil {
+		return nil, err
+	}
+
+	result.PreviousFP, err = fingerprint(obj, pData)
+	if err != nil {
+		return nil, err
+	}
+	result.PreviousPEM = pData
+
+	result.Diverged = result.CurrentFP != result.PreviousFP
+
+	return result, nil
+}
+
+// seedPreviousKey stores data under the previous key of a single secret holding both anchors. The secret
+// belongs to whoever issues the current anchor, so it is not labeled as managed.
+func (m *ManageSecret) seedPreviousKey(ctx context.Context, secret *v1.Secret, previousKey string, data []byte) error {
+	orig := secret.DeepCopy()
+	secret.Data[previousKey] = append([]byte(nil), data...)
+
+	if err := m.Client.Patch(ctx, secret, client.MergeFrom(orig)); err != nil {
+		return fmt.Errorf("seed previous key %q of secret %s/%s: %w", previousKey, secret.Namespace, secret.Name, err)
+	}
+
+	return nil
+}
+
+// singleSecretKeys resolves the current/previous keys for single-secret mode, applying defaults.
+func singleSecretKeys(mode *trv1alpha1.SingleSecretModeSpec) (string, string) {
+	currentKey, previousKey := secretDataKey, previousSecretDataKey
+	if len(mode.CurrentKey) > 0 {
+		currentKey = mode.CurrentKey
+	}
+
+	if len(mode.PreviousKey) > 0 {
+		previousKey = mode.PreviousKey
+	}
+
+	return currentKey, previousKey
+}
+
+// FingerprintPEMCerts returns the SHA-256 fingerprint ("sha256:<hex>") of all certificates in a bundle. PEM is
+// expected, but raw DER and PKCS#7 are accepted too.
+func FingerprintPEMCerts(pemBytes []byte) (string, error) {
 	der, err := concatDER(pemBytes)
 	if err != nil {
 		return "", err
@@ -136,34 +325,94 @@ func fingerprintPEMCerts(pemBytes []byte) (string, error) {
 	return "sha256:" + hex.EncodeToString(sum[:]), nil
 }
 
-func concatDER(pemBytes []byte) ([]byte, error) {
-	var out []byte
-	in := pemBytes
-	for {
-		block, rest := pem.Decode(in)
-		if block == nil {
-			break
-		}
-		in = rest
-		if block.Type != "CERTIFICATE" {
-			continue
+// FingerprintAnchorCert returns the SHA-256 fingerprint ("sha256:<hex>") of the root certificate in a PEM bundle:
+// the first self-signed certificate, or else the first CA whose subject equals its issuer.
+func FingerprintAnchorCert(pemBytes []byte) (string, error) {
+	der, err := anchorDER(pemBytes)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// fingerprint fingerprints pemBytes according to the CR's fingerprint mode.
+func fingerprint(obj *trv1alpha1.LinkerdTrustRotation, pemBytes []byte) (string, error) {
+	if obj.Spec.Linkerd.FingerprintMode == trv1alpha1.FingerprintModeAnchor {
+		return FingerprintAnchorCert(pemBytes)
+	}
+
+	return FingerprintPEMCerts(pemBytes)
+}
+
+// checkCertCount rejects a certificate bundle whose number of certificates is outside linkerd.minCerts/maxCerts.
+func checkCertCount(obj *trv1alpha1.LinkerdTrustRotation, name string, pemBytes []byte) error {
+	minCerts, maxCerts := obj.Spec.Linkerd.MinCerts, obj.Spec.Linkerd.MaxCerts
+	if minCerts <= 0 && maxCerts <= 0 {
+		return nil
+	}
+
+	certs, err := certutil.Parse(pemBytes)
+	if err != nil {
+		return fmt.Errorf("secret %s: %w", name, err)
+	}
+	n := int32(len(certs))
+
+	if minCerts > 0 && n < minCerts {
+		return fmt.Errorf("secret %s holds %d certificate(s), want at least %d (linkerd.minCerts)", name, n, minCerts)
+	}
+	if maxCerts > 0 && n > maxCerts {
+		return fmt.Errorf("secret %s holds %d certificate(s), want at most %d (linkerd.maxCerts)", name, n, maxCerts)
+	}
+
+	return nil
+}
+
+// anchorDER returns the DER of the root certificate in a PEM, DER or PKCS#7 bundle.
+func anchorDER(pemBytes []byte) ([]byte, error) {
+	certs, err := certutil.Parse(pemBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range certs {
+		if bytes.Equal(c.RawSubject, c.RawIssuer) && c.CheckSignatureFrom(c) == nil {
+			return c.Raw, nil
 		}
-		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
-			return nil, fmt.Errorf("invalid certificate in PEM: %w", err)
+	}
+	for _, c := range certs {
+		if c.IsCA && bytes.Equal(c.RawSubject, c.RawIssuer) {
+			return c.Raw, nil
 		}
-		out = append(out, block.Bytes...)
 	}
-	if len(out) == 0 {
-		return nil, fmt.Errorf("no CERTIFICATE blocks found")
+
+	return nil, fmt.Errorf("no self-signed root certificate found")
+}
+
+// concatDER concatenates the DER of all certificates in a PEM, DER or PKCS#7 bundle, in order.
+func concatDER(pemBytes []byte) ([]byte, error) {
+	certs, err := certutil.Parse(pemBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	for _, c := range certs {
+		out = append(out, c.Raw...)
 	}
 	return out, nil
 }
 
-func (m *ManageSecret) bootstrapPreviousSecrets(ctx context.Context, cSecret *v1.Secret, obj *trv1alpha1.LinkerdTrustRotation) error {
+// bootstrapPreviousSecrets creates the previous secret as a copy of cSecret and reports whether this call
+// created it. Transient API errors are retried with backoff until timeout; AlreadyExists is accepted
+// when the concurrently created secret holds the same certificate.
+func (m *ManageSecret) bootstrapPreviousSecrets(ctx context.Context, cSecret *v1.Secret, obj *trv1alpha1.LinkerdTrustRotation,
+	timeout time.Duration) (bool, error) {
 	previousSecret := &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      obj.Spec.Linkerd.PreviousTrustAnchorSecret,
 			Namespace: obj.Spec.Linkerd.Namespace,
+			Labels:    managed.Labels(obj),
 			Annotations: map[string]string{
 				secretAnnotation: "true",
 			},
@@ -172,7 +421,251 @@ func (m *ManageSecret) bootstrapPreviousSecrets(ctx context.Context, cSecret *v1
 		Data: cSecret.Data,
 	}
 
-	return m.Client.Create(ctx, previousSecret)
+	if obj.Spec.Linkerd.OwnBootstrappedSecret {
+		owned, err := managed.SetOwner(obj, previousSecret, m.Scheme, false)
+		if err != nil {
+			return false, fmt.Errorf("set owner of secret %s/%s: %w", previousSecret.Namespace, previousSecret.Name, err)
+		}
+
+		if !owned {
+			m.Logger.Info(fmt.Sprintf("not owning secret %s/%s: the CR is in another namespace",
+				previousSecret.Namespace, previousSecret.Name))
+		}
+	}
+
+	key := client.ObjectKeyFromObject(previousSecret)
+	interval := bootstrapInitialInterval
+	for start := time.Now(); ; {
+		err := m.Client.Create(ctx, previousSecret.DeepCopy())
+		switch {
+		case err == nil:
+			return true, nil
+		case apierrors.IsAlreadyExists(err):
+			// Another actor (e.g. a second operator replica) won the race; accept its copy if it matches.
+			return false, m.verifyConcurrentBootstrap(ctx, key, cSecret)
+		case !isTransient(err):
+			return false, fmt.Errorf("create previous secret %s: %w", key.String(), err)
+		}
+
+		if time.Since(start) >= timeout {
+			return false, fmt.Errorf("create previous secret %s: giving up after %s: %w", key.String(), timeout, err)
+		}
+
+		m.Logger.Info(fmt.Sprintf("creating previous secret %s failed (%v), retrying in %s", key.String(), err, interval))
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(interval):
+		}
+		interval = min(2*interval, bootstrapMaxInterval)
+	}
+}
+
+// verifyConcurrentBootstrap checks that a previous secret created concurrently by someone else
+// holds the certificate we intended to bootstrap from cSecret.
+func (m *ManageSecret) verifyConcurrentBootstrap(ctx context.Context, key types.NamespacedName, cSecret *v1.Secret) error {
+	existing := &v1.Secret{}
+	if err := m.Client.Get(ctx, key, existing); err != nil {
+		return fmt.Errorf("get concurrently created previous secret %s: %w", key.String(), err)
+	}
+
+	if !bytes.Equal(existing.Data[secretDataKey], cSecret.Data[secretDataKey]) {
+		return fmt.Errorf("previous secret %s was created concurrently with a different certificate than %s",
+			key.String(), cSecret.Name)
+	}
+
+	m.Logger.Info(fmt.Sprintf("previous secret %s was bootstrapped concurrently, reusing it", key.String()))
+	return nil
+}
+
+// isTransient reports whether an API error is worth retrying.
+func isTransient(err error) bool {
+	return apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) || apierrors.IsInternalError(err)
+}
+
+// bootstrapTimeout returns linkerd.bootstrapTimeout, or the default when unset.
+func bootstrapTimeout(obj *trv1alpha1.LinkerdTrustRotation) time.Duration {
+	if d := obj.Spec.Linkerd.BootstrapTimeout; d != nil && d.Duration > 0 {
+		return d.Duration
+	}
+
+	return defaultBootstrapTimeout
+}
+
+// CurrentSecretTimeout returns linkerd.currentSecretTimeout, or the default when unset.
+func CurrentSecretTimeout(obj *trv1alpha1.LinkerdTrustRotation) time.Duration {
+	if d := obj.Spec.Linkerd.CurrentSecretTimeout; d != nil && d.Duration > 0 {
+		return d.Duration
+	}
+
+	return defaultCurrentSecretTimeout
+}
+
+// rebootstrapInvalid reports whether an existing but invalid previous secret may be recreated.
+func rebootstrapInvalid(obj *trv1alpha1.LinkerdTrustRotation) bool {
+	return obj.Spec.Linkerd.BootstrapPreviousSecret &&
+		obj.Spec.Linkerd.BootstrapMode == trv1alpha1.BootstrapModeIfMissingOrInvalid
+}
+
+// rebootstrapPreviousSecret overwrites the data of an invalid previous secret with the current one.
+// Callers must only use it after the previous secret failed certificate validation.
+func (m *ManageSecret) rebootstrapPreviousSecret(ctx context.Context, cSecret, pSecret *v1.Secret, extraLabels map[string]string) error {
+	orig := pSecret.DeepCopy()
+	pSecret.Labels = managed.MergeLabels(pSecret.Labels, extraLabels)
+	if pSecret.Annotations == nil {
+		pSecret.Annotations = map[string]string{}
+	}
+
+	pSecret.Annotations[secretAnnotation] = "true"
+	pSecret.Data = make(map[string][]byte, len(cSecret.Data))
+	for k, v := range cSecret.Data {
+		pSecret.Data[k] = append([]byte(nil), v...)
+	}
+
+	if err := m.Client.Patch(ctx, pSecret, client.MergeFrom(orig)); err != nil {
+		return fmt.Errorf("recreate previous secret %s/%s: %w", pSecret.Namespace, pSecret.Name, err)
+	}
+
+	return nil
+}
+
+// FingerprintSecret reads the secret and fingerprints the certificates under dataKey (default "tls.crt").
+// It never mutates the cluster, so it is safe for diagnostics.
+func (m *ManageSecret) FingerprintSecret(ctx context.Context, key types.NamespacedName, dataKey string) (string, error) {
+	if len(dataKey) == 0 {
+		dataKey = secretDataKey
+	}
+
+	secret := &v1.Secret{}
+	if err := m.Client.Get(ctx, key, secret); err != nil {
+		return "", fmt.Errorf("get secret %s: %w", key.String(), err)
+	}
+
+	data, ok := secret.Data[dataKey]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no key %q", key.String(), dataKey)
+	}
+
+	return FingerprintPEMCerts(data)
+}
+
+// CleanupPrevious drops the previous trust anchor once the rotation is finished.
+// In single-secret mode the previous key is overwritten with the current anchor,
+// otherwise the previous secret is deleted (and re-bootstrapped on the next reconcile).
+func (m *ManageSecret) CleanupPrevious(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) error {
+	mode := obj.Spec.Linkerd.SingleSecretMode
+	if mode == nil {
+		return m.DeleteSecrets(ctx, obj, obj.Spec.Linkerd.PreviousTrustAnchorSecret)
+	}
+
+	currentKey, previousKey := singleSecretKeys(mode)
+	key := types.NamespacedName{Namespace: obj.Spec.Linkerd.Namespace, Name: obj.Spec.Linkerd.TrustAnchorSecret}
+	secret := &v1.Secret{}
+	if err := m.Client.Get(ctx, key, secret); err != nil {
+		return fmt.Errorf("get secret %s: %w", key.String(), err)
+	}
+
+	// Without the current key there is nothing to sync to; the previous anchor is kept.
+	current, ok := secret.Data[currentKey]
+	if !ok || len(current) == 0 {
+		return fmt.Errorf("sync key %q of secret %s: current key %q is missing", previousKey, key.String(), currentKey)
+	}
+
+	orig := secret.DeepCopy()
+	secret.Data[previousKey] = current
+	if err := m.Client.Patch(ctx, secret, client.MergeFrom(orig)); err != nil {
+		return fmt.Errorf("sync key %q of secret %s: %w", previousKey, key.String(), err)
+	}
+
+	m.Logger.Info(fmt.Sprintf("Synced previous key %q with current key %q in secret %s", previousKey, currentKey, key.String()))
+	return nil
+}
+
+// RolledBackSecretName returns the name of the secret RestorePrevious saves the replaced current anchor to.
+func RolledBackSecretName(obj *trv1alpha1.LinkerdTrustRotation) string {
+	return obj.Spec.Linkerd.TrustAnchorSecret + rolledBackSecretSuffix
+}
+
+// RestorePrevious makes the previous trust anchor current again by copying the previous secret's data
+// (certificate and key) over the current secret. The replaced data is first saved to the
+// RolledBackSecretName secret, so the new anchor can be put back once the cause of the rollback is fixed.
+// Single-secret mode keeps no previous key, so it is refused.
+func (m *ManageSecret) RestorePrevious(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) error {
+	if obj.Spec.Linkerd.SingleSecretMode != nil {
+		return fmt.Errorf("restoring the previous trust anchor is not supported in single-secret mode")
+	}
+
+	namespace := obj.Spec.Linkerd.Namespace
+	pKey := types.NamespacedName{Namespace: namespace, Name: obj.Spec.Linkerd.PreviousTrustAnchorSecret}
+	pSecret := &v1.Secret{}
+	if err := m.Client.Get(ctx, pKey, pSecret); err != nil {
+		return fmt.Errorf("get secret %s: %w", pKey.String(), err)
+	}
+
+	cKey := types.NamespacedName{Namespace: namespace, Name: obj.Spec.Linkerd.TrustAnchorSecret}
+	cSecret := &v1.Secret{}
+	if err := m.Client.Get(ctx, cKey, cSecret); err != nil {
+		return fmt.Errorf("get secret %s: %w", cKey.String(), err)
+	}
+
+	// A repeated restore finds the previous anchor already current; the saved new anchor must survive it.
+	if !bytes.Equal(cSecret.Data[secretDataKey], pSecret.Data[secretDataKey]) {
+		if err := m.saveRolledBack(ctx, obj, cSecret); err != nil {
+			return err
+		}
+	}
+
+	orig := cSecret.DeepCopy()
+	cSecret.Data = make(map[string][]byte, len(pSecret.Data))
+	for k, v := range pSecret.Data {
+		cSecret.Data[k] = append([]byte(nil), v...)
+	}
+
+	if err := m.Client.Patch(ctx, cSecret, client.MergeFrom(orig)); err != nil {
+		return fmt.Errorf("restore secret %s from %s: %w", cKey.String(), pKey.String(), err)
+	}
+
+	m.Logger.Info(fmt.Sprintf("Restored trust anchor secret %s from %s", cKey.String(), pKey.String()))
+	return nil
+}
+
+// saveRolledBack copies the data of the current secret to the RolledBackSecretName secret, replacing
+// the copy of an earlier rollback.
+func (m *ManageSecret) saveRolledBack(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, cSecret *v1.Secret) error {
+	backup := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        RolledBackSecretName(obj),
+			Namespace:   cSecret.Namespace,
+			Labels:      managed.Labels(obj),
+			Annotations: map[string]string{secretAnnotation: "true"},
+		},
+		Type: cSecret.Type,
+		Data: make(map[string][]byte, len(cSecret.Data)),
+	}
+	for k, v := range cSecret.Data {
+		backup.Data[k] = append([]byte(nil), v...)
+	}
+
+	key := client.ObjectKeyFromObject(backup)
+	err := m.Client.Create(ctx, backup.DeepCopy())
+	if apierrors.IsAlreadyExists(err) {
+		existing := &v1.Secret{}
+		if err := m.Client.Get(ctx, key, existing); err != nil {
+			return fmt.Errorf("get secret %s: %w", key.String(), err)
+		}
+
+		orig := existing.DeepCopy()
+		existing.Data = backup.Data
+		err = m.Client.Patch(ctx, existing, client.MergeFrom(orig))
+	}
+	if err != nil {
+		return fmt.Errorf("save trust anchor secret %s/%s to %s: %w", cSecret.Namespace, cSecret.Name, key.String(), err)
+	}
+
+	m.Logger.Info(fmt.Sprintf("Saved trust anchor secret %s/%s to %s", cSecret.Namespace, cSecret.Name, key.String()))
+	return nil
 }
 
 func (m *ManageSecret) DeleteSecrets(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, name string) error {
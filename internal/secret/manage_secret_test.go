@@ -0,0 +1,618 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+const (
+	testNamespace      = "linkerd"
+	testCurrentSecret  = "linkerd-trust-anchor"
+	testPreviousSecret = "linkerd-previous-anchor"
+)
+
+// testCA is a test CA certificate with its key, for signing further certificates.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+// issueTestCA creates a CA certificate valid from notBefore to notAfter, signed by parent or self-signed
+// if parent is nil.
+func issueTestCA(t *testing.T, cn string, parent *testCA, notBefore, notAfter time.Time) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+
+	signerCert, signerKey := tmpl, key
+	if parent != nil {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signerCert, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// newTestCA creates a CA certificate valid for the hour around now, signed by parent or self-signed if parent is nil.
+func newTestCA(t *testing.T, cn string, parent *testCA) *testCA {
+	t.Helper()
+
+	return issueTestCA(t, cn, parent, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+}
+
+// selfSignedPEM returns the PEM of a self-signed CA certificate valid for the hour around now.
+func selfSignedPEM(t *testing.T, cn string) []byte {
+	t.Helper()
+
+	return newTestCA(t, cn, nil).pem
+}
+
+func testSecret(name string, crt []byte) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name},
+		Type:       v1.SecretTypeTLS,
+		Data:       map[string][]byte{secretDataKey: crt},
+	}
+}
+
+func testLTR(mode trv1alpha1.BootstrapMode) *trv1alpha1.LinkerdTrustRotation {
+	return &trv1alpha1.LinkerdTrustRotation{
+		Spec: trv1alpha1.LinkerdTrustRotationSpec{
+			Linkerd: trv1alpha1.LinkerdSpec{
+				Namespace:                 testNamespace,
+				TrustAnchorSecret:         testCurrentSecret,
+				PreviousTrustAnchorSecret: testPreviousSecret,
+				BootstrapPreviousSecret:   true,
+				BootstrapMode:             mode,
+			},
+		},
+	}
+}
+
+func TestEnsureTrustSecretsBootstrap(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	current := selfSignedPEM(t, "current")
+	previous := selfSignedPEM(t, "previous")
+	garbage := []byte("not a certificate")
+
+	tests := []struct {
+		name         string
+		mode         trv1alpha1.BootstrapMode
+		previous     *v1.Secret
+		wantPrevious []byte
+		wantCreated  bool
+		wantDiverged bool
+		wantErr      bool
+	}{
+		{
+			name:         "missing previous is bootstrapped from current",
+			mode:         trv1alpha1.BootstrapModeIfMissing,
+			wantPrevious: current,
+			wantCreated:  true,
+		},
+		{
+			name:         "valid previous is never overwritten",
+			mode:         trv1alpha1.BootstrapModeIfMissingOrInvalid,
+			previous:     testSecret(testPreviousSecret, previous),
+			wantPrevious: previous,
+			wantDiverged: true,
+		},
+		{
+			name:     "invalid previous is kept in IfMissing mode",
+			mode:     trv1alpha1.BootstrapModeIfMissing,
+			previous: testSecret(testPreviousSecret, garbage),
+			wantErr:  true,
+		},
+		{
+			name:         "invalid previous is recreated in IfMissingOrInvalid mode",
+			mode:         trv1alpha1.BootstrapModeIfMissingOrInvalid,
+			previous:     testSecret(testPreviousSecret, garbage),
+			wantPrevious: current,
+			wantCreated:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := []client.Object{testSecret(testCurrentSecret, current)}
+			if tt.previous != nil {
+				objects = append(objects, tt.previous)
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+			result, err := New(c, scheme, logr.Discard()).EnsureTrustSecrets(context.Background(), testLTR(tt.mode))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("EnsureTrustSecrets() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EnsureTrustSecrets() error = %v", err)
+			}
+
+			if result.CreatedPrevious != tt.wantCreated {
+				t.Errorf("CreatedPrevious = %v, want %v", result.CreatedPrevious, tt.wantCreated)
+			}
+
+			if result.Bootstrapped != tt.wantCreated {
+				t.Errorf("Bootstrapped = %v, want %v", result.Bootstrapped, tt.wantCreated)
+			}
+
+			if result.Diverged != tt.wantDiverged {
+				t.Errorf("Diverged = %v, want %v", result.Diverged, tt.wantDiverged)
+			}
+
+			var got v1.Secret
+			key := types.NamespacedName{Namespace: testNamespace, Name: testPreviousSecret}
+			if err := c.Get(context.Background(), key, &got); err != nil {
+				t.Fatalf("get previous secret: %v", err)
+			}
+
+			if !bytes.Equal(got.Data[secretDataKey], tt.wantPrevious) {
+				t.Errorf("previous secret data does not match the expected certificate")
+			}
+		})
+	}
+}
+
+func TestEnsureTrustSecretsSingleSecretBootstrap(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	current := selfSignedPEM(t, "current")
+	previous := selfSignedPEM(t, "previous")
+	garbage := []byte("not a certificate")
+
+	tests := []struct {
+		name         string
+		bootstrap    bool
+		mode         trv1alpha1.BootstrapMode
+		previous     []byte
+		wantPrevious []byte
+		wantSeeded   bool
+		wantDiverged bool
+		wantErr      bool
+	}{
+		{
+			name:         "missing previous key is seeded from current",
+			bootstrap:    true,
+			mode:         trv1alpha1.BootstrapModeIfMissing,
+			wantPrevious: current,
+			wantSeeded:   true,
+		},
+		{
+			name:      "missing previous key is left alone without bootstrap",
+			bootstrap: false,
+			mode:      trv1alpha1.BootstrapModeIfMissing,
+		},
+		{
+			name:         "valid previous key is never overwritten",
+			bootstrap:    true,
+			mode:         trv1alpha1.BootstrapModeIfMissingOrInvalid,
+			previous:     previous,
+			wantPrevious: previous,
+			wantDiverged: true,
+		},
+		{
+			name:      "invalid previous key is kept in IfMissing mode",
+			bootstrap: true,
+			mode:      trv1alpha1.BootstrapModeIfMissing,
+			previous:  garbage,
+			wantErr:   true,
+		},
+		{
+			name:         "invalid previous key is recreated in IfMissingOrInvalid mode",
+			bootstrap:    true,
+			mode:         trv1alpha1.BootstrapModeIfMissingOrInvalid,
+			previous:     garbage,
+			wantPrevious: current,
+			wantSeeded:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := testSecret(testCurrentSecret, current)
+			if tt.previous != nil {
+				secret.Data[previousSecretDataKey] = tt.previous
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+
+			obj := testLTR(tt.mode)
+			obj.Spec.Linkerd.BootstrapPreviousSecret = tt.bootstrap
+			obj.Spec.Linkerd.SingleSecretMode = &trv1alpha1.SingleSecretModeSpec{}
+			result, err := New(c, scheme, logr.Discard()).EnsureTrustSecrets(context.Background(), obj)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("EnsureTrustSecrets() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EnsureTrustSecrets() error = %v", err)
+			}
+
+			if result.Bootstrapped != tt.wantSeeded {
+				t.Errorf("Bootstrapped = %v, want %v", result.Bootstrapped, tt.wantSeeded)
+			}
+			if result.Diverged != tt.wantDiverged {
+				t.Errorf("Diverged = %v, want %v", result.Diverged, tt.wantDiverged)
+			}
+			if tt.wantPrevious != nil && result.PreviousFP == "" {
+				t.Error("PreviousFP empty, want the fingerprint of the previous key")
+			}
+
+			var got v1.Secret
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(secret), &got); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got.Data[previousSecretDataKey], tt.wantPrevious) {
+				t.Errorf("previous key holds %q, want the expected certificate", got.Data[previousSecretDataKey])
+			}
+			if !bytes.Equal(got.Data[secretDataKey], current) {
+				t.Error("current key modified")
+			}
+		})
+	}
+}
+
+func TestBootstrapPreviousSecretsRetriesAndRaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	current := selfSignedPEM(t, "current")
+	other := selfSignedPEM(t, "other")
+	gr := schema.GroupResource{Resource: "secrets"}
+
+	tests := []struct {
+		name        string
+		failures    []error
+		raced       []byte
+		wantCreated bool
+		wantErr     bool
+	}{
+		{
+			name:        "transient errors are retried",
+			failures:    []error{apierrors.NewServiceUnavailable("busy"), apierrors.NewTooManyRequests("slow down", 0)},
+			wantCreated: true,
+		},
+		{
+			name:     "permanent errors are not retried",
+			failures: []error{apierrors.NewForbidden(gr, testPreviousSecret, errors.New("denied"))},
+			wantErr:  true,
+		},
+		{
+			name:  "a matching concurrent copy is reused",
+			raced: current,
+		},
+		{
+			name:    "a different concurrent copy is rejected",
+			raced:   other,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures := tt.failures
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(testSecret(testCurrentSecret, current)).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						if len(failures) > 0 {
+							err := failures[0]
+							failures = failures[1:]
+							return err
+						}
+						if tt.raced != nil {
+							// Another replica creates the secret just before us.
+							if err := c.Create(ctx, testSecret(testPreviousSecret, tt.raced)); err != nil {
+								return err
+							}
+						}
+
+						return c.Create(ctx, obj, opts...)
+					},
+				}).Build()
+
+			obj := testLTR(trv1alpha1.BootstrapModeIfMissing)
+			obj.Spec.Linkerd.BootstrapTimeout = &metav1.Duration{Duration: 5 * time.Second}
+
+			result, err := New(c, scheme, logr.Discard()).EnsureTrustSecrets(context.Background(), obj)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("EnsureTrustSecrets() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EnsureTrustSecrets() error = %v", err)
+			}
+
+			if result.Bootstrapped != tt.wantCreated {
+				t.Errorf("Bootstrapped = %v, want %v", result.Bootstrapped, tt.wantCreated)
+			}
+			if result.Diverged {
+				t.Error("Diverged = true, want false right after bootstrap")
+			}
+		})
+	}
+}
+
+func TestRestorePrevious(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	oldAnchor, newAnchor := selfSignedPEM(t, "old"), selfSignedPEM(t, "new")
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		testSecret(testCurrentSecret, newAnchor),
+		testSecret(testPreviousSecret, oldAnchor),
+	).Build()
+	m := New(c, scheme, logr.Discard())
+
+	obj := testLTR(trv1alpha1.BootstrapModeIfMissing)
+	if err := m.RestorePrevious(context.Background(), obj); err != nil {
+		t.Fatalf("RestorePrevious() error = %v", err)
+	}
+
+	var cur v1.Secret
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testCurrentSecret}, &cur); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(cur.Data[secretDataKey], oldAnchor) {
+		t.Error("current secret does not hold the previous anchor after restore")
+	}
+
+	// The replaced new anchor is kept, also across a repeated restore.
+	for range 2 {
+		var saved v1.Secret
+		key := types.NamespacedName{Namespace: testNamespace, Name: RolledBackSecretName(obj)}
+		if err := c.Get(context.Background(), key, &saved); err != nil {
+			t.Fatalf("get rolled-back secret: %v", err)
+		}
+		if !bytes.Equal(saved.Data[secretDataKey], newAnchor) {
+			t.Error("rolled-back secret does not hold the new anchor")
+		}
+
+		if err := m.RestorePrevious(context.Background(), obj); err != nil {
+			t.Fatalf("repeated RestorePrevious() error = %v", err)
+		}
+	}
+
+	obj.Spec.Linkerd.SingleSecretMode = &trv1alpha1.SingleSecretModeSpec{}
+	if err := m.RestorePrevious(context.Background(), obj); err == nil {
+		t.Error("RestorePrevious() error = nil in single-secret mode, want error")
+	}
+}
+
+func TestCleanupPreviousSingleSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	oldAnchor, newAnchor := selfSignedPEM(t, "old"), selfSignedPEM(t, "new")
+	tests := []struct {
+		name    string
+		data    map[string][]byte
+		wantErr bool
+	}{
+		{name: "previous key synced", data: map[string][]byte{secretDataKey: newAnchor, previousSecretDataKey: oldAnchor}},
+		{name: "previous key added", data: map[string][]byte{secretDataKey: newAnchor}},
+		{name: "no data", data: nil, wantErr: true},
+		{name: "current key missing", data: map[string][]byte{previousSecretDataKey: oldAnchor}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := testSecret(testCurrentSecret, nil)
+			secret.Data = tt.data
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			m := New(c, scheme, logr.Discard())
+
+			obj := testLTR(trv1alpha1.BootstrapModeIfMissing)
+			obj.Spec.Linkerd.SingleSecretMode = &trv1alpha1.SingleSecretModeSpec{}
+			err := m.CleanupPrevious(context.Background(), obj)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("CleanupPrevious() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CleanupPrevious() error = %v", err)
+			}
+
+			var cur v1.Secret
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(secret), &cur); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(cur.Data[previousSecretDataKey], newAnchor) {
+				t.Error("previous key does not hold the current anchor after cleanup")
+			}
+		})
+	}
+}
+
+func TestFingerprintAnchorMode(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	anchor := newTestCA(t, "root.linkerd.cluster.local", nil)
+	intermediate := newTestCA(t, "intermediate.linkerd.cluster.local", anchor)
+	chain := append(append([]byte{}, intermediate.pem...), anchor.pem...)
+	reordered := append(append([]byte{}, anchor.pem...), intermediate.pem...)
+
+	anchorFP, err := FingerprintAnchorCert(anchor.pem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainFP, err := FingerprintAnchorCert(chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chainFP != anchorFP {
+		t.Errorf("FingerprintAnchorCert(chain) = %s, want the anchor fingerprint %s", chainFP, anchorFP)
+	}
+	if _, err := FingerprintAnchorCert(intermediate.pem); err == nil {
+		t.Error("expected an error for a bundle without a self-signed root")
+	}
+
+	for _, mode := range []trv1alpha1.FingerprintMode{trv1alpha1.FingerprintModeChain, trv1alpha1.FingerprintModeAnchor} {
+		obj := testLTR(trv1alpha1.BootstrapModeIfMissing)
+		obj.Spec.Linkerd.FingerprintMode = mode
+
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(testSecret(testCurrentSecret, chain), testSecret(testPreviousSecret, reordered)).Build()
+		result, err := New(c, scheme, logr.Discard()).EnsureTrustSecrets(context.Background(), obj)
+		if err != nil {
+			t.Fatalf("%s: %v", mode, err)
+		}
+
+		wantDiverged := mode == trv1alpha1.FingerprintModeChain
+		if result.Diverged != wantDiverged {
+			t.Errorf("%s: Diverged = %v, want %v", mode, result.Diverged, wantDiverged)
+		}
+		if mode == trv1alpha1.FingerprintModeAnchor && result.CurrentFP != anchorFP {
+			t.Errorf("%s: CurrentFP = %s, want %s", mode, result.CurrentFP, anchorFP)
+		}
+	}
+}
+
+func TestFingerprintDERAnchor(t *testing.T) {
+	anchor := selfSignedPEM(t, "root.linkerd.cluster.local")
+	block, _ := pem.Decode(anchor)
+
+	pemFP, err := FingerprintPEMCerts(anchor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, fn := range map[string]func([]byte) (string, error){"chain": FingerprintPEMCerts, "anchor": FingerprintAnchorCert} {
+		derFP, err := fn(block.Bytes)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if derFP != pemFP {
+			t.Errorf("%s: DER fingerprint = %s, want the PEM fingerprint %s", name, derFP, pemFP)
+		}
+	}
+}
+
+func TestEnsureTrustSecretsCertCount(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	anchor := selfSignedPEM(t, "anchor")
+	withLeaf := append(append([]byte{}, anchor...), selfSignedPEM(t, "leaf")...)
+
+	tests := []struct {
+		name     string
+		min, max int32
+		current  []byte
+		wantErr  bool
+	}{
+		{name: "no constraint", current: withLeaf},
+		{name: "exactly one", min: 1, max: 1, current: anchor},
+		{name: "appended leaf exceeds max", max: 1, current: withLeaf, wantErr: true},
+		{name: "below min", min: 2, current: anchor, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := testLTR(trv1alpha1.BootstrapModeIfMissing)
+			obj.Spec.Linkerd.MinCerts, obj.Spec.Linkerd.MaxCerts = tt.min, tt.max
+
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(testSecret(testCurrentSecret, tt.current), testSecret(testPreviousSecret, anchor)).Build()
+			_, err := New(c, scheme, logr.Discard()).EnsureTrustSecrets(context.Background(), obj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EnsureTrustSecrets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnsureTrustSecretsCurrentMissing(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	_, err := New(c, scheme, logr.Discard()).EnsureTrustSecrets(context.Background(), testLTR(trv1alpha1.BootstrapModeIfMissing))
+	if !errors.Is(err, ErrCurrentSecretMissing) {
+		t.Fatalf("EnsureTrustSecrets() error = %v, want ErrCurrentSecretMissing", err)
+	}
+
+	obj := testLTR(trv1alpha1.BootstrapModeIfMissing)
+	if got := CurrentSecretTimeout(obj); got != defaultCurrentSecretTimeout {
+		t.Errorf("CurrentSecretTimeout() = %s, want default %s", got, defaultCurrentSecretTimeout)
+	}
+
+	obj.Spec.Linkerd.CurrentSecretTimeout = &metav1.Duration{Duration: time.Minute}
+	if got := CurrentSecretTimeout(obj); got != time.Minute {
+		t.Errorf("CurrentSecretTimeout() = %s, want 1m", got)
+	}
+}
@@ -0,0 +1,101 @@
+package secret
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/certutil"
+)
+
+const issuerPollInterval = 2 * time.Second
+
+// WaitIssuerSignedByAnchor waits (up to timeout) for the identity issuer secret to exist and
+// verifies that its certificate chains to the trust anchor in anchorPEM. Call it after the issuer
+// was re-issued and before the control plane restarts, so an unrelated anchor never reaches proxies.
+func (m *ManageSecret) WaitIssuerSignedByAnchor(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation,
+	issuerSecret string, anchorPEM []byte, timeout time.Duration) error {
+	key := types.NamespacedName{Namespace: obj.Spec.Linkerd.Namespace, Name: issuerSecret}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		secret := &v1.Secret{}
+		err := m.Client.Get(ctx, key, secret)
+		if err == nil {
+			return VerifyIssuerChain(secret.Data[v1.TLSCertKey], anchorPEM)
+		}
+
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("get issuer secret %s: %w", key.String(), err)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for issuer secret %s to be re-issued", key.String())
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(issuerPollInterval):
+		}
+	}
+}
+
+// IssuerSignedByBundle verifies that the identity issuer secret exists and its certificate chains to one of
+// the anchors in bundlePEM, without waiting for a re-issue.
+func (m *ManageSecret) IssuerSignedByBundle(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation,
+	issuerSecret string, bundlePEM []byte) error {
+	key := types.NamespacedName{Namespace: obj.Spec.Linkerd.Namespace, Name: issuerSecret}
+
+	secret := &v1.Secret{}
+	if err := m.Client.Get(ctx, key, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("issuer secret %s not found", key.String())
+		}
+		return fmt.Errorf("get issuer secret %s: %w", key.String(), err)
+	}
+
+	return VerifyIssuerChain(secret.Data[v1.TLSCertKey], bundlePEM)
+}
+
+// VerifyIssuerChain verifies that the first certificate in issuerPEM chains to one of the anchors in anchorPEM.
+// Further certificates in issuerPEM are used as intermediates.
+func VerifyIssuerChain(issuerPEM, anchorPEM []byte) error {
+	issuerCerts, err := certutil.Parse(issuerPEM)
+	if err != nil {
+		return fmt.Errorf("issuer certificate: %w", err)
+	}
+
+	anchors, err := certutil.Parse(anchorPEM)
+	if err != nil {
+		return fmt.Errorf("trust anchor: %w", err)
+	}
+
+	roots := x509.NewCertPool()
+	for _, c := range anchors {
+		roots.AddCert(c)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, c := range issuerCerts[1:] {
+		intermediates.AddCert(c)
+	}
+
+	issuer := issuerCerts[0]
+	if _, err := issuer.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return fmt.Errorf("issuer certificate %q (issued by %q) is not signed by the current trust anchor %q: %w",
+			issuer.Subject.CommonName, issuer.Issuer.CommonName, anchors[0].Subject.CommonName, err)
+	}
+
+	return nil
+}
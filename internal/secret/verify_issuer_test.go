@@ -0,0 +1,21 @@
+package secret
+
+import "testing"
+
+func TestVerifyIssuerChain(t *testing.T) {
+	anchor := newTestCA(t, "root.linkerd.cluster.local", nil)
+	unrelated := newTestCA(t, "root.linkerd.cluster.local", nil)
+	issuer := newTestCA(t, "identity.linkerd.cluster.local", anchor)
+
+	if err := VerifyIssuerChain(issuer.pem, anchor.pem); err != nil {
+		t.Fatalf("issuer signed by the anchor: %v", err)
+	}
+
+	if err := VerifyIssuerChain(issuer.pem, unrelated.pem); err == nil {
+		t.Fatal("expected an error for an issuer not signed by the anchor")
+	}
+
+	if err := VerifyIssuerChain(nil, anchor.pem); err == nil {
+		t.Fatal("expected an error for a missing issuer certificate")
+	}
+}
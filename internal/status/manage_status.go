@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -11,9 +12,11 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+	"linkerd-trust-rotator.operators.infra/internal/metrics"
 )
 
 // ManageStatus updates CR status via the status subresource.
@@ -75,10 +78,73 @@ func (m *ManageStatus) Patch(ctx context.Context, obj *trv1alpha1.LinkerdTrustRo
 	return m.Client.Status().Patch(ctx, obj, client.MergeFrom(oldObj))
 }
 
+// patchPhase is Patch for mutations that may change the phase: on a phase change it adds the time
+// spent in the phase being left to status.phaseDurations and to the phase duration metric.
+func (m *ManageStatus) patchPhase(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, processName string,
+	mutate func(st *trv1alpha1.LinkerdTrustRotationStatus)) error {
+	var (
+		left  *trv1alpha1.Phase
+		spent time.Duration
+	)
+
+	if err := m.Patch(ctx, obj, processName, func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		prev := st.Phase
+		mutate(st)
+		left, spent = trackPhase(st, prev, time.Now().UTC())
+	}); err != nil {
+		return err
+	}
+
+	if left != nil {
+		metrics.ObservePhaseDuration(string(*left), spent)
+	}
+
+	return nil
+}
+
+// trackPhase stamps the entry time when st.Phase moves to another phase than st.TrackedPhase and accumulates
+// the time spent in the phase left. Idle is the reset at the start of every reconcile rather than the end of
+// a phase, so it is not tracked: a reconcile returning to the tracked phase continues it. trackPhase returns
+// the timed phase left and its duration, or nil for untimed phases (Idle and terminal ones).
+func trackPhase(st *trv1alpha1.LinkerdTrustRotationStatus, prev *trv1alpha1.Phase, now time.Time) (*trv1alpha1.Phase, time.Duration) {
+	if st.Phase == nil || *st.Phase == trv1alpha1.PhaseIdle || *st.Phase == st.TrackedPhase ||
+		(prev != nil && *prev == *st.Phase) {
+		return nil, 0
+	}
+
+	left, entered := st.TrackedPhase, st.PhaseEnteredAt
+	at := metav1.NewTime(now)
+	st.PhaseEnteredAt = &at
+	st.TrackedPhase = *st.Phase
+
+	if left == "" || entered == nil || !timedPhase(left) {
+		return nil, 0
+	}
+
+	spent := now.Sub(entered.Time)
+	if st.PhaseDurations == nil {
+		st.PhaseDurations = map[string]metav1.Duration{}
+	}
+	total := st.PhaseDurations[string(left)]
+	st.PhaseDurations[string(left)] = metav1.Duration{Duration: total.Duration + spent}
+
+	return &left, spent
+}
+
+// timedPhase reports whether time spent in the phase belongs to a rotation.
+func timedPhase(p trv1alpha1.Phase) bool {
+	switch p {
+	case trv1alpha1.PhaseIdle, trv1alpha1.PhaseDryRun, trv1alpha1.PhaseSucceeded, trv1alpha1.PhaseFailed:
+		return false
+	default:
+		return true
+	}
+}
+
 // SetPhase sets the high-level phase, with optional reason/message.
 func (m *ManageStatus) SetPhase(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation,
 	phase *trv1alpha1.Phase, reason *trv1alpha1.Reason, message *string) error {
-	return m.Patch(ctx, obj, "SetPhase", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+	return m.patchPhase(ctx, obj, "SetPhase", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
 		st.Phase = phase
 		st.Reason = reason
 		st.Message = message
@@ -89,7 +155,7 @@ func (m *ManageStatus) SetPhase(ctx context.Context, obj *trv1alpha1.LinkerdTrus
 func (m *ManageStatus) SetProgress(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, cpReady bool, current, total *int) error {
 	percent := 0
 	if current != nil && total != nil {
-		percent = calcPercent(*current, *total)
+		percent = calcPercent(*current, *total, obj.Spec.Rollout.ProgressRounding)
 	}
 	return m.Patch(ctx, obj, "SetProgress", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
 		st.Progress = &trv1alpha1.ProgressStatus{
@@ -99,7 +165,9 @@ func (m *ManageStatus) SetProgress(ctx context.Context, obj *trv1alpha1.LinkerdT
 	})
 }
 
-func calcPercent(current, total int) int {
+// calcPercent returns current/total as a percentage in [0, 100], rounded per mode.
+// 100 is only returned when current == total, so rounding never reports completion early.
+func calcPercent(current, total int, mode trv1alpha1.ProgressRounding) int {
 	if total <= 0 {
 		return 0
 	}
@@ -108,41 +176,62 @@ func calcPercent(current, total int) int {
 		current = 0
 	}
 
-	if current > total {
-		current = total
-	}
-	// round to nearest integer
-	p := int(math.Round(float64(current) * 100.0 / float64(total)))
-	if p < 0 {
-		return 0
+	if current >= total {
+		return 100
 	}
 
-	if p > 100 {
-		return 100
+	ratio := float64(current) * 100.0 / float64(total)
+	p := int(math.Round(ratio))
+	if mode == trv1alpha1.ProgressRoundingFloor {
+		p = int(math.Floor(ratio))
 	}
 
-	return p
+	return min(p, 99)
 }
 
 // SetTrustInfo sets bundle state and fingerprints.
 func (m *ManageStatus) SetTrustInfo(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, bundleState *trv1alpha1.BundleState, currentFP, previousFP string) error {
+	n := int(obj.Spec.Linkerd.FingerprintShortLength)
 	return m.Patch(ctx, obj, "SetTrustInfo", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
 		st.Trust = &trv1alpha1.TrustStatus{
-			BundleState: bundleState,
-			CurrentFP:   currentFP,
-			PreviousFP:  previousFP,
+			BundleState:    bundleState,
+			CurrentFP:      ShortFP(currentFP, n),
+			PreviousFP:     ShortFP(previousFP, n),
+			CurrentFullFP:  currentFP,
+			PreviousFullFP: previousFP,
 		}
 	})
 }
 
-// SetPlanHash updates plan hash state.
-func (m *ManageStatus) SetPlanHash(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, workRef *trv1alpha1.WorkRef, next, total int, hash string) error {
+// ShortFP shortens a "sha256:<hex>" fingerprint to its first n hex characters, keeping the prefix;
+// n <= 0 returns fp unchanged.
+func ShortFP(fp string, n int) string {
+	prefix, hex, ok := strings.Cut(fp, ":")
+	if !ok {
+		prefix, hex = "", fp
+	} else {
+		prefix += ":"
+	}
+
+	if n <= 0 || len(hex) <= n {
+		return fp
+	}
+
+	return prefix + hex[:n]
+}
+
+// SetPlanHash updates plan hash state (with the version of the hashing scheme) and the workloads completed so far.
+func (m *ManageStatus) SetPlanHash(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, workRef *trv1alpha1.WorkRef,
+	next, total int, hash string, hashVersion int, completed []trv1alpha1.WorkRef) error {
+	completed = append([]trv1alpha1.WorkRef(nil), completed...)
 	return m.Patch(ctx, obj, "SetPlanHash", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
 		st.Cursor = &trv1alpha1.RolloutCursor{
-			PlanHash: hash,
-			Next:     next,
-			Total:    total,
-			LastDone: workRef,
+			PlanHash:        hash,
+			PlanHashVersion: hashVersion,
+			Next:            next,
+			Total:           total,
+			LastDone:        workRef,
+			Completed:       completed,
 		}
 	})
 }
@@ -164,25 +253,266 @@ func (m *ManageStatus) SetRetry(ctx context.Context, obj *trv1alpha1.LinkerdTrus
 	})
 }
 
-// SetDryRunOutput sets the human-readable output of the last dry run.
-func (m *ManageStatus) SetDryRunOutput(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, dryRunOutput string) error {
-	return m.Patch(ctx, obj, "SetDryRunOutput", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+// SetReconcileError records the last reconcile error with a timestamp; an empty message clears it.
+func (m *ManageStatus) SetReconcileError(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, message string) error {
+	var now *metav1.Time
+	if len(message) > 0 {
+		t := metav1.NewTime(time.Now().UTC())
+		now = &t
+	}
+
+	return m.Patch(ctx, obj, "SetReconcileError", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		if len(message) > 0 && st.LastReconcileError == message {
+			// keep the time of the first occurrence for repeated errors
+			return
+		}
+
+		st.LastReconcileError = message
+		st.LastReconcileErrorTime = now
+	})
+}
+
+// SetWorkloadCounts updates the planned and/or completed per-kind counters; nil arguments are left unchanged.
+func (m *ManageStatus) SetWorkloadCounts(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, planned, completed *trv1alpha1.WorkloadCounts) error {
+	return m.Patch(ctx, obj, "SetWorkloadCounts", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		if planned != nil {
+			p := *planned
+			st.PlannedCounts = &p
+		}
+		if completed != nil {
+			c := *completed
+			st.CompletedCounts = &c
+		}
+	})
+}
+
+// StartRotation records the start of a rotation for the trust anchor fingerprint fp: unless a rotation for fp
+// is already in progress (started and not yet completed, or failed after deleting the issuer secret), it
+// generates a new rotation ID, resets the timestamps and clears the per-rotation progress. The cursor and
+// retries are also dropped when the previous rotation was for another anchor.
+func (m *ManageStatus) StartRotation(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, fp string) error {
+	if RotationInProgress(&obj.Status, fp) {
+		return nil
+	}
+
+	anchorChanged := obj.Status.RotationFP != fp
+	now := metav1.NewTime(time.Now().UTC())
+	id := string(uuid.NewUUID())
+	return m.Patch(ctx, obj, "StartRotation", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.RotationID = id
+		st.RotationFP = fp
+		st.StartedAt = &now
+		st.CompletionTime = nil
+		st.PhaseDurations = nil
+		// A phase tracked before the start, e.g. an abandoned Detecting, belongs to no rotation.
+		st.TrackedPhase = ""
+		st.ControlPlaneDone = false
+		st.ControlPlaneDoneAt = nil
+		st.IssuerSecretDeleted = false
+		st.OngoingRolloutsDeferredSince = nil
+		if anchorChanged {
+			st.Cursor = nil
+			st.Retries = nil
+		}
+	})
+}
+
+// RotationInProgress reports whether a rotation for the trust anchor fingerprint fp was started and not yet
+// completed, or failed after deleting the issuer secret; StartRotation then keeps its ID instead of starting
+// a new one. A rotation recorded without a fingerprint (before status.rotationFP existed) matches any fp.
+func RotationInProgress(st *trv1alpha1.LinkerdTrustRotationStatus, fp string) bool {
+	if st.RotationFP != "" && st.RotationFP != fp {
+		return false
+	}
+
+	return st.RotationID != "" && st.StartedAt != nil &&
+		(st.IssuerSecretDeleted || st.ControlPlaneDone || st.CompletionTime == nil || st.CompletionTime.Before(st.StartedAt))
+}
+
+// SetControlPlaneDone records whether the control-plane phase of the current rotation has completed, and when.
+func (m *ManageStatus) SetControlPlaneDone(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, done bool) error {
+	var at *metav1.Time
+	if done {
+		now := metav1.NewTime(time.Now().UTC())
+		at = &now
+	}
+
+	return m.Patch(ctx, obj, "SetControlPlaneDone", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.ControlPlaneDone = done
+		st.ControlPlaneDoneAt = at
+	})
+}
+
+// SetIssuerSecretDeleted records whether the issuer secret has been deleted in the current rotation.
+func (m *ManageStatus) SetIssuerSecretDeleted(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, deleted bool) error {
+	return m.Patch(ctx, obj, "SetIssuerSecretDeleted", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.IssuerSecretDeleted = deleted
+	})
+}
+
+// SetRollbackPending records that a rollback restored the previous anchor and still has to restart the control plane.
+func (m *ManageStatus) SetRollbackPending(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation) error {
+	return m.Patch(ctx, obj, "SetRollbackPending", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.RollbackPending = true
+	})
+}
+
+// SetRotatedFP records the trust anchor fingerprint the control and/or data plane were rotated for;
+// nil arguments are left unchanged.
+func (m *ManageStatus) SetRotatedFP(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, controlPlane, dataPlane *string) error {
+	return m.Patch(ctx, obj, "SetRotatedFP", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		if controlPlane != nil {
+			st.ControlPlaneRotatedFP = *controlPlane
+		}
+		if dataPlane != nil {
+			st.DataPlaneRotatedFP = *dataPlane
+		}
+	})
+}
+
+// SetMeshProxyCheckedFP records the trust anchor fingerprint the mesh-wide proxy check passed for.
+func (m *ManageStatus) SetMeshProxyCheckedFP(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, fp string) error {
+	return m.Patch(ctx, obj, "SetMeshProxyCheckedFP", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.MeshProxyCheckedFP = fp
+	})
+}
+
+// SetDivergedSince records when the secret divergence was first observed; nil clears it.
+func (m *ManageStatus) SetDivergedSince(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, since *metav1.Time) error {
+	return m.Patch(ctx, obj, "SetDivergedSince", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.DivergedSince = since
+	})
+}
+
+// SetCurrentSecretMissingSince records when the current trust anchor secret was first found missing; nil clears it.
+func (m *ManageStatus) SetCurrentSecretMissingSince(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, since *metav1.Time) error {
+	return m.Patch(ctx, obj, "SetCurrentSecretMissingSince", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.CurrentSecretMissingSince = since
+	})
+}
+
+// SetOngoingRolloutsDeferredSince records when mid-rollout Deployments were first deferred; nil clears it.
+func (m *ManageStatus) SetOngoingRolloutsDeferredSince(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation,
+	since *metav1.Time) error {
+	return m.Patch(ctx, obj, "SetOngoingRolloutsDeferredSince", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.OngoingRolloutsDeferredSince = since
+	})
+}
+
+// SetCleanedUpAt records when the previous anchor was cleaned up; nil clears it once the hold is over.
+func (m *ManageStatus) SetCleanedUpAt(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, at *metav1.Time) error {
+	return m.Patch(ctx, obj, "SetCleanedUpAt", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.CleanedUpAt = at
+	})
+}
+
+// SetEffectiveConfig records the configuration resolved for the CR after defaults.
+func (m *ManageStatus) SetEffectiveConfig(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, cfg *trv1alpha1.EffectiveConfig) error {
+	return m.Patch(ctx, obj, "SetEffectiveConfig", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.EffectiveConfig = cfg
+	})
+}
+
+// SetObservedHashes records the bundle and secret fingerprint hashes acted upon; empty values are left unchanged.
+func (m *ManageStatus) SetObservedHashes(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, bundleHash, secretHash string) error {
+	return m.Patch(ctx, obj, "SetObservedHashes", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		if bundleHash != "" {
+			st.LastObservedBundleHash = bundleHash
+		}
+		if secretHash != "" {
+			st.LastObservedSecretHash = secretHash
+		}
+	})
+}
+
+// RecordSkippedTrigger counts a detected trust change that needed no action and records why. hash identifies
+// the skipped trust state: a trigger already counted with the same reason and hash is not counted (or
+// written) again, so a skip seen by every requeue counts once.
+func (m *ManageStatus) RecordSkippedTrigger(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation,
+	reason trv1alpha1.Reason, hash string) error {
+	if obj.Status.LastSkipReason == reason && obj.Status.LastSkippedHash == hash {
+		return nil
+	}
+
+	return m.Patch(ctx, obj, "RecordSkippedTrigger", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.SkippedTriggerCount++
+		st.LastSkipReason = reason
+		st.LastSkippedHash = hash
+	})
+}
+
+// SetVerification records the outcome of a verify-only reconcile and reports it in the Verifying phase.
+func (m *ManageStatus) SetVerification(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, verification *trv1alpha1.VerificationStatus) error {
+	return m.patchPhase(ctx, obj, "SetVerification", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.Phase = PhasePtr(trv1alpha1.PhaseVerifying)
+		st.Reason = ReasonPtr(trv1alpha1.ReasonVerificationSucceeded)
+		st.Message = StringPtr("Verify-only check passed: the trust state is consistent and linkerd check succeeded")
+		if !verification.Healthy {
+			st.Reason = ReasonPtr(trv1alpha1.ReasonVerificationFailed)
+			st.Message = StringPtr(fmt.Sprintf("Verify-only check found %d problem(s): %s",
+				len(verification.Findings), strings.Join(verification.Findings, "; ")))
+		}
+		st.Verification = verification
+	})
+}
+
+// SetDryRunOutput sets the human-readable output of the last dry run, its plan hash and estimated duration.
+func (m *ManageStatus) SetDryRunOutput(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, dryRunOutput, planHash string,
+	estimate time.Duration) error {
+	return m.patchPhase(ctx, obj, "SetDryRunOutput", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
 		st.Phase = PhasePtr(trv1alpha1.PhaseDryRun)
 		st.Reason = ReasonPtr(trv1alpha1.ReasonDryRun)
 		st.Message = StringPtr("The data-plane dry run has completed successfully")
 		st.DryRunPlan = dryRunOutput
+		st.DryRunPlanHash = planHash
+		st.DryRunEstimatedDuration = &metav1.Duration{Duration: estimate}
 		st.Progress = nil
 	})
 }
 
+// AwaitPlanApproval pauses the rotation because the data-plane plan changed since it was approved,
+// publishing the new plan and its hash for review.
+func (m *ManageStatus) AwaitPlanApproval(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation,
+	planOutput, planHash, message string) error {
+	return m.patchPhase(ctx, obj, "AwaitPlanApproval", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.Phase = PhasePtr(trv1alpha1.PhaseAwaitingApproval)
+		st.Reason = ReasonPtr(trv1alpha1.ReasonPlanChanged)
+		st.Message = &message
+		st.DryRunPlan = planOutput
+		st.DryRunPlanHash = planHash
+	})
+}
+
 // MarkSucceeded marks completion and sets Succeeded phase.
 func (m *ManageStatus) MarkSucceeded(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, message string) error {
 	now := metav1.NewTime(time.Now().UTC())
-	return m.Patch(ctx, obj, "MarkSucceeded", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+	return m.patchPhase(ctx, obj, "MarkSucceeded", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
 		st.Phase = PhasePtr("Succeeded")
 		st.Reason = ReasonPtr("Completed")
 		st.Message = &message
 		st.CompletionTime = &now
+		st.ControlPlaneDone = false
+		st.ControlPlaneDoneAt = nil
+		st.IssuerSecretDeleted = false
+	})
+}
+
+// MarkRolledBack marks a failed rotation rolled back to the previous anchor and resets its progress,
+// so the next anchor change starts a fresh rotation.
+func (m *ManageStatus) MarkRolledBack(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation, message string) error {
+	now := metav1.NewTime(time.Now().UTC())
+	return m.patchPhase(ctx, obj, "MarkRolledBack", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+		st.Phase = PhasePtr(trv1alpha1.PhaseFailed)
+		st.Reason = ReasonPtr(trv1alpha1.ReasonRolledBack)
+		st.Message = &message
+		st.CompletionTime = &now
+		st.ControlPlaneDone = false
+		st.ControlPlaneDoneAt = nil
+		st.IssuerSecretDeleted = false
+		st.RollbackPending = false
+		st.CleanedUpAt = nil
+		st.Retries = nil
+		st.Cursor = nil
 	})
 }
 
@@ -190,7 +520,7 @@ func (m *ManageStatus) MarkSucceeded(ctx context.Context, obj *trv1alpha1.Linker
 func (m *ManageStatus) MarkFailed(ctx context.Context, obj *trv1alpha1.LinkerdTrustRotation,
 	reason trv1alpha1.Reason, message string) error {
 	now := metav1.NewTime(time.Now().UTC())
-	return m.Patch(ctx, obj, "MarkFailed", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
+	return m.patchPhase(ctx, obj, "MarkFailed", func(st *trv1alpha1.LinkerdTrustRotationStatus) {
 		st.Phase = PhasePtr("Failed")
 		st.Reason = &reason
 		st.Message = &message
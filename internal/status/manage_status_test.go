@@ -0,0 +1,149 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trv1alpha1 "linkerd-trust-rotator.operators.infra/api/v1alpha1"
+)
+
+func TestCalcPercent(t *testing.T) {
+	tests := []struct {
+		current, total int
+		nearest, floor int
+	}{
+		{current: 0, total: 0, nearest: 0, floor: 0},
+		{current: 0, total: 3, nearest: 0, floor: 0},
+		{current: 1, total: 3, nearest: 33, floor: 33},
+		{current: 2, total: 3, nearest: 67, floor: 66},
+		{current: 3, total: 3, nearest: 100, floor: 100},
+		{current: 4, total: 3, nearest: 100, floor: 100},
+		{current: -1, total: 3, nearest: 0, floor: 0},
+		{current: 199, total: 200, nearest: 99, floor: 99},
+		{current: 995, total: 1000, nearest: 99, floor: 99},
+		{current: 994, total: 1000, nearest: 99, floor: 99},
+		{current: 1, total: 200, nearest: 1, floor: 0},
+		{current: 1000, total: 1000, nearest: 100, floor: 100},
+	}
+
+	for _, tt := range tests {
+		if got := calcPercent(tt.current, tt.total, trv1alpha1.ProgressRoundingNearest); got != tt.nearest {
+			t.Errorf("calcPercent(%d, %d, Nearest) = %d, want %d", tt.current, tt.total, got, tt.nearest)
+		}
+
+		if got := calcPercent(tt.current, tt.total, ""); got != tt.nearest {
+			t.Errorf("calcPercent(%d, %d, default) = %d, want %d", tt.current, tt.total, got, tt.nearest)
+		}
+
+		if got := calcPercent(tt.current, tt.total, trv1alpha1.ProgressRoundingFloor); got != tt.floor {
+			t.Errorf("calcPercent(%d, %d, Floor) = %d, want %d", tt.current, tt.total, got, tt.floor)
+		}
+	}
+}
+
+func TestTrackPhase(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	st := &trv1alpha1.LinkerdTrustRotationStatus{}
+
+	enter := func(p trv1alpha1.Phase, at time.Time) (*trv1alpha1.Phase, time.Duration) {
+		prev := st.Phase
+		st.Phase = PhasePtr(p)
+		return trackPhase(st, prev, at)
+	}
+
+	if left, _ := enter(trv1alpha1.PhaseIdle, start); left != nil {
+		t.Fatalf("first phase must not be timed, got %s", *left)
+	}
+
+	// Idle is not timed.
+	if left, _ := enter(trv1alpha1.PhaseRollingControlPlane, start.Add(time.Hour)); left != nil {
+		t.Fatalf("Idle must not be timed, got %s", *left)
+	}
+
+	left, spent := enter(trv1alpha1.PhaseRollingDataPlane, start.Add(time.Hour+2*time.Minute))
+	if left == nil || *left != trv1alpha1.PhaseRollingControlPlane || spent != 2*time.Minute {
+		t.Fatalf("left %v after %s, want RollingCP after 2m", left, spent)
+	}
+
+	// Staying in a phase changes nothing.
+	if left, _ := enter(trv1alpha1.PhaseRollingDataPlane, start.Add(time.Hour+3*time.Minute)); left != nil {
+		t.Fatalf("unchanged phase must not be timed, got %s", *left)
+	}
+
+	// The Idle reset of every reconcile neither leaves the phase nor restarts its clock.
+	for i := range 3 {
+		at := start.Add(time.Hour + time.Duration(4+i)*time.Minute)
+		if left, _ := enter(trv1alpha1.PhaseIdle, at); left != nil {
+			t.Fatalf("entering Idle left %s", *left)
+		}
+		if left, _ := enter(trv1alpha1.PhaseRollingDataPlane, at.Add(time.Second)); left != nil {
+			t.Fatalf("returning to the tracked phase left %s", *left)
+		}
+	}
+	if got := st.PhaseEnteredAt.Time; !got.Equal(start.Add(time.Hour + 2*time.Minute)) {
+		t.Fatalf("phaseEnteredAt %s, want the first entry of RollingDP", got)
+	}
+
+	enter(trv1alpha1.PhaseIdle, start.Add(time.Hour+9*time.Minute))
+	left, spent = enter(trv1alpha1.PhaseSucceeded, start.Add(time.Hour+10*time.Minute))
+	if left == nil || *left != trv1alpha1.PhaseRollingDataPlane || spent != 8*time.Minute {
+		t.Fatalf("left %v after %s, want RollingDP after 8m", left, spent)
+	}
+
+	want := map[string]time.Duration{"RollingCP": 2 * time.Minute, "RollingDP": 8 * time.Minute}
+	for phase, d := range want {
+		if got := st.PhaseDurations[phase].Duration; got != d {
+			t.Errorf("%s: got %s, want %s", phase, got, d)
+		}
+	}
+}
+
+func TestShortFP(t *testing.T) {
+	const fp = "sha256:0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		fp   string
+		n    int
+		want string
+	}{
+		{fp, 0, fp},
+		{fp, 16, "sha256:0123456789abcdef"},
+		{fp, 64, fp},
+		{"0123456789abcdef", 4, "0123"},
+		{"", 16, ""},
+	}
+
+	for _, tt := range tests {
+		if got := ShortFP(tt.fp, tt.n); got != tt.want {
+			t.Errorf("ShortFP(%q, %d) = %q, want %q", tt.fp, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestRotationInProgress(t *testing.T) {
+	started := metav1.NewTime(time.Now().Add(-time.Hour))
+	completed := metav1.NewTime(time.Now())
+	failed := trv1alpha1.LinkerdTrustRotationStatus{
+		RotationID: "r1", RotationFP: "fp-a", StartedAt: &started, CompletionTime: &completed, ControlPlaneDone: true,
+	}
+
+	if !RotationInProgress(&failed, "fp-a") {
+		t.Error("failed rotation past the control plane is not resumed for its anchor")
+	}
+	if RotationInProgress(&failed, "fp-b") {
+		t.Error("failed rotation is resumed for another anchor")
+	}
+
+	legacy := failed
+	legacy.RotationFP = ""
+	if !RotationInProgress(&legacy, "fp-b") {
+		t.Error("rotation without a recorded fingerprint is not resumed")
+	}
+
+	done := failed
+	done.ControlPlaneDone = false
+	if RotationInProgress(&done, "fp-a") {
+		t.Error("completed rotation reported in progress")
+	}
+}